@@ -0,0 +1,1442 @@
+package schemax
+
+/*
+asn1.go implements the export and import of definitions by way of the
+ASN.1 information object notation of ITU-T Rec. X.501.
+*/
+
+/*
+asn1Types maps the numeric OIDs of RFC 4517 syntaxes to the ASN.1 types
+-- per ITU-T Rec. X.520 and X.509 where applicable -- of their values.
+*/
+var asn1Types = map[string]string{
+	`1.3.6.1.4.1.1466.115.121.1.6`:  `BIT STRING`,
+	`1.3.6.1.4.1.1466.115.121.1.7`:  `BOOLEAN`,
+	`1.3.6.1.4.1.1466.115.121.1.8`:  `Certificate`,
+	`1.3.6.1.4.1.1466.115.121.1.9`:  `CertificateList`,
+	`1.3.6.1.4.1.1466.115.121.1.10`: `CertificatePair`,
+	`1.3.6.1.4.1.1466.115.121.1.11`: `CountryName`,
+	`1.3.6.1.4.1.1466.115.121.1.12`: `DistinguishedName`,
+	`1.3.6.1.4.1.1466.115.121.1.14`: `PreferredDeliveryMethod`,
+	`1.3.6.1.4.1.1466.115.121.1.15`: `UnboundedDirectoryString`,
+	`1.3.6.1.4.1.1466.115.121.1.21`: `EnhancedGuide`,
+	`1.3.6.1.4.1.1466.115.121.1.22`: `FacsimileTelephoneNumber`,
+	`1.3.6.1.4.1.1466.115.121.1.24`: `GeneralizedTime`,
+	`1.3.6.1.4.1.1466.115.121.1.25`: `Guide`,
+	`1.3.6.1.4.1.1466.115.121.1.26`: `IA5String`,
+	`1.3.6.1.4.1.1466.115.121.1.27`: `INTEGER`,
+	`1.3.6.1.4.1.1466.115.121.1.34`: `NameAndOptionalUID`,
+	`1.3.6.1.4.1.1466.115.121.1.36`: `NumericString`,
+	`1.3.6.1.4.1.1466.115.121.1.38`: `OBJECT IDENTIFIER`,
+	`1.3.6.1.4.1.1466.115.121.1.40`: `OCTET STRING`,
+	`1.3.6.1.4.1.1466.115.121.1.41`: `PostalAddress`,
+	`1.3.6.1.4.1.1466.115.121.1.44`: `PrintableString`,
+	`1.3.6.1.4.1.1466.115.121.1.50`: `TelephoneNumber`,
+	`1.3.6.1.4.1.1466.115.121.1.52`: `TelexNumber`,
+	`1.3.6.1.4.1.1466.115.121.1.53`: `UTCTime`,
+	`1.3.6.1.4.1.1466.115.121.1.58`: `SubstringAssertion`,
+}
+
+/*
+asn1Sized contains the ASN.1 types of asn1Types which may bear a size
+constraint, through which a minimum upper bound is conveyed.
+*/
+var asn1Sized = map[string]bool{
+	`BIT STRING`:      true,
+	`IA5String`:       true,
+	`NumericString`:   true,
+	`OCTET STRING`:    true,
+	`PrintableString`: true,
+}
+
+/*
+asn1SyntaxNames maps the references of the SYNTAX-NAME information objects
+of ITU-T Rec. X.520, which describe the syntaxes of RFC 4517, to the numeric
+OIDs thereof.  These are consulted when an LDAP-SYNTAX field bears a
+reference of the form "directoryString.&id" which is not assigned within
+the module at hand.
+*/
+var asn1SyntaxNames = map[string]string{
+	`attributeTypeDescription`:    `1.3.6.1.4.1.1466.115.121.1.3`,
+	`bitString`:                   `1.3.6.1.4.1.1466.115.121.1.6`,
+	`boolean`:                     `1.3.6.1.4.1.1466.115.121.1.7`,
+	`countryString`:               `1.3.6.1.4.1.1466.115.121.1.11`,
+	`dn`:                          `1.3.6.1.4.1.1466.115.121.1.12`,
+	`deliveryMethod`:              `1.3.6.1.4.1.1466.115.121.1.14`,
+	`directoryString`:             `1.3.6.1.4.1.1466.115.121.1.15`,
+	`dITContentRuleDescription`:   `1.3.6.1.4.1.1466.115.121.1.16`,
+	`dITStructureRuleDescription`: `1.3.6.1.4.1.1466.115.121.1.17`,
+	`enhancedGuide`:               `1.3.6.1.4.1.1466.115.121.1.21`,
+	`facsimileTelephoneNr`:        `1.3.6.1.4.1.1466.115.121.1.22`,
+	`fax`:                         `1.3.6.1.4.1.1466.115.121.1.23`,
+	`generalizedTime`:             `1.3.6.1.4.1.1466.115.121.1.24`,
+	`guide`:                       `1.3.6.1.4.1.1466.115.121.1.25`,
+	`ia5String`:                   `1.3.6.1.4.1.1466.115.121.1.26`,
+	`integer`:                     `1.3.6.1.4.1.1466.115.121.1.27`,
+	`jpeg`:                        `1.3.6.1.4.1.1466.115.121.1.28`,
+	`matchingRuleDescription`:     `1.3.6.1.4.1.1466.115.121.1.30`,
+	`matchingRuleUseDescription`:  `1.3.6.1.4.1.1466.115.121.1.31`,
+	`nameAndOptionalUID`:          `1.3.6.1.4.1.1466.115.121.1.34`,
+	`nameFormDescription`:         `1.3.6.1.4.1.1466.115.121.1.35`,
+	`numericString`:               `1.3.6.1.4.1.1466.115.121.1.36`,
+	`objectClassDescription`:      `1.3.6.1.4.1.1466.115.121.1.37`,
+	`oid`:                         `1.3.6.1.4.1.1466.115.121.1.38`,
+	`otherMailbox`:                `1.3.6.1.4.1.1466.115.121.1.39`,
+	`octetString`:                 `1.3.6.1.4.1.1466.115.121.1.40`,
+	`postalAddr`:                  `1.3.6.1.4.1.1466.115.121.1.41`,
+	`printableString`:             `1.3.6.1.4.1.1466.115.121.1.44`,
+	`telephoneNr`:                 `1.3.6.1.4.1.1466.115.121.1.50`,
+	`telexNr`:                     `1.3.6.1.4.1.1466.115.121.1.52`,
+	`utcTime`:                     `1.3.6.1.4.1.1466.115.121.1.53`,
+	`ldapSyntaxDescription`:       `1.3.6.1.4.1.1466.115.121.1.54`,
+	`substringAssertion`:          `1.3.6.1.4.1.1466.115.121.1.58`,
+}
+
+/*
+asn1Phrases contains the keyword phrases of the WITH SYNTAX clause of
+each supported X.501 information object class.  Phrases which have no
+LDAP counterpart are recognized, but otherwise ignored.
+*/
+var asn1Phrases = map[string][]string{
+	`ATTRIBUTE`: {`SUBTYPE OF`, `WITH SYNTAX`, `EQUALITY MATCHING RULE`,
+		`ORDERING MATCHING RULE`, `SUBSTRINGS MATCHING RULE`, `SINGLE VALUE`,
+		`COLLECTIVE`, `DUMMY`, `NO USER MODIFICATION`, `USAGE`, `LDAP-SYNTAX`,
+		`LDAP-NAME`, `LDAP-DESC`, `OBSOLETE`, `ID`},
+	`MATCHING-RULE`: {`PARENT`, `SYNTAX`, `UNIQUE-MATCH-INDICATOR`,
+		`LDAP-SYNTAX`, `LDAP-NAME`, `LDAP-DESC`, `ID`},
+	`OBJECT-CLASS`: {`SUBCLASS OF`, `KIND`, `MUST CONTAIN`, `MAY CONTAIN`,
+		`LDAP-NAME`, `LDAP-DESC`, `ID`},
+	`NAME-FORM`: {`NAMES`, `WITH ATTRIBUTES`, `AND OPTIONALLY`, `LDAP-NAME`,
+		`LDAP-DESC`, `ID`},
+	`CONTENT-RULE`: {`STRUCTURAL OBJECT-CLASS`, `AUXILIARY OBJECT-CLASSES`,
+		`MUST CONTAIN`, `MAY CONTAIN`, `MUST-NOT CONTAIN`},
+	`STRUCTURE-RULE`: {`NAME FORM`, `SUPERIOR RULES`, `ID`},
+	`SYNTAX-NAME`:    {`LDAP-DESC`, `DIRECTORY SYNTAX`, `ID`},
+}
+
+/*
+asn1Classes contains the supported X.501 information object classes in
+the order in which their instances are pushed into a [Schema], alongside
+the [Definition] types to which they correspond.
+*/
+var asn1Classes = [][2]string{
+	{`MATCHING-RULE`, `matchingRule`},
+	{`ATTRIBUTE`, `attributeType`},
+	{`OBJECT-CLASS`, `objectClass`},
+	{`NAME-FORM`, `nameForm`},
+	{`CONTENT-RULE`, `dITContentRule`},
+	{`STRUCTURE-RULE`, `dITStructureRule`},
+}
+
+/*
+asn1Roots maps the names of the root arcs of the OID tree to their
+numeric values.
+*/
+var asn1Roots = map[string]string{
+	`itu-t`:           `0`,
+	`ccitt`:           `0`,
+	`iso`:             `1`,
+	`joint-iso-itu-t`: `2`,
+	`joint-iso-ccitt`: `2`,
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the MATCHING-RULE class of ITU-T Rec. X.501, e.g.:
+
+	caseIgnoreMatch MATCHING-RULE ::= {
+	    SYNTAX UnboundedDirectoryString
+	    LDAP-SYNTAX { 1 3 6 1 4 1 1466 115 121 1 15 }
+	    LDAP-NAME { "caseIgnoreMatch" }
+	    ID { 2 5 13 2 }
+	}
+
+See [AttributeType.InformationObject] for details on references.
+*/
+func (r MatchingRule) InformationObject() (s string) {
+	if !r.IsZero() {
+		s = asn1Object(r, `MATCHING-RULE`,
+			asn1TypeField(`SYNTAX`, r.Syntax(), 0),
+			asn1Syntax(r.Syntax()),
+			asn1Names(r.Names()),
+			asn1Text(`LDAP-DESC`, r.Description()),
+			`ID `+asn1OID(r.NumericOID()))
+	}
+
+	return
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the ATTRIBUTE class of ITU-T Rec. X.501, e.g.:
+
+	cn ATTRIBUTE ::= {
+	    SUBTYPE OF name
+	    LDAP-NAME { "cn", "commonName" }
+	    LDAP-DESC "RFC4519: common name(s) for which the entity is known by"
+	    ID { 2 5 4 3 }
+	}
+
+Each information object is referenced by the principal name of its
+[Definition], the first letter of which is lower-cased as ASN.1 requires,
+or by its numeric OID if unnamed, e.g.: "oid-2-5-4-3".  [DITStructureRule]
+instances lacking a name are referenced by rule ID, e.g.: "rule-0".
+
+The WITH SYNTAX field bears the ASN.1 type of the syntax of the type, if
+known, within which the minimum upper bound, if any, is expressed as a
+size constraint, e.g.: "DirectoryString{64}" or "IA5String (SIZE
+(1..256))".  The LDAP-SYNTAX field bears the numeric OID of the syntax
+itself.  Extensions are not expressed.
+*/
+func (r AttributeType) InformationObject() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	var usage string
+	if u := r.Usage(); len(u) > 0 {
+		usage = `USAGE ` + u
+	}
+
+	s = asn1Object(r, `ATTRIBUTE`,
+		asn1Reference(`SUBTYPE OF`, r.SuperType()),
+		asn1TypeField(`WITH SYNTAX`, r.Syntax(), r.MinimumUpperBounds()),
+		asn1Reference(`EQUALITY MATCHING RULE`, r.Equality()),
+		asn1Reference(`ORDERING MATCHING RULE`, r.Ordering()),
+		asn1Reference(`SUBSTRINGS MATCHING RULE`, r.Substring()),
+		asn1Flag(`SINGLE VALUE`, r.SingleValue()),
+		asn1Flag(`COLLECTIVE`, r.Collective()),
+		asn1Flag(`NO USER MODIFICATION`, r.NoUserModification()),
+		usage,
+		asn1Syntax(r.Syntax()),
+		asn1Names(r.Names()),
+		asn1Text(`LDAP-DESC`, r.Description()),
+		asn1Flag(`OBSOLETE`, r.Obsolete()),
+		`ID `+asn1OID(r.NumericOID()))
+
+	return
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the OBJECT-CLASS class of ITU-T Rec. X.501, e.g.:
+
+	person OBJECT-CLASS ::= {
+	    SUBCLASS OF { top }
+	    MUST CONTAIN { sn | cn }
+	    MAY CONTAIN { userPassword | telephoneNumber | seeAlso | description }
+	    LDAP-NAME { "person" }
+	    ID { 2 5 6 6 }
+	}
+
+See [AttributeType.InformationObject] for details on references.
+*/
+func (r ObjectClass) InformationObject() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	var kind string
+	switch r.Kind() {
+	case AbstractKind:
+		kind = `KIND abstract`
+	case AuxiliaryKind:
+		kind = `KIND auxiliary`
+	}
+
+	s = asn1Object(r, `OBJECT-CLASS`,
+		asn1ObjectClasses(`SUBCLASS OF`, r.SuperClasses()),
+		kind,
+		asn1AttributeTypes(`MUST CONTAIN`, r.Must()),
+		asn1AttributeTypes(`MAY CONTAIN`, r.May()),
+		asn1Names(r.Names()),
+		asn1Text(`LDAP-DESC`, r.Description()),
+		`ID `+asn1OID(r.NumericOID()))
+
+	return
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the NAME-FORM class of ITU-T Rec. X.501, e.g.:
+
+	nArcForm NAME-FORM ::= {
+	    NAMES arc
+	    WITH ATTRIBUTES { n }
+	    LDAP-NAME { "nArcForm" }
+	    LDAP-DESC "arc name form for a number form RDN"
+	    ID { 1 3 6 1 4 1 56521 101 2 7 2 }
+	}
+
+See [AttributeType.InformationObject] for details on references.
+*/
+func (r NameForm) InformationObject() (s string) {
+	if !r.IsZero() {
+		s = asn1Object(r, `NAME-FORM`,
+			asn1Reference(`NAMES`, r.OC()),
+			asn1AttributeTypes(`WITH ATTRIBUTES`, r.Must()),
+			asn1AttributeTypes(`AND OPTIONALLY`, r.May()),
+			asn1Names(r.Names()),
+			asn1Text(`LDAP-DESC`, r.Description()),
+			`ID `+asn1OID(r.NumericOID()))
+	}
+
+	return
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the CONTENT-RULE class of ITU-T Rec. X.501, e.g.:
+
+	arcContent CONTENT-RULE ::= {
+	    STRUCTURAL OBJECT-CLASS arc.&id
+	    AUXILIARY OBJECT-CLASSES { x660Context | x667Context }
+	    MUST CONTAIN { n }
+	    MUST-NOT CONTAIN { dotNotation }
+	}
+
+As the CONTENT-RULE class provides no fields for names or descriptions,
+only the principal name of the receiver is conveyed, by way of the
+reference.  See [AttributeType.InformationObject] for details.
+*/
+func (r DITContentRule) InformationObject() (s string) {
+	if !r.IsZero() {
+		s = asn1Object(r, `CONTENT-RULE`,
+			`STRUCTURAL OBJECT-CLASS `+asn1Ref(r.StructuralClass())+`.&id`,
+			asn1ObjectClasses(`AUXILIARY OBJECT-CLASSES`, r.Aux()),
+			asn1AttributeTypes(`MUST CONTAIN`, r.Must()),
+			asn1AttributeTypes(`MAY CONTAIN`, r.May()),
+			asn1AttributeTypes(`MUST-NOT CONTAIN`, r.Not()))
+	}
+
+	return
+}
+
+/*
+InformationObject returns the ASN.1 information object definition of the
+receiver instance per the STRUCTURE-RULE class of ITU-T Rec. X.501, e.g.:
+
+	arcStructure STRUCTURE-RULE ::= {
+	    NAME FORM nArcForm
+	    SUPERIOR RULES { rootArcStructure }
+	    ID 1
+	}
+
+As the STRUCTURE-RULE class provides no fields for names or descriptions,
+only the principal name of the receiver is conveyed, by way of the
+reference.  See [AttributeType.InformationObject] for details.
+*/
+func (r DITStructureRule) InformationObject() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	var sups string
+	if x := r.SuperRules(); x.Len() > 0 {
+		var refs []string
+		for i := 0; i < x.Len(); i++ {
+			refs = append(refs, asn1Ref(x.Index(i)))
+		}
+		sups = `SUPERIOR RULES { ` + join(refs, ` | `) + ` }`
+	}
+
+	s = asn1Object(r, `STRUCTURE-RULE`,
+		asn1Reference(`NAME FORM`, r.Form()),
+		sups,
+		`ID `+uitoa(r.RuleID()))
+
+	return
+}
+
+/*
+InformationObjects returns the ASN.1 information object definitions of
+the [MatchingRule], [AttributeType], [ObjectClass], [DITContentRule],
+[NameForm] and [DITStructureRule] instances within the receiver instance,
+separated by blank lines, alongside an error.  See the InformationObject
+method of each type (e.g.: [AttributeType.InformationObject]) for details.
+
+Definitions follow any superior of the same type upon which they depend.
+The return value contains information object assignments only, and may
+be enclosed within an ASN.1 module which imports the X.501 information
+object classes, as well as any ASN.1 types used, from their respective
+modules.  The [LDAPSyntax] and [MatchingRuleUse] instances within the
+receiver, which have no counterpart among the X.501 information object
+classes, are not expressed.
+
+An error is returned if the receiver is zero, or if any reference would
+be assigned more than once.
+
+See [Schema.ParseInformationObjects] for the inverse.
+*/
+func (r Schema) InformationObjects() (s string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	used := make(map[string]string)
+	var objs []string
+	for _, defs := range []Definitions{
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		for _, def := range canonicalOrder(defs) {
+			ref := asn1Ref(def)
+			if prev, found := used[ref]; found {
+				err = mkerr(ErrNotUnique.Error() + `: ASN.1 reference ` + ref +
+					` of ` + def.Type() + ` conflicts with ` + prev)
+				return
+			}
+			used[ref] = def.Type()
+			objs = append(objs, asn1Definition(def))
+		}
+	}
+
+	s = join(objs, string(rune(10)))
+
+	return
+}
+
+/*
+asn1Definition returns the information object definition of def.
+*/
+func asn1Definition(def Definition) (s string) {
+	switch tv := def.(type) {
+	case MatchingRule:
+		s = tv.InformationObject()
+	case AttributeType:
+		s = tv.InformationObject()
+	case ObjectClass:
+		s = tv.InformationObject()
+	case DITContentRule:
+		s = tv.InformationObject()
+	case NameForm:
+		s = tv.InformationObject()
+	case DITStructureRule:
+		s = tv.InformationObject()
+	}
+
+	return
+}
+
+/*
+asn1Ref returns the ASN.1 reference of def.
+*/
+func asn1Ref(def Definition) string {
+	if name := def.Name(); len(name) > 0 {
+		return lowerFirst(name)
+	} else if ds, ok := def.(DITStructureRule); ok {
+		return `rule-` + uitoa(ds.RuleID())
+	}
+
+	return `oid-` + repAll(def.NumericOID(), `.`, `-`)
+}
+
+/*
+asn1Object returns the information object assignment of def to class,
+bearing each non-zero field on its own line.
+*/
+func asn1Object(def Definition, class string, fields ...string) string {
+	lf := string(rune(10))
+	s := asn1Ref(def) + ` ` + class + ` ::= {` + lf
+	for _, field := range fields {
+		if len(field) > 0 {
+			s += `    ` + field + lf
+		}
+	}
+
+	return s + `}` + lf
+}
+
+/*
+asn1OID returns the ASN.1 value notation of the numeric OID.
+*/
+func asn1OID(oid string) string {
+	return `{ ` + repAll(oid, `.`, ` `) + ` }`
+}
+
+/*
+asn1String returns the ASN.1 value notation of the string s.
+*/
+func asn1String(s string) string {
+	return `"` + repAll(s, `"`, `""`) + `"`
+}
+
+/*
+asn1Text returns the label and ASN.1 string value of s, or a zero string
+if s is zero.
+*/
+func asn1Text(label, s string) (field string) {
+	if len(s) > 0 {
+		field = label + ` ` + asn1String(s)
+	}
+
+	return
+}
+
+/*
+asn1Flag returns the label and a TRUE value if set, else a zero string.
+*/
+func asn1Flag(label string, set bool) (field string) {
+	if set {
+		field = label + ` TRUE`
+	}
+
+	return
+}
+
+/*
+asn1Names returns the LDAP-NAME field bearing names, or a zero string if
+names is empty.
+*/
+func asn1Names(names QuotedDescriptorList) (field string) {
+	if names.Len() > 0 {
+		var vals []string
+		for i := 0; i < names.Len(); i++ {
+			vals = append(vals, asn1String(names.Index(i)))
+		}
+		field = `LDAP-NAME { ` + join(vals, `, `) + ` }`
+	}
+
+	return
+}
+
+/*
+asn1Reference returns the label and reference of def, or a zero string
+if def is zero.
+*/
+func asn1Reference(label string, def Definition) (field string) {
+	if !def.IsZero() {
+		field = label + ` ` + asn1Ref(def)
+	}
+
+	return
+}
+
+/*
+asn1AttributeTypes returns the label and object set of x, or a zero
+string if x is empty.
+*/
+func asn1AttributeTypes(label string, x AttributeTypes) (field string) {
+	if x.Len() > 0 {
+		var refs []string
+		for i := 0; i < x.Len(); i++ {
+			refs = append(refs, asn1Ref(x.Index(i)))
+		}
+		field = label + ` { ` + join(refs, ` | `) + ` }`
+	}
+
+	return
+}
+
+/*
+asn1ObjectClasses returns the label and object set of x, or a zero
+string if x is empty.
+*/
+func asn1ObjectClasses(label string, x ObjectClasses) (field string) {
+	if x.Len() > 0 {
+		var refs []string
+		for i := 0; i < x.Len(); i++ {
+			refs = append(refs, asn1Ref(x.Index(i)))
+		}
+		field = label + ` { ` + join(refs, ` | `) + ` }`
+	}
+
+	return
+}
+
+/*
+asn1Syntax returns the LDAP-SYNTAX field of syn, or a zero string if syn
+is zero.
+*/
+func asn1Syntax(syn LDAPSyntax) (field string) {
+	if !syn.IsZero() {
+		field = `LDAP-SYNTAX ` + asn1OID(syn.NumericOID())
+	}
+
+	return
+}
+
+/*
+asn1TypeField returns the label and ASN.1 type of values of syn, bearing
+the minimum upper bound mub if non-zero, or a zero string if the type is
+unknown.
+*/
+func asn1TypeField(label string, syn LDAPSyntax, mub uint) (field string) {
+	if syn.IsZero() {
+		return
+	}
+
+	typ, found := asn1Types[syn.NumericOID()]
+	if !found {
+		return
+	}
+
+	if mub > 0 {
+		if typ == `UnboundedDirectoryString` {
+			typ = `DirectoryString{` + uitoa(mub) + `}`
+		} else if asn1Sized[typ] {
+			typ += ` (SIZE (1..` + uitoa(mub) + `))`
+		}
+	}
+	field = label + ` ` + typ
+
+	return
+}
+
+/*
+ParseInformationObjects returns an error following an attempt to parse
+raw as ASN.1 information object definitions (see [InformationObjectMaps])
+and to push the resulting definitions into the receiver instance.
+
+A [Definition] already present within the receiver by the same numeric
+OID (or rule ID) and name is not pushed again, thus modules which restate
+well-known definitions may be parsed.  All definitions are pushed within
+a single transaction (see [Schema.Begin]), thus the receiver is left
+intact should any of them fail, or should the result fail the
+[Schema.Compliant] checks.
+*/
+func (r Schema) ParseInformationObjects(raw string) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	var maps DefinitionMaps
+	if maps, err = InformationObjectMaps(raw); err != nil {
+		return
+	}
+
+	tx := r.Begin()
+	if tx.IsZero() {
+		err = mkerr(ErrInvalidInput.Error() + `: Schema is frozen`)
+		return
+	}
+
+	stage := tx.Schema()
+	for i := 0; i < maps.Len() && err == nil; i++ {
+		def := maps.Index(i)
+		if oid := def.value(`NUMERICOID`); def.Type() == `dITContentRule` && !isNumericOID(oid) {
+			// The structural class was defined elsewhere.
+			def[`NUMERICOID`] = []string{stage.ObjectClasses().Get(oid).NumericOID()}
+		}
+
+		if !asn1Present(stage, def) {
+			err = stage.FromMap(def)
+		}
+	}
+
+	if err != nil {
+		tx.Rollback()
+	} else {
+		err = tx.Commit()
+	}
+
+	return
+}
+
+/*
+asn1Present returns a Boolean value indicative of the definition described
+by def being present within s by the same numeric OID (or rule ID) and name.
+*/
+func asn1Present(s Schema, def DefinitionMap) bool {
+	var x Definition
+	oid := def.value(`NUMERICOID`)
+	switch def.Type() {
+	case `matchingRule`:
+		x = s.MatchingRules().Get(oid)
+	case `attributeType`:
+		x = s.AttributeTypes().Get(oid)
+	case `objectClass`:
+		x = s.ObjectClasses().Get(oid)
+	case `nameForm`:
+		x = s.NameForms().Get(oid)
+	case `dITContentRule`:
+		x = s.DITContentRules().Get(oid)
+	case `dITStructureRule`:
+		x = s.DITStructureRules().Get(def.value(`RULEID`))
+	}
+
+	if x == nil || x.IsZero() {
+		return false
+	}
+
+	name := def.value(`NAME`)
+	return x.Names().Contains(name) || (len(name) == 0 && len(x.Name()) == 0)
+}
+
+/*
+InformationObjectMaps returns an instance of [DefinitionMaps] describing
+the ASN.1 information object definitions of the MATCHING-RULE, ATTRIBUTE,
+OBJECT-CLASS, NAME-FORM, CONTENT-RULE and STRUCTURE-RULE classes of ITU-T
+Rec. X.501 within raw, alongside an error.  raw may contain an entire ASN.1
+module; all other assignments are ignored, save for OBJECT IDENTIFIER value
+assignments, such as:
+
+	id-at OBJECT IDENTIFIER ::= { joint-iso-itu-t ds(5) attributeType(4) }
+	id-at-commonName OBJECT IDENTIFIER ::= { id-at 3 }
+
+which may be referenced by the ID fields of the information objects. The
+maps are ordered by class as listed above, each following any superior of
+the same class upon which it depends, such that they may be pushed using
+[Schema.FromMaps].
+
+References to information objects defined within raw are replaced by the
+numeric OIDs (or rule IDs) of the objects; all other references, such as
+"name" or "caseIgnoreMatch", are retained as names.  The NAME of each
+definition is taken from its LDAP-NAME field, else its reference.  The
+syntax of an [AttributeType] or [MatchingRule] is taken from its LDAP-
+SYNTAX field, else its ASN.1 type, if known, along with any minimum upper
+bound expressed as a size constraint.  The LDAP-SYNTAX field may bear a
+numeric OID, or a reference to a SYNTAX-NAME information object of the
+form "directoryString.&id".  Such objects need not be defined within raw
+if they are among those defined by ITU-T Rec. X.520 for the syntaxes of
+RFC 4517.  See [AttributeType.InformationObject]
+for details on the notation produced by this package.
+*/
+func InformationObjectMaps(raw string) (maps DefinitionMaps, err error) {
+	var toks []string
+	if toks, err = asn1Tokens(raw); err != nil {
+		return
+	}
+
+	m := &asn1Module{
+		objects:  make(map[string]*asn1Assignment),
+		values:   make(map[string][]string),
+		resolved: make(map[string]string),
+	}
+	if err = m.scan(toks); err != nil {
+		return
+	}
+
+	for _, class := range asn1Classes {
+		var defs []DefinitionMap
+		var deps [][]string
+		for _, obj := range m.order {
+			if obj.class != class[0] {
+				continue
+			}
+
+			var def DefinitionMap
+			if def, err = m.definition(obj, class[1]); err != nil {
+				return
+			}
+			defs = append(defs, def)
+			deps = append(deps, obj.deps)
+		}
+
+		var ordered []DefinitionMap
+		if ordered, err = asn1Order(defs, deps); err != nil {
+			return
+		}
+		maps = append(maps, ordered...)
+	}
+
+	return
+}
+
+/*
+asn1Assignment describes an information object assignment.
+*/
+type asn1Assignment struct {
+	ref    string
+	class  string
+	fields map[string][]string
+	id     string   // numeric OID or rule ID
+	deps   []string // identifiers of superiors of the same class
+}
+
+/*
+asn1Module contains the assignments of interest within an ASN.1 module.
+*/
+type asn1Module struct {
+	order    []*asn1Assignment
+	objects  map[string]*asn1Assignment
+	values   map[string][]string // OID value reference -> value tokens
+	resolved map[string]string   // OID value reference -> numeric OID
+}
+
+/*
+scan records the information object and OID value assignments within
+toks, as well as the identifiers of the information objects.
+*/
+func (r *asn1Module) scan(toks []string) (err error) {
+	for i := 0; i < len(toks) && err == nil; i++ {
+		var start int
+		var class string
+		switch {
+		case i+3 < len(toks) && toks[i+2] == `::=` && toks[i+3] == `{`:
+			if _, found := asn1Phrases[toks[i+1]]; found {
+				class, start = toks[i+1], i+3
+			}
+		case i+4 < len(toks) && toks[i+1] == `OBJECT` && toks[i+2] == `IDENTIFIER` &&
+			toks[i+3] == `::=` && toks[i+4] == `{`:
+			start = i + 4
+		}
+
+		if start == 0 {
+			continue
+		}
+
+		var end int
+		if end, err = asn1Close(toks, start); err != nil {
+			break
+		}
+
+		ref := toks[i]
+		if _, found := r.objects[ref]; found {
+			err = mkerr(ErrNotUnique.Error() + `: ASN.1 reference ` + ref)
+		} else if _, found = r.values[ref]; found {
+			err = mkerr(ErrNotUnique.Error() + `: ASN.1 reference ` + ref)
+		} else if len(class) == 0 {
+			r.values[ref] = toks[start : end+1]
+		} else {
+			obj := &asn1Assignment{ref: ref, class: class}
+			if obj.fields, err = asn1Fields(class, toks[start+1:end]); err == nil {
+				r.objects[ref] = obj
+				r.order = append(r.order, obj)
+			}
+		}
+		i = end
+	}
+
+	// Identify each object prior to the resolution of any
+	// references thereto.
+	for _, obj := range r.order {
+		if err != nil {
+			break
+		}
+
+		switch obj.class {
+		case `CONTENT-RULE`:
+			continue
+		case `STRUCTURE-RULE`:
+			obj.id, err = asn1Number(obj.fields[`ID`])
+		default:
+			obj.id, err = r.oid(obj.fields[`ID`])
+		}
+
+		if err == nil && len(obj.id) == 0 {
+			err = mkerr(ErrInformationObject.Error() + `: missing ID for ` + obj.ref)
+		}
+	}
+
+	return
+}
+
+/*
+definition returns the [DefinitionMap] of typ describing obj.
+*/
+func (r *asn1Module) definition(obj *asn1Assignment, typ string) (def DefinitionMap, err error) {
+	def = DefinitionMap{`TYPE`: {typ}}
+	f := obj.fields
+
+	if vals, found := f[`LDAP-NAME`]; found {
+		if def[`NAME`], err = asn1Strings(vals); err != nil {
+			return
+		}
+	} else if !hasPfx(obj.ref, `oid-`) && !hasPfx(obj.ref, `rule-`) {
+		def[`NAME`] = []string{obj.ref}
+	}
+
+	if vals, found := f[`LDAP-DESC`]; found {
+		var desc []string
+		if desc, err = asn1Strings(vals); err == nil && len(desc) != 1 {
+			err = mkerr(ErrInformationObject.Error() + `: bad LDAP-DESC for ` + obj.ref)
+		}
+		def[`DESC`] = desc
+	}
+
+	switch obj.class {
+	case `STRUCTURE-RULE`:
+		def[`RULEID`] = []string{obj.id}
+	case `CONTENT-RULE`:
+		// handled below
+	default:
+		def[`NUMERICOID`] = []string{obj.id}
+	}
+
+	// clause assigns the resolved references within the named
+	// field to key, also recording those of the same class
+	// as dependencies if sup is true.
+	clause := func(field, key, class string, sup bool) {
+		vals, found := f[field]
+		if !found || err != nil {
+			return
+		}
+
+		var refs []string
+		if refs, err = asn1Set(vals); err != nil {
+			err = mkerr(err.Error() + ` (` + field + ` of ` + obj.ref + `)`)
+			return
+		}
+
+		for _, ref := range refs {
+			id := r.ident(ref, class)
+			def[key] = append(def[key], id)
+			if dep, local := r.objects[ref]; sup && local && dep.class == class {
+				obj.deps = append(obj.deps, id)
+			}
+		}
+	}
+
+	// flag assigns TRUE to key if the named field is TRUE.
+	flag := func(field, key string) {
+		if vals, found := f[field]; found && err == nil {
+			if len(vals) != 1 || (vals[0] != `TRUE` && vals[0] != `FALSE`) {
+				err = mkerr(ErrInformationObject.Error() + `: bad ` + field + ` for ` + obj.ref)
+			} else if vals[0] == `TRUE` {
+				def[key] = []string{`TRUE`}
+			}
+		}
+	}
+
+	switch obj.class {
+	case `MATCHING-RULE`:
+		err = r.syntax(def, obj, `SYNTAX`)
+	case `ATTRIBUTE`:
+		clause(`SUBTYPE OF`, `SUP`, `ATTRIBUTE`, true)
+		clause(`EQUALITY MATCHING RULE`, `EQUALITY`, `MATCHING-RULE`, false)
+		clause(`ORDERING MATCHING RULE`, `ORDERING`, `MATCHING-RULE`, false)
+		clause(`SUBSTRINGS MATCHING RULE`, `SUBSTR`, `MATCHING-RULE`, false)
+		flag(`SINGLE VALUE`, `SINGLE-VALUE`)
+		flag(`COLLECTIVE`, `COLLECTIVE`)
+		flag(`NO USER MODIFICATION`, `NO-USER-MODIFICATION`)
+		flag(`OBSOLETE`, `OBSOLETE`)
+		if vals, found := f[`USAGE`]; found && err == nil {
+			def[`USAGE`] = vals
+		}
+		if err == nil {
+			err = r.syntax(def, obj, `WITH SYNTAX`)
+		}
+	case `OBJECT-CLASS`:
+		clause(`SUBCLASS OF`, `SUP`, `OBJECT-CLASS`, true)
+		clause(`MUST CONTAIN`, `MUST`, `ATTRIBUTE`, false)
+		clause(`MAY CONTAIN`, `MAY`, `ATTRIBUTE`, false)
+		if vals, found := f[`KIND`]; found && err == nil {
+			def[`KIND`] = []string{uc(join(vals, ``))}
+		}
+	case `NAME-FORM`:
+		clause(`NAMES`, `OC`, `OBJECT-CLASS`, false)
+		clause(`WITH ATTRIBUTES`, `MUST`, `ATTRIBUTE`, false)
+		clause(`AND OPTIONALLY`, `MAY`, `ATTRIBUTE`, false)
+	case `CONTENT-RULE`:
+		err = r.structural(def, obj)
+		clause(`AUXILIARY OBJECT-CLASSES`, `AUX`, `OBJECT-CLASS`, false)
+		clause(`MUST CONTAIN`, `MUST`, `ATTRIBUTE`, false)
+		clause(`MAY CONTAIN`, `MAY`, `ATTRIBUTE`, false)
+		clause(`MUST-NOT CONTAIN`, `NOT`, `ATTRIBUTE`, false)
+	case `STRUCTURE-RULE`:
+		clause(`NAME FORM`, `FORM`, `NAME-FORM`, false)
+		clause(`SUPERIOR RULES`, `SUP`, `STRUCTURE-RULE`, true)
+	}
+
+	return
+}
+
+/*
+structural assigns the numeric OID of the structural class of the
+content rule obj to def, or its reference if defined elsewhere.
+*/
+func (r *asn1Module) structural(def DefinitionMap, obj *asn1Assignment) (err error) {
+	vals := obj.fields[`STRUCTURAL OBJECT-CLASS`]
+	var oid string
+	switch {
+	case len(vals) == 0:
+		err = mkerr(ErrInformationObject.Error() + `: missing STRUCTURAL OBJECT-CLASS for ` + obj.ref)
+	case len(vals) == 3 && vals[1] == `.` && vals[2] == `&id`:
+		oid = r.ident(vals[0], `OBJECT-CLASS`)
+	default:
+		oid, err = r.oid(vals)
+	}
+
+	if err == nil {
+		def[`NUMERICOID`] = []string{oid}
+	}
+
+	return
+}
+
+/*
+syntax assigns the SYNTAX of obj to def per its LDAP-SYNTAX field, else
+per the ASN.1 type within the named field.
+*/
+func (r *asn1Module) syntax(def DefinitionMap, obj *asn1Assignment, field string) (err error) {
+	oid, mub := asn1TypeSyntax(obj.fields[field])
+	if vals, found := obj.fields[`LDAP-SYNTAX`]; found {
+		if len(vals) == 3 && vals[1] == `.` && vals[2] == `&id` {
+			oid, err = r.syntaxName(vals[0])
+		} else {
+			oid, err = r.oid(vals)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	if len(oid) == 0 {
+		if _, sup := def[`SUP`]; !sup {
+			err = mkerr(ErrInformationObject.Error() + `: no known syntax for ` + obj.ref)
+		}
+		return
+	}
+
+	if len(mub) > 0 {
+		oid += `{` + mub + `}`
+	}
+	def[`SYNTAX`] = []string{oid}
+
+	return
+}
+
+/*
+syntaxName returns the numeric OID of the SYNTAX-NAME information object
+referenced by ref, whether defined within the receiver or by ITU-T Rec.
+X.520, alongside an error.
+*/
+func (r *asn1Module) syntaxName(ref string) (oid string, err error) {
+	var found bool
+	if obj, local := r.objects[ref]; local && obj.class == `SYNTAX-NAME` {
+		oid = obj.id
+	} else if oid, found = asn1SyntaxNames[ref]; !found {
+		err = mkerr(ErrInformationObject.Error() + `: unresolved SYNTAX-NAME reference ` + ref)
+	}
+
+	return
+}
+
+/*
+ident returns the numeric OID (or rule ID) of the information object of
+class referenced by ref if defined within the receiver, else the numeric
+OID encoded within a generated reference (e.g.: "oid-2-5-4-3"), else ref.
+*/
+func (r *asn1Module) ident(ref, class string) string {
+	if obj, found := r.objects[ref]; found && obj.class == class {
+		return obj.id
+	} else if hasPfx(ref, `oid-`) && isNumericOID(repAll(ref[4:], `-`, `.`)) {
+		return repAll(ref[4:], `-`, `.`)
+	} else if hasPfx(ref, `rule-`) {
+		if _, err := atoi(ref[5:]); err == nil {
+			return ref[5:]
+		}
+	}
+
+	return ref
+}
+
+/*
+oid returns the numeric OID described by toks, which is either a value
+reference or an OBJECT IDENTIFIER value in braces, alongside an error.
+*/
+func (r *asn1Module) oid(toks []string) (oid string, err error) {
+	if len(toks) == 1 {
+		return r.valueRef(toks[0])
+	} else if len(toks) < 3 || toks[0] != `{` || toks[len(toks)-1] != `}` {
+		err = mkerr(ErrInformationObject.Error() + `: bad OBJECT IDENTIFIER value ` + join(toks, ` `))
+		return
+	}
+
+	var arcs []string
+	inner := toks[1 : len(toks)-1]
+	for i := 0; i < len(inner) && err == nil; i++ {
+		tok := inner[i]
+		switch {
+		case asn1IsNumber(tok):
+			arcs = append(arcs, tok)
+		case i+3 < len(inner) && inner[i+1] == `(` && inner[i+3] == `)`:
+			// NameAndNumberForm, e.g.: ds(5)
+			arcs = append(arcs, inner[i+2])
+			i += 3
+		case i == 0:
+			if root, found := asn1Roots[tok]; found {
+				arcs = append(arcs, root)
+			} else {
+				var base string
+				if base, err = r.valueRef(tok); err == nil {
+					arcs = append(arcs, base)
+				}
+			}
+		default:
+			err = mkerr(ErrInformationObject.Error() + `: unexpected ` + tok + ` within OBJECT IDENTIFIER value`)
+		}
+	}
+
+	if err == nil {
+		if oid = join(arcs, `.`); !isNumericOID(oid) {
+			err = mkerr(ErrInformationObject.Error() + `: bad OBJECT IDENTIFIER value ` + join(toks, ` `))
+		}
+	}
+
+	return
+}
+
+/*
+valueRef returns the numeric OID assigned to the value reference ref.
+*/
+func (r *asn1Module) valueRef(ref string) (oid string, err error) {
+	var found bool
+	if oid, found = r.resolved[ref]; found {
+		if len(oid) == 0 {
+			err = mkerr(ErrInformationObject.Error() + `: circular OBJECT IDENTIFIER reference ` + ref)
+		}
+		return
+	}
+
+	var toks []string
+	if toks, found = r.values[ref]; !found {
+		err = mkerr(ErrInformationObject.Error() + `: unresolved OBJECT IDENTIFIER reference ` + ref)
+		return
+	}
+
+	r.resolved[ref] = `` // in progress
+	if oid, err = r.oid(toks); err == nil {
+		r.resolved[ref] = oid
+	}
+
+	return
+}
+
+/*
+asn1Order returns defs ordered such that each follows the definitions
+upon which it depends per deps, alongside an error in the event of a
+cycle.
+*/
+func asn1Order(defs []DefinitionMap, deps [][]string) (ordered []DefinitionMap, err error) {
+	ident := func(def DefinitionMap) string {
+		if def.Type() == `dITStructureRule` {
+			return def.value(`RULEID`)
+		}
+		return def.value(`NUMERICOID`)
+	}
+
+	done := make(map[string]bool)
+	pending := make([]int, len(defs))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for len(pending) > 0 {
+		next := -1
+		for j, idx := range pending {
+			ready := true
+			for _, dep := range deps[idx] {
+				ready = ready && (done[dep] || dep == ident(defs[idx]))
+			}
+			if ready {
+				next = j
+				break
+			}
+		}
+
+		if next == -1 {
+			err = mkerr(ErrInformationObject.Error() + `: circular dependency among ` +
+				defs[pending[0]].Type() + ` definitions`)
+			return
+		}
+
+		idx := pending[next]
+		pending = append(pending[:next], pending[next+1:]...)
+		ordered = append(ordered, defs[idx])
+		done[ident(defs[idx])] = true
+	}
+
+	return
+}
+
+/*
+asn1Fields returns the values of the fields within body, the tokens
+enclosed by the braces of an information object of class, keyed by the
+phrases of class.
+*/
+func asn1Fields(class string, body []string) (fields map[string][]string, err error) {
+	fields = make(map[string][]string)
+	phrases := asn1Phrases[class]
+
+	// phrase returns the phrase of class beginning at
+	// body[i], if any.
+	phrase := func(i int) (match string) {
+		for _, p := range phrases {
+			words := split(p, ` `)
+			if i+len(words) > len(body) {
+				continue
+			}
+
+			ok := true
+			for j, w := range words {
+				ok = ok && body[i+j] == w
+			}
+			if ok && len(p) > len(match) {
+				match = p
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(body) && err == nil; {
+		p := phrase(i)
+		if len(p) == 0 {
+			err = mkerr(ErrInformationObject.Error() + `: unexpected ` + body[i] + ` within ` + class)
+			break
+		} else if _, found := fields[p]; found {
+			err = mkerr(ErrInformationObject.Error() + `: duplicate ` + p + ` within ` + class)
+			break
+		}
+
+		i += len(split(p, ` `))
+		var val []string
+		for depth := 0; i < len(body); i++ {
+			if depth == 0 && len(phrase(i)) > 0 {
+				break
+			}
+			switch body[i] {
+			case `{`, `(`:
+				depth++
+			case `}`, `)`:
+				depth--
+			}
+			val = append(val, body[i])
+		}
+
+		if len(val) == 0 {
+			err = mkerr(ErrInformationObject.Error() + `: missing value for ` + p + ` within ` + class)
+		}
+		fields[p] = val
+	}
+
+	return
+}
+
+/*
+asn1Close returns the index of the brace which closes that at toks[start].
+*/
+func asn1Close(toks []string, start int) (end int, err error) {
+	depth := 0
+	for end = start; end < len(toks); end++ {
+		switch toks[end] {
+		case `{`:
+			depth++
+		case `}`:
+			if depth--; depth == 0 {
+				return
+			}
+		}
+	}
+	err = mkerr(ErrInformationObject.Error() + `: unbalanced braces`)
+
+	return
+}
+
+/*
+asn1Set returns the references within an object set, e.g.: "{ a | b }",
+or within a lone reference.  Extension markers are ignored.
+*/
+func asn1Set(toks []string) (refs []string, err error) {
+	if len(toks) > 1 {
+		if toks[0] != `{` || toks[len(toks)-1] != `}` {
+			err = mkerr(ErrInformationObject.Error() + `: bad object set ` + join(toks, ` `))
+			return
+		}
+		toks = toks[1 : len(toks)-1]
+	}
+
+	for i, tok := range toks {
+		switch {
+		case tok == `...`:
+		case i%2 == 1 && (tok == `|` || tok == `,`):
+		case i%2 == 0 && asn1Word(tok):
+			refs = append(refs, tok)
+		default:
+			err = mkerr(ErrInformationObject.Error() + `: unexpected ` + tok + ` within object set`)
+			return
+		}
+	}
+
+	return
+}
+
+/*
+asn1Strings returns the strings within a lone string value or within a
+SEQUENCE OF strings, e.g.: `{ "a", "b" }`.
+*/
+func asn1Strings(toks []string) (vals []string, err error) {
+	if len(toks) > 1 {
+		if toks[0] != `{` || toks[len(toks)-1] != `}` {
+			err = mkerr(ErrInformationObject.Error() + `: bad string value ` + join(toks, ` `))
+			return
+		}
+		toks = toks[1 : len(toks)-1]
+	}
+
+	for i, tok := range toks {
+		switch {
+		case i%2 == 1 && tok == `,`:
+		case i%2 == 0 && len(tok) > 1 && tok[0] == '"':
+			vals = append(vals, repAll(tok[1:len(tok)-1], `""`, `"`))
+		default:
+			err = mkerr(ErrInformationObject.Error() + `: unexpected ` + tok + ` within string value`)
+			return
+		}
+	}
+
+	return
+}
+
+/*
+asn1Number returns the lone number within toks.
+*/
+func asn1Number(toks []string) (n string, err error) {
+	if len(toks) != 1 || !asn1IsNumber(toks[0]) {
+		err = mkerr(ErrInformationObject.Error() + `: bad INTEGER value ` + join(toks, ` `))
+		return
+	}
+	n = toks[0]
+
+	return
+}
+
+/*
+asn1TypeSyntax returns the numeric OID of the syntax whose values are of
+the ASN.1 type within toks, as well as the minimum upper bound within
+its size constraint, if any.  A zero OID is returned if the type is not
+known.
+*/
+func asn1TypeSyntax(toks []string) (oid, mub string) {
+	if len(toks) == 0 {
+		return
+	}
+
+	var base []string
+	for i, tok := range toks {
+		if tok == `{` || tok == `(` {
+			// The last number within the parameter or
+			// constraint is the upper bound.
+			for _, t := range toks[i:] {
+				if asn1IsNumber(t) {
+					mub = t
+				}
+			}
+			break
+		}
+		base = append(base, tok)
+	}
+
+	typ := join(base, ` `)
+	if typ == `DirectoryString` {
+		typ = `UnboundedDirectoryString`
+	}
+
+	for o, t := range asn1Types {
+		if t == typ {
+			oid = o
+			break
+		}
+	}
+
+	if len(oid) == 0 {
+		mub = ``
+	}
+
+	return
+}
+
+/*
+asn1IsNumber returns a Boolean value indicative of tok consisting solely
+of digits.
+*/
+func asn1IsNumber(tok string) bool {
+	for i := 0; i < len(tok); i++ {
+		if tok[i] < '0' || tok[i] > '9' {
+			return false
+		}
+	}
+
+	return len(tok) > 0
+}
+
+/*
+asn1Word returns a Boolean value indicative of tok being an ASN.1 word,
+such as a reference.
+*/
+func asn1Word(tok string) bool {
+	return len(tok) > 0 && asn1Alnum(tok[0]) && !asn1IsNumber(tok)
+}
+
+/*
+asn1Alnum returns a Boolean value indicative of c being an ASCII letter
+or digit.
+*/
+func asn1Alnum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+/*
+asn1Tokens returns the lexical items of the ASN.1 notation within raw,
+less comments, alongside an error.
+*/
+func asn1Tokens(raw string) (toks []string, err error) {
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+			i++
+		case hasPfx(raw[i:], `--`):
+			// A comment ends at the next "--" or line end.
+			j := i + 2
+			for j < len(raw) && raw[j] != '\n' && !hasPfx(raw[j:], `--`) {
+				j++
+			}
+			if hasPfx(raw[j:], `--`) {
+				j += 2
+			}
+			i = j
+		case hasPfx(raw[i:], `/*`):
+			j := stridx(raw[i+2:], `*/`)
+			if j == -1 {
+				err = mkerr(ErrInformationObject.Error() + `: unterminated comment`)
+				return
+			}
+			i += j + 4
+		case c == '"':
+			j := i + 1
+			for ; j < len(raw); j++ {
+				if raw[j] == '"' {
+					if j+1 < len(raw) && raw[j+1] == '"' {
+						j++ // escaped quotation mark
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(raw) {
+				err = mkerr(ErrInformationObject.Error() + `: unterminated string`)
+				return
+			}
+			toks = append(toks, raw[i:j+1])
+			i = j + 1
+		case hasPfx(raw[i:], `::=`), hasPfx(raw[i:], `...`):
+			toks = append(toks, raw[i:i+3])
+			i += 3
+		case hasPfx(raw[i:], `..`):
+			toks = append(toks, raw[i:i+2])
+			i += 2
+		case c == '&' || asn1Alnum(c):
+			j := i + 1
+			for j < len(raw) && (asn1Alnum(raw[j]) ||
+				(raw[j] == '-' && j+1 < len(raw) && asn1Alnum(raw[j+1]))) {
+				j++
+			}
+			toks = append(toks, raw[i:j])
+			i = j
+		default:
+			toks = append(toks, string(c))
+			i++
+		}
+	}
+
+	return
+}
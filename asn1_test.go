@@ -0,0 +1,288 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the export of an [AttributeType] as an ASN.1
+information object definition per the ATTRIBUTE class of ITU-T Rec.
+X.501.
+*/
+func ExampleAttributeType_InformationObject() {
+	n := mySchema.AttributeTypes().Get(`n`)
+	fmt.Print(n.InformationObject())
+	// Output: n ATTRIBUTE ::= {
+	//     WITH SYNTAX INTEGER
+	//     EQUALITY MATCHING RULE integerMatch
+	//     ORDERING MATCHING RULE integerOrderingMatch
+	//     SINGLE VALUE TRUE
+	//     LDAP-SYNTAX { 1 3 6 1 4 1 1466 115 121 1 27 }
+	//     LDAP-NAME { "n", "numberForm" }
+	//     LDAP-DESC "X.680, cl. 32.3: NumberForm"
+	//     ID { 1 3 6 1 4 1 56521 101 2 3 1 }
+	// }
+}
+
+/*
+This example demonstrates the import of ASN.1 information object
+definitions from an ASN.1 module, the ID fields of which reference
+OBJECT IDENTIFIER value assignments.
+*/
+func ExampleSchema_ParseInformationObjects() {
+	s := mySchema.Clone()
+	err := s.ParseInformationObjects(`
+Example DEFINITIONS ::= BEGIN
+
+id-ex OBJECT IDENTIFIER ::= { iso(1) identified-organization(3) 6 1 4 1 56521 999 2 }
+id-ex-at OBJECT IDENTIFIER ::= { id-ex 1 } -- attribute types
+id-ex-oc OBJECT IDENTIFIER ::= { id-ex 2 } -- object classes
+
+shoeSize ATTRIBUTE ::= {
+	WITH SYNTAX INTEGER
+	EQUALITY MATCHING RULE integerMatch
+	SINGLE VALUE TRUE
+	ID { id-ex-at 1 } }
+
+nickName ATTRIBUTE ::= {
+	SUBTYPE OF name
+	WITH SYNTAX DirectoryString{64}
+	ID { id-ex-at 2 } }
+
+shoeWearer OBJECT-CLASS ::= {
+	SUBCLASS OF { top }
+	KIND auxiliary
+	MUST CONTAIN { shoeSize }
+	MAY CONTAIN { nickName | cn, ... }
+	ID { id-ex-oc 1 } }
+
+END`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(s.AttributeTypes().Get(`nickName`))
+	fmt.Println(s.ObjectClasses().Get(`shoeWearer`))
+	// Output: ( 1.3.6.1.4.1.56521.999.2.1.2
+	//     NAME 'nickName'
+	//     SUP name
+	//     SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64} )
+	// ( 1.3.6.1.4.1.56521.999.2.2.1
+	//     NAME 'shoeWearer'
+	//     SUP top
+	//     AUXILIARY
+	//     MUST shoeSize
+	//     MAY ( nickName
+	//         $ cn ) )
+}
+
+func TestSchema_InformationObjects(t *testing.T) {
+	objs, err := mySchema.InformationObjects()
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// The basic schema bears only syntaxes and matching rules, thus
+	// every other definition must be rebuilt from the notation.
+	basic := NewBasicSchema()
+	if err = basic.ParseInformationObjects(objs); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	want, got := mySchema.Counters(), basic.Counters()
+	if want.AT != got.AT || want.OC != got.OC || want.DC != got.DC ||
+		want.NF != got.NF || want.DS != got.DS {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), want, got)
+		return
+	}
+
+	// X-ORIGIN is lost.
+	want1, got1 := mySchema.AttributeTypes().Get(`mail`).Canonical(),
+		basic.AttributeTypes().Get(`mail`).Canonical()
+	if len(got1) < 2 || stridx(want1, got1[:len(got1)-2]) != 0 {
+		t.Errorf("%s failed:\nwant %s\ngot  %s", t.Name(), want1, got1)
+		return
+	}
+
+	// DESC is lost, as the STRUCTURE-RULE class has no such field.
+	if ds := basic.DITStructureRules().Get(1); ds.Name() != `arcStructure` ||
+		ds.Form().Name() != `nArcForm` || ds.SuperRules().Len() != 1 {
+		t.Errorf("%s failed: bad DITStructureRule %s", t.Name(), ds)
+		return
+	}
+
+	dc := basic.DITContentRules().Get(`arcContent`)
+	if !dc.Not().Contains(`dotNotation`) || !dc.Must().Contains(`n`) || dc.Aux().Len() != 4 {
+		t.Errorf("%s failed: bad DITContentRule %s", t.Name(), dc)
+		return
+	}
+
+	// A reference may only be assigned once within a module.
+	clone := mySchema.Clone()
+	if err = clone.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.89
+		NAME 'person'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if _, err = clone.InformationObjects(); err == nil {
+		t.Errorf("%s failed: expected error for duplicate reference", t.Name())
+		return
+	}
+
+	if _, err = (Schema{}).InformationObjects(); err == nil {
+		t.Errorf("%s failed: expected error for zero Schema", t.Name())
+	} else if err = (Schema{}).ParseInformationObjects(objs); err == nil {
+		t.Errorf("%s failed: expected error for zero Schema", t.Name())
+	} else if len((AttributeType{}).InformationObject()) != 0 {
+		t.Errorf("%s failed: expected zero string for zero AttributeType", t.Name())
+	}
+}
+
+func TestInformationObjectMaps(t *testing.T) {
+	maps, err := InformationObjectMaps(`
+		-- subtypes may precede their supertypes
+		sub ATTRIBUTE ::= { SUBTYPE OF super ID { 1 3 6 1 4 1 56521 999 3 2 } }
+		super ATTRIBUTE ::= {
+			WITH SYNTAX OCTET STRING (SIZE (1..32)) -- inline comment --
+			DUMMY FALSE
+			ID { 1 3 6 1 4 1 56521 999 3 1 }
+		}
+		oid-1-3-6-1-4-1-56521-999-3-3 ATTRIBUTE ::= {
+			SUBTYPE OF oid-2-5-4-41
+			ID { 1 3 6 1 4 1 56521 999 3 3 }
+		}
+		rule-7 STRUCTURE-RULE ::= { NAME FORM nArcForm SUPERIOR RULES { rule-0 } ID 7 }
+		widgetContent CONTENT-RULE ::= {
+			STRUCTURAL OBJECT-CLASS { 2 5 6 6 }
+			MUST-NOT CONTAIN { description }
+		}`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if maps.Len() != 5 {
+		t.Errorf("%s failed: want 5 maps, got %d", t.Name(), maps.Len())
+		return
+	}
+
+	for idx, want := range [][2]string{
+		{`NUMERICOID`, `1.3.6.1.4.1.56521.999.3.1`},
+		{`SUP`, `1.3.6.1.4.1.56521.999.3.1`},
+		{`SUP`, `2.5.4.41`},
+		{`NUMERICOID`, `2.5.6.6`},
+		{`SUP`, `0`},
+	} {
+		if got := maps.Index(idx).value(want[0]); got != want[1] {
+			t.Errorf("%s[%d] failed: want %s %s, got %q", t.Name(), idx, want[0], want[1], got)
+			return
+		}
+	}
+
+	if syn := maps.Index(0).value(`SYNTAX`); syn != `1.3.6.1.4.1.1466.115.121.1.40{32}` {
+		t.Errorf("%s failed: bad SYNTAX %s", t.Name(), syn)
+		return
+	} else if maps.Index(2).Contains(`NAME`) || maps.Index(4).Contains(`NAME`) {
+		t.Errorf("%s failed: generated references used as names", t.Name())
+		return
+	}
+
+	for idx, raw := range []string{
+		`x ATTRIBUTE ::= { ID { 2 5 } `,
+		`x ATTRIBUTE ::= { BOGUS TRUE ID { 2 5 } }`,
+		`x ATTRIBUTE ::= { ID { 2 5 } ID { 2 6 } }`,
+		`x ATTRIBUTE ::= { WITH SYNTAX Unknown ID { 2 5 } }`,
+		`x ATTRIBUTE ::= { WITH SYNTAX INTEGER ID id-unknown }`,
+		`x ATTRIBUTE ::= { WITH SYNTAX INTEGER ID { unknown 5 } }`,
+		`x ATTRIBUTE ::= { WITH SYNTAX INTEGER ID a } a OBJECT IDENTIFIER ::= { b 1 } b OBJECT IDENTIFIER ::= { a 1 }`,
+		`x ATTRIBUTE ::= { SUBTYPE OF y ID { 2 5 1 } } y ATTRIBUTE ::= { SUBTYPE OF x ID { 2 5 2 } }`,
+		`x ATTRIBUTE ::= { WITH SYNTAX INTEGER ID { 2 5 } } x ATTRIBUTE ::= { WITH SYNTAX INTEGER ID { 2 6 } }`,
+		`x ATTRIBUTE ::= { SUBTYPE OF name SINGLE VALUE YES ID { 2 5 } }`,
+		`x OBJECT-CLASS ::= { MUST CONTAIN { a b } ID { 2 5 } }`,
+		`x STRUCTURE-RULE ::= { NAME FORM f ID one }`,
+		`x CONTENT-RULE ::= { MUST CONTAIN { cn } }`,
+		`x ATTRIBUTE ::= { SUBTYPE OF name LDAP-DESC "unterminated ID { 2 5 } }`,
+		`/* unterminated comment`,
+	} {
+		if _, err = InformationObjectMaps(raw); err == nil {
+			t.Errorf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+			return
+		}
+	}
+}
+
+/*
+TestInformationObjectMaps_x520 exercises assignments reproduced verbatim
+from ITU-T Rec. X.520, whose LDAP-SYNTAX fields reference SYNTAX-NAME
+information objects rather than numeric OIDs.
+*/
+func TestInformationObjectMaps_x520(t *testing.T) {
+	maps, err := InformationObjectMaps(`
+ds OBJECT IDENTIFIER ::= {joint-iso-itu-t ds(5)}
+id-at OBJECT IDENTIFIER ::= {ds attributeType(4)}
+id-lsx OBJECT IDENTIFIER ::= {1 3 6 1 4 1 1466 115 121 1}
+
+name ATTRIBUTE ::= {
+  WITH SYNTAX              UnboundedDirectoryString
+  EQUALITY MATCHING RULE   caseIgnoreMatch
+  SUBSTRINGS MATCHING RULE caseIgnoreSubstringsMatch
+  LDAP-SYNTAX              directoryString.&id
+  LDAP-NAME                {"name"}
+  ID                       id-at-name }
+
+commonName ATTRIBUTE ::= {
+  SUBTYPE OF               name
+  WITH SYNTAX              UnboundedDirectoryString
+  LDAP-SYNTAX              directoryString.&id
+  LDAP-NAME                {"cn","commonName"}
+  ID                       id-at-commonName }
+
+countryName ATTRIBUTE ::= {
+  SUBTYPE OF               name
+  WITH SYNTAX              CountryName
+  SINGLE VALUE             TRUE
+  LDAP-SYNTAX              countryString.&id
+  LDAP-NAME                {"c"}
+  ID                       id-at-countryName }
+
+directoryString SYNTAX-NAME ::= {
+  LDAP-DESC         "Directory String"
+  DIRECTORY SYNTAX  UnboundedDirectoryString
+  ID                id-lsx-directoryString }
+
+id-at-commonName                 OBJECT IDENTIFIER ::= {id-at 3}
+id-at-countryName                OBJECT IDENTIFIER ::= {id-at 6}
+id-at-name                       OBJECT IDENTIFIER ::= {id-at 41}
+id-lsx-directoryString           OBJECT IDENTIFIER ::= {id-lsx 15}
+`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if maps.Len() != 3 {
+		t.Errorf("%s failed: want 3 maps, got %d", t.Name(), maps.Len())
+		return
+	}
+
+	for idx, want := range [][3]string{
+		{`2.5.4.41`, `name`, `1.3.6.1.4.1.1466.115.121.1.15`},
+		{`2.5.4.3`, `cn`, `1.3.6.1.4.1.1466.115.121.1.15`},
+		{`2.5.4.6`, `c`, `1.3.6.1.4.1.1466.115.121.1.11`},
+	} {
+		def := maps.Index(idx)
+		if def.value(`NUMERICOID`) != want[0] || def.value(`NAME`) != want[1] ||
+			def.value(`SYNTAX`) != want[2] {
+			t.Errorf("%s[%d] failed: unexpected map %v", t.Name(), idx, def)
+			return
+		}
+	}
+
+	if _, err = InformationObjectMaps(`x ATTRIBUTE ::= {
+		LDAP-SYNTAX bogusString.&id
+		ID { 2 5 } }`); err == nil {
+		t.Errorf("%s failed: expected error for unresolved SYNTAX-NAME, got nil", t.Name())
+	}
+}
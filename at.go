@@ -89,7 +89,7 @@ func (r AttributeType) Parse(raw string) (err error) {
 
 func (r *attributeType) parse(raw string) error {
 	// parseAT wraps the antlr4512 AttributeType parser/lexer
-	mp, err := parseAT(raw)
+	mp, err := parseAT(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -138,6 +138,9 @@ func (r *attributeType) replace(x AttributeType) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, AttributeType{r})()
+
 	r.OID = x.attributeType.OID
 	r.Macro = x.attributeType.Macro
 	r.Name = x.attributeType.Name
@@ -156,9 +159,17 @@ func (r *attributeType) replace(x AttributeType) {
 	r.Extensions = x.attributeType.Extensions
 	r.data = x.attributeType.data
 	r.schema = x.attributeType.schema
-	r.stringer = x.attributeType.stringer
+	r.stringer, r.rendering = copyStringer(x.attributeType.stringer, x.attributeType.rendering, r.prepareString)
 	r.valQual = x.attributeType.valQual
 	r.data = x.attributeType.data
+
+	if member(old.schema, AttributeType{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  AttributeType{&old},
+			New:  AttributeType{r},
+		})
+	}
 }
 
 /*
@@ -181,6 +192,10 @@ func (r AttributeType) SetSchema(schema Schema) AttributeType {
 }
 
 func (r *attributeType) setSchema(schema Schema) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -219,6 +234,10 @@ func (r AttributeType) SetData(x any) AttributeType {
 }
 
 func (r *attributeType) setData(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -346,6 +365,10 @@ func (r AttributeType) SetValueQualifier(function ValueQualifier) AttributeType
 }
 
 func (r *attributeType) setValueQualifier(function ValueQualifier) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	r.valQual = function
 }
 
@@ -378,9 +401,9 @@ func (r AttributeTypes) Inventory() (inv Inventory) {
 // stackage closure func - do not exec directly (use String method)
 func (r AttributeTypes) oIDsStringer(_ ...any) string {
 	slice := r.index(0)
-	hd := slice.Schema().Options().Positive(HangingIndents)
+	f := slice.Schema().Formatting()
 	id := r.cast().ID()
-	if hd && id != `at_oidlist` {
+	if f.MultiLine && id != `at_oidlist` {
 		return r.oIDsStringerPretty(len(id))
 	}
 
@@ -433,39 +456,21 @@ This has no effect if the stack has only one member, producing something
 like:
 
 	cn
-*/
-func (r AttributeTypes) oIDsStringerPretty(lead int) (present string) {
-	L := r.Len()
-	switch L {
-	case 0:
-		return
-	case 1:
-		present = r.Index(0).OID()
-		return
-	}
 
-	num := lead + 5
-	for idx := 0; idx < L; idx++ {
-		sl := r.Index(idx).OID()
-		if idx == 0 {
-			present += `( ` + sl + string(rune(10))
-			continue
-		}
-
-		for i := 0; i < num; i++ {
-			present += ` `
-		}
-
-		present += `$ ` + sl
-		if idx == L-1 {
-			present += ` )`
-			break // no newline on last line
+Should the LineWidth field of the relevant [Formatting] be set, members
+are instead packed onto as few lines as that width allows.
+*/
+func (r AttributeTypes) oIDsStringerPretty(lead int) string {
+	var f Formatting
+	var oids []string
+	for i := 0; i < r.len(); i++ {
+		if i == 0 {
+			f = r.index(i).Schema().Formatting()
 		}
-
-		present += string(rune(10))
+		oids = append(oids, r.index(i).OID())
 	}
 
-	return
+	return f.list(oids, lead)
 }
 
 // stackage closure func - do not exec directly.
@@ -552,7 +557,7 @@ func (r AttributeTypes) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -583,12 +588,21 @@ the principal identifier of an [AttributeType] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r AttributeTypes) Get(id string) AttributeType {
 	return r.get(id)
 }
 
 func (r AttributeTypes) get(id string) (at AttributeType) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		at, _ = def.(AttributeType)
+		return
+	}
+
 	for i := 0; i < r.len() && at.IsZero(); i++ {
 		if _at := r.index(i); !_at.IsZero() {
 			if _at.attributeType.OID == id {
@@ -755,6 +769,10 @@ func (r AttributeType) SetNumericOID(id string) AttributeType {
 }
 
 func (r *attributeType) setNumericOID(id string) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -781,6 +799,10 @@ func (r AttributeType) SetExtension(x string, xstrs ...string) AttributeType {
 }
 
 func (r *attributeType) setExtension(x string, xstrs ...string) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -813,6 +835,11 @@ func (r AttributeType) SetName(x ...string) AttributeType {
 }
 
 func (r *attributeType) setName(x ...string) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, AttributeType{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -858,6 +885,10 @@ func (r AttributeType) SetDescription(desc string) AttributeType {
 }
 
 func (r *attributeType) setDescription(desc string) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -921,6 +952,10 @@ func (r AttributeType) SetStringer(function ...Stringer) AttributeType {
 }
 
 func (r *attributeType) setStringer(function ...Stringer) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
@@ -928,20 +963,16 @@ func (r *attributeType) setStringer(function ...Stringer) {
 
 	if stringer == nil {
 		// no user provided closure means we
-		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// defer to a general use stringer, the
+		// text/template op of which is deferred
+		// until first use.
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 		return
 	}
 
 	// assign user-provided closure
 	r.stringer = stringer
+	r.rendering = nil
 }
 
 /*
@@ -950,29 +981,15 @@ to represent the receiver instance as a string using [text/template].
 */
 func (r *attributeType) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(`attributeType`).
-		Funcs(funcMap(map[string]any{
-			`Substring`:    func() string { return r.Substring.OID() },
-			`Ordering`:     func() string { return r.Ordering.OID() },
-			`Equality`:     func() string { return r.Equality.OID() },
-			`Syntax`:       func() string { return r.Syntax.NumericOID() },
-			`MUB`:          func() string { return `{` + uitoa(r.MUB) + `}` },
-			`SuperType`:    func() string { return r.SuperType.OID() },
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			`Obsolete`:     func() bool { return r.Obsolete },
-			`IsSingleVal`:  func() bool { return r.Single },
-			`Collective`:   func() bool { return r.Collective },
-			`IsNoUserMod`:  func() bool { return r.NoUserMod },
-			`Usage`:        func() string { return AttributeType{r}.Usage() },
-		}))
-
-	if t, err = t.Parse(attributeTypeTmpl); err == nil {
+	t := newTemplate(`attributeType`).Funcs(funcMap(r.tmplFuncs()))
+
+	if t, err = t.Parse(r.schema.template(`attributeType`, attributeTypeTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *attributeType
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -981,6 +998,36 @@ func (r *attributeType) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *attributeType) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`OID`:          func() string { return r.schema.macroOID(f, r.OID) },
+		`Substring`:    func() string { return r.Substring.OID() },
+		`Ordering`:     func() string { return r.Ordering.OID() },
+		`Equality`:     func() string { return r.Equality.OID() },
+		`Syntax`:       func() string { return r.Syntax.NumericOID() },
+		`MUB`:          func() string { return `{` + uitoa(r.MUB) + `}` },
+		`SuperType`:    func() string { return r.SuperType.OID() },
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		`Obsolete`:     func() bool { return r.Obsolete },
+		`IsSingleVal`:  func() bool { return r.Single },
+		`Collective`:   func() bool { return r.Collective },
+		`IsNoUserMod`:  func() bool { return r.NoUserMod },
+		`Usage`: func() (usage string) {
+			usage = AttributeType{r}.Usage()
+			if len(usage) == 0 && f.EmitDefaults {
+				usage = `userApplications`
+			}
+			return
+		},
+	}, `NAME`, `DESC`, `OBSOLETE`, `SUP`, `EQUALITY`, `SUBSTR`, `ORDERING`,
+		`SYNTAX`, `SINGLE-VALUE`, `COLLECTIVE`, `NO-USER-MODIFICATION`, `USAGE`)
+}
+
 /*
 String is a stringer method that returns the string representation of
 the receiver instance.  A zero-value indicates an invalid receiver, or
@@ -1025,6 +1072,10 @@ func (r AttributeType) SetMinimumUpperBounds(mub any) AttributeType {
 }
 
 func (r *attributeType) setMinimumUpperBounds(mub any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	switch tv := mub.(type) {
 	case int:
 		if tv >= 0 {
@@ -1082,6 +1133,10 @@ func (r AttributeType) SetSyntax(x any) AttributeType {
 }
 
 func (r *attributeType) setSyntax(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var def LDAPSyntax
 	switch tv := x.(type) {
 	case string:
@@ -1156,6 +1211,10 @@ func (r AttributeType) SetEquality(x any) AttributeType {
 }
 
 func (r *attributeType) setEquality(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var def MatchingRule
 	switch tv := x.(type) {
 	case string:
@@ -1230,6 +1289,10 @@ func (r AttributeType) SetSubstring(x any) AttributeType {
 }
 
 func (r *attributeType) setSubstring(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var def MatchingRule
 	switch tv := x.(type) {
 	case string:
@@ -1304,6 +1367,10 @@ func (r AttributeType) SetOrdering(x any) AttributeType {
 }
 
 func (r *attributeType) setOrdering(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var def MatchingRule
 	switch tv := x.(type) {
 	case string:
@@ -1399,6 +1466,10 @@ func (r AttributeType) SetSuperType(x any) AttributeType {
 }
 
 func (r *attributeType) setSuperType(x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	var def AttributeType
 	switch tv := x.(type) {
 	case string:
@@ -1491,6 +1562,9 @@ func (r AttributeType) SetObsolete(x any) AttributeType {
 }
 
 func (r *attributeType) setBoolean(t string, x any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
 
 	var Bool bool
 	switch tv := x.(type) {
@@ -1545,6 +1619,10 @@ func (r AttributeType) SetUsage(u any) AttributeType {
 }
 
 func (r *attributeType) setUsage(u any) {
+	if !modify(AttributeType{r}, r.rendering) {
+		return
+	}
+
 	switch tv := u.(type) {
 	case string:
 		switch lc(tv) {
@@ -1707,6 +1785,9 @@ func (r AttributeType) Map() (def DefinitionMap) {
 	def[`SUBSTR`] = []string{r.Substring().OID()}
 	def[`ORDERING`] = []string{r.Ordering().OID()}
 	def[`SYNTAX`] = []string{r.Syntax().NumericOID()}
+	if mub := r.MinimumUpperBounds(); mub > 0 && len(def[`SYNTAX`][0]) > 0 {
+		def[`SYNTAX`][0] += `{` + uitoa(mub) + `}`
+	}
 	def[`SINGLE-VALUE`] = []string{bool2str(r.SingleValue())}
 	def[`COLLECTIVE`] = []string{bool2str(r.Collective())}
 	def[`NO-USER-MODIFICATION`] = []string{bool2str(r.NoUserModification())}
@@ -1738,6 +1819,12 @@ func (r AttributeType) setOID(x string) {
 	}
 }
 
+func (r AttributeType) resetRendering() {
+	if !r.IsZero() {
+		r.attributeType.rendering.reset()
+	}
+}
+
 /*
 LoadAttributeTypes returns an error following an attempt to load all
 built-in [AttributeType] slices into the receiver instance.
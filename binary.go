@@ -0,0 +1,711 @@
+package schemax
+
+/*
+binary.go implements the persistence of Schema instances in binary form.
+*/
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+)
+
+/*
+binaryMagic prefixes all binary-encoded [Schema] instances.
+*/
+const binaryMagic string = `schemax`
+
+/*
+binaryVersion is the current revision of the binary format produced by
+[Schema.MarshalBinary].  It MUST be incremented whenever the format (or
+the meaning of any value therein) changes, such that content produced by
+a prior revision is rejected by [Schema.UnmarshalBinary].
+*/
+const binaryVersion uint16 = 1
+
+/*
+binarySchema is the encoded form of a [Schema] instance. Definitions are
+stored in the order of the collections within a [Schema].
+*/
+type binarySchema struct {
+	DN          string
+	Options     uint16
+	Macros      map[string]string
+	Definitions [8][]binaryDefinition
+}
+
+/*
+binaryDefinition is the encoded form of any [Definition]. References to
+other definitions are stored using numeric OIDs (or rule IDs, in the case
+of [DITStructureRule] instances), and are resolved upon decoding.
+
+In the case of [MatchingRuleUse] and [DITContentRule] instances, OID is
+the numeric OID of the respective [MatchingRule] or [ObjectClass].
+*/
+type binaryDefinition struct {
+	OID        string
+	RuleID     uint
+	Macro      []string
+	Name       []string
+	Desc       string
+	Obsolete   bool
+	Single     bool
+	Collective bool
+	NoUserMod  bool
+	MUB        uint
+	Usage      uint
+	Kind       uint
+
+	Syntax     string
+	SuperType  string
+	Equality   string
+	Ordering   string
+	Substring  string
+	Structural string
+	Form       string
+
+	SuperClasses []string
+	Aux          []string
+	Must         []string
+	May          []string
+	Not          []string
+	Applies      []string
+	SuperRules   []uint
+
+	Extensions []binaryExtension
+}
+
+/*
+binaryExtension is the encoded form of an [Extension].
+*/
+type binaryExtension struct {
+	XString string
+	Values  []string
+}
+
+/*
+MarshalBinary returns the receiver instance in binary form alongside an
+error, thereby implementing the [encoding.BinaryMarshaler] interface.
+
+The return value contains all definitions, the [Macros] instance, the
+[Options] bit settings and the DN of the receiver, and is prefixed by a
+header bearing the revision of the binary format.  See [Schema.UnmarshalBinary]
+for a means of restoring the receiver from the return value.
+
+[SyntaxQualifier], [AssertionMatcher], [ValueQualifier] and [Stringer]
+closures, as well as user data values (see the various SetData methods),
+cannot be persisted and are not included.
+
+If the receiver is an overlay (see [Schema.Overlay]), the definitions of
+its base are not included.
+*/
+func (r Schema) MarshalBinary() (data []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	bs := binarySchema{
+		DN:      r.DN(),
+		Options: r.Options().binary(),
+		Macros:  r.Macros().macros,
+	}
+
+	for i := 0; i < r.LDAPSyntaxes().len(); i++ {
+		bs.Definitions[ldapSyntaxesIndex] = append(bs.Definitions[ldapSyntaxesIndex],
+			r.LDAPSyntaxes().index(i).binary())
+	}
+
+	for i := 0; i < r.MatchingRules().len(); i++ {
+		bs.Definitions[matchingRulesIndex] = append(bs.Definitions[matchingRulesIndex],
+			r.MatchingRules().index(i).binary())
+	}
+
+	for i := 0; i < r.AttributeTypes().len(); i++ {
+		bs.Definitions[attributeTypesIndex] = append(bs.Definitions[attributeTypesIndex],
+			r.AttributeTypes().index(i).binary())
+	}
+
+	for i := 0; i < r.MatchingRuleUses().len(); i++ {
+		bs.Definitions[matchingRuleUsesIndex] = append(bs.Definitions[matchingRuleUsesIndex],
+			r.MatchingRuleUses().index(i).binary())
+	}
+
+	for i := 0; i < r.ObjectClasses().len(); i++ {
+		bs.Definitions[objectClassesIndex] = append(bs.Definitions[objectClassesIndex],
+			r.ObjectClasses().index(i).binary())
+	}
+
+	for i := 0; i < r.DITContentRules().len(); i++ {
+		bs.Definitions[dITContentRulesIndex] = append(bs.Definitions[dITContentRulesIndex],
+			r.DITContentRules().index(i).binary())
+	}
+
+	for i := 0; i < r.NameForms().len(); i++ {
+		bs.Definitions[nameFormsIndex] = append(bs.Definitions[nameFormsIndex],
+			r.NameForms().index(i).binary())
+	}
+
+	for i := 0; i < r.DITStructureRules().len(); i++ {
+		bs.Definitions[dITStructureRulesIndex] = append(bs.Definitions[dITStructureRulesIndex],
+			r.DITStructureRules().index(i).binary())
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(binaryMagic)
+	binary.Write(buf, binary.BigEndian, binaryVersion)
+	if err = gob.NewEncoder(buf).Encode(bs); err == nil {
+		data = buf.Bytes()
+	}
+
+	return
+}
+
+/*
+UnmarshalBinary returns an error following an attempt to restore the
+receiver instance from data, as produced by [Schema.MarshalBinary], thus
+implementing the [encoding.BinaryUnmarshaler] interface.
+
+All references between definitions are rewired; no parsing takes place.
+Upon success, the receiver is replaced with a new instance of [Schema].
+
+If the receiver is an overlay (see [Schema.Overlay]) at the time this
+method is called, the new instance is an overlay of the same base, and
+references to base definitions are resolved therein.
+
+[ErrBinaryFormat] is returned if data is not in the expected format, and
+[ErrBinaryVersion] is returned if data was produced using a different
+revision of the binary format, e.g.: by a previous release of this package.
+Either condition should be handled by parsing the original definitions
+anew.
+*/
+func (r *Schema) UnmarshalBinary(data []byte) (err error) {
+	if r == nil {
+		err = ErrNilReceiver
+		return
+	}
+
+	hlen := len(binaryMagic) + 2
+	if len(data) < hlen || string(data[:len(binaryMagic)]) != binaryMagic {
+		err = ErrBinaryFormat
+		return
+	} else if binary.BigEndian.Uint16(data[len(binaryMagic):hlen]) != binaryVersion {
+		err = ErrBinaryVersion
+		return
+	}
+
+	var bs binarySchema
+	if err = gob.NewDecoder(bytes.NewReader(data[hlen:])).Decode(&bs); err != nil {
+		err = mkerr(ErrBinaryFormat.Error() + `: ` + err.Error())
+		return
+	}
+
+	var s Schema
+	if base := r.Base(); !base.IsZero() {
+		s = base.Overlay()
+	} else {
+		s = initSchema()
+	}
+
+	if err = s.unmarshalBinary(bs); err == nil {
+		*r = s
+	}
+
+	return
+}
+
+/*
+unmarshalBinary populates the receiver instance, which must be devoid of
+definitions, using the contents of bs.
+*/
+func (r Schema) unmarshalBinary(bs binarySchema) (err error) {
+	opts := newOpts()
+	for i := 0; i < 16; i++ {
+		if opt := Option(1 << i); bs.Options&uint16(opt) != 0 {
+			opts.Shift(opt)
+		}
+	}
+	r.cast().Auxiliary()[`options`] = opts
+	r.cast().SetID(bs.DN)
+
+	m := r.Macros()
+	for k, v := range bs.Macros {
+		m.macros[k] = v
+	}
+
+	dec := &binaryDecoder{schema: r}
+	dec.lDAPSyntaxes(bs.Definitions[ldapSyntaxesIndex])
+	dec.matchingRules(bs.Definitions[matchingRulesIndex])
+	dec.attributeTypes(bs.Definitions[attributeTypesIndex])
+	dec.matchingRuleUses(bs.Definitions[matchingRuleUsesIndex])
+	dec.objectClasses(bs.Definitions[objectClassesIndex])
+	dec.dITContentRules(bs.Definitions[dITContentRulesIndex])
+	dec.nameForms(bs.Definitions[nameFormsIndex])
+	dec.dITStructureRules(bs.Definitions[dITStructureRulesIndex])
+
+	return dec.err
+}
+
+/*
+binary returns the bit settings of the receiver instance.
+*/
+func (r Options) binary() (bits uint16) {
+	for i := 0; i < 16; i++ {
+		if opt := Option(1 << i); r.Positive(opt) {
+			bits |= uint16(opt)
+		}
+	}
+
+	return
+}
+
+func binaryExtensions(x Extensions) (e []binaryExtension) {
+	for i := 0; i < x.len(); i++ {
+		ext := x.index(i)
+		e = append(e, binaryExtension{
+			XString: ext.XString,
+			Values:  ext.Values.List(),
+		})
+	}
+
+	return
+}
+
+func binaryAttributeTypes(x AttributeTypes) (oids []string) {
+	for i := 0; i < x.len(); i++ {
+		oids = append(oids, x.index(i).NumericOID())
+	}
+
+	return
+}
+
+func binaryObjectClasses(x ObjectClasses) (oids []string) {
+	for i := 0; i < x.len(); i++ {
+		oids = append(oids, x.index(i).NumericOID())
+	}
+
+	return
+}
+
+func (r LDAPSyntax) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.lDAPSyntax.OID,
+		Macro:      r.lDAPSyntax.Macro,
+		Desc:       r.lDAPSyntax.Desc,
+		Extensions: binaryExtensions(r.lDAPSyntax.Extensions),
+	}
+}
+
+func (r MatchingRule) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.matchingRule.OID,
+		Macro:      r.matchingRule.Macro,
+		Name:       r.matchingRule.Name.List(),
+		Desc:       r.matchingRule.Desc,
+		Obsolete:   r.matchingRule.Obsolete,
+		Syntax:     r.matchingRule.Syntax.NumericOID(),
+		Extensions: binaryExtensions(r.matchingRule.Extensions),
+	}
+}
+
+func (r AttributeType) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.attributeType.OID,
+		Macro:      r.attributeType.Macro,
+		Name:       r.attributeType.Name.List(),
+		Desc:       r.attributeType.Desc,
+		Obsolete:   r.attributeType.Obsolete,
+		Single:     r.attributeType.Single,
+		Collective: r.attributeType.Collective,
+		NoUserMod:  r.attributeType.NoUserMod,
+		MUB:        r.attributeType.MUB,
+		Usage:      r.attributeType.Usage,
+		Syntax:     r.attributeType.Syntax.NumericOID(),
+		SuperType:  r.attributeType.SuperType.NumericOID(),
+		Equality:   r.attributeType.Equality.NumericOID(),
+		Ordering:   r.attributeType.Ordering.NumericOID(),
+		Substring:  r.attributeType.Substring.NumericOID(),
+		Extensions: binaryExtensions(r.attributeType.Extensions),
+	}
+}
+
+func (r MatchingRuleUse) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.matchingRuleUse.OID.NumericOID(),
+		Name:       r.matchingRuleUse.Name.List(),
+		Desc:       r.matchingRuleUse.Desc,
+		Obsolete:   r.matchingRuleUse.Obsolete,
+		Applies:    binaryAttributeTypes(r.matchingRuleUse.Applies),
+		Extensions: binaryExtensions(r.matchingRuleUse.Extensions),
+	}
+}
+
+func (r ObjectClass) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:          r.objectClass.OID,
+		Macro:        r.objectClass.Macro,
+		Name:         r.objectClass.Name.List(),
+		Desc:         r.objectClass.Desc,
+		Obsolete:     r.objectClass.Obsolete,
+		Kind:         r.objectClass.Kind,
+		SuperClasses: binaryObjectClasses(r.objectClass.SuperClasses),
+		Must:         binaryAttributeTypes(r.objectClass.Must),
+		May:          binaryAttributeTypes(r.objectClass.May),
+		Extensions:   binaryExtensions(r.objectClass.Extensions),
+	}
+}
+
+func (r DITContentRule) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.dITContentRule.OID.NumericOID(),
+		Macro:      r.dITContentRule.Macro,
+		Name:       r.dITContentRule.Name.List(),
+		Desc:       r.dITContentRule.Desc,
+		Obsolete:   r.dITContentRule.Obsolete,
+		Aux:        binaryObjectClasses(r.dITContentRule.Aux),
+		Must:       binaryAttributeTypes(r.dITContentRule.Must),
+		May:        binaryAttributeTypes(r.dITContentRule.May),
+		Not:        binaryAttributeTypes(r.dITContentRule.Not),
+		Extensions: binaryExtensions(r.dITContentRule.Extensions),
+	}
+}
+
+func (r NameForm) binary() binaryDefinition {
+	return binaryDefinition{
+		OID:        r.nameForm.OID,
+		Macro:      r.nameForm.Macro,
+		Name:       r.nameForm.Name.List(),
+		Desc:       r.nameForm.Desc,
+		Obsolete:   r.nameForm.Obsolete,
+		Structural: r.nameForm.Structural.NumericOID(),
+		Must:       binaryAttributeTypes(r.nameForm.Must),
+		May:        binaryAttributeTypes(r.nameForm.May),
+		Extensions: binaryExtensions(r.nameForm.Extensions),
+	}
+}
+
+func (r DITStructureRule) binary() binaryDefinition {
+	var sup []uint
+	for i := 0; i < r.dITStructureRule.SuperRules.len(); i++ {
+		sup = append(sup, r.dITStructureRule.SuperRules.index(i).RuleID())
+	}
+
+	return binaryDefinition{
+		RuleID:     r.dITStructureRule.ID,
+		Name:       r.dITStructureRule.Name.List(),
+		Desc:       r.dITStructureRule.Desc,
+		Obsolete:   r.dITStructureRule.Obsolete,
+		Form:       r.dITStructureRule.Form.NumericOID(),
+		SuperRules: sup,
+		Extensions: binaryExtensions(r.dITStructureRule.Extensions),
+	}
+}
+
+/*
+binaryDecoder restores the definitions of a [Schema] from their encoded
+forms, one collection at a time and in the order of the collections within
+a [Schema].
+
+Definitions of the same type may reference one another (e.g.: an
+[AttributeType] super type) regardless of order, thus all definitions of
+a collection are allocated prior to any being pushed.
+
+Where the target is an overlay, definitions which shadow those of the base
+are pushed regardless.
+*/
+type binaryDecoder struct {
+	schema Schema
+	err    error
+
+	ats map[string]AttributeType
+	ocs map[string]ObjectClass
+	dss map[uint]DITStructureRule
+}
+
+/*
+fail records the first error encountered.
+*/
+func (r *binaryDecoder) fail(err error, id string) {
+	if r.err == nil {
+		r.err = mkerr(err.Error() + `: ` + id)
+	}
+}
+
+func (r *binaryDecoder) names(x []string) (n QuotedDescriptorList) {
+	n = NewName()
+	for i := 0; i < len(x); i++ {
+		n.cast().Push(x[i])
+	}
+
+	return
+}
+
+func (r *binaryDecoder) extensions(x []binaryExtension, e Extensions, def Definition) {
+	e.setDefinition(def)
+	for i := 0; i < len(x); i++ {
+		e.Set(x[i].XString, x[i].Values...)
+	}
+}
+
+func (r *binaryDecoder) lDAPSyntax(id string) (ls LDAPSyntax) {
+	if len(id) > 0 {
+		if ls = r.schema.LDAPSyntaxes().get(id); ls.IsZero() {
+			r.fail(ErrLDAPSyntaxNotFound, id)
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) matchingRule(id string) (mr MatchingRule) {
+	if len(id) > 0 {
+		if mr = r.schema.MatchingRules().get(id); mr.IsZero() {
+			r.fail(ErrMatchingRuleNotFound, id)
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) attributeType(id string) (at AttributeType) {
+	if len(id) > 0 {
+		if at = r.ats[id]; at.IsZero() {
+			if at = r.schema.AttributeTypes().get(id); at.IsZero() {
+				r.fail(ErrAttributeTypeNotFound, id)
+			}
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) objectClass(id string) (oc ObjectClass) {
+	if len(id) > 0 {
+		if oc = r.ocs[id]; oc.IsZero() {
+			if oc = r.schema.ObjectClasses().get(id); oc.IsZero() {
+				r.fail(ErrObjectClassNotFound, id)
+			}
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) nameForm(id string) (nf NameForm) {
+	if len(id) > 0 {
+		if nf = r.schema.NameForms().get(id); nf.IsZero() {
+			r.fail(ErrNameFormNotFound, id)
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) dITStructureRule(id uint) (ds DITStructureRule) {
+	if ds = r.dss[id]; ds.IsZero() {
+		if ds = r.schema.DITStructureRules().get(id); ds.IsZero() {
+			r.fail(ErrDITStructureRuleNotFound, uitoa(id))
+		}
+	}
+
+	return
+}
+
+func (r *binaryDecoder) attributeTypeList(ids []string, l AttributeTypes) {
+	for i := 0; i < len(ids); i++ {
+		if at := r.attributeType(ids[i]); !at.IsZero() {
+			l.cast().Push(at)
+		}
+	}
+}
+
+func (r *binaryDecoder) objectClassList(ids []string, l ObjectClasses) {
+	for i := 0; i < len(ids); i++ {
+		if oc := r.objectClass(ids[i]); !oc.IsZero() {
+			l.cast().Push(oc)
+		}
+	}
+}
+
+func (r *binaryDecoder) lDAPSyntaxes(bds []binaryDefinition) {
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd := bds[i]
+		_def := newLDAPSyntax()
+		_def.OID = bd.OID
+		_def.Macro = bd.Macro
+		_def.Desc = bd.Desc
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, LDAPSyntax{_def})
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+		pushLocal(r.schema.LDAPSyntaxes().cast(), LDAPSyntax{_def})
+	}
+}
+
+func (r *binaryDecoder) matchingRules(bds []binaryDefinition) {
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd := bds[i]
+		_def := newMatchingRule()
+		_def.OID = bd.OID
+		_def.Macro = bd.Macro
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		_def.Syntax = r.lDAPSyntax(bd.Syntax)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, MatchingRule{_def})
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+		pushLocal(r.schema.MatchingRules().cast(), MatchingRule{_def})
+	}
+}
+
+func (r *binaryDecoder) attributeTypes(bds []binaryDefinition) {
+	defs := make([]AttributeType, len(bds))
+	r.ats = make(map[string]AttributeType, len(bds))
+	for i := 0; i < len(bds); i++ {
+		defs[i] = AttributeType{newAttributeType()}
+		r.ats[bds[i].OID] = defs[i]
+	}
+
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd, _def := bds[i], defs[i].attributeType
+		_def.OID = bd.OID
+		_def.Macro = bd.Macro
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		_def.Single = bd.Single
+		_def.Collective = bd.Collective
+		_def.NoUserMod = bd.NoUserMod
+		_def.MUB = bd.MUB
+		_def.Usage = bd.Usage
+		_def.Syntax = r.lDAPSyntax(bd.Syntax)
+		_def.SuperType = r.attributeType(bd.SuperType)
+		_def.Equality = r.matchingRule(bd.Equality)
+		_def.Ordering = r.matchingRule(bd.Ordering)
+		_def.Substring = r.matchingRule(bd.Substring)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, defs[i])
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	}
+
+	for i := 0; i < len(defs) && r.err == nil; i++ {
+		pushLocal(r.schema.AttributeTypes().cast(), defs[i])
+	}
+	r.ats = nil
+}
+
+func (r *binaryDecoder) matchingRuleUses(bds []binaryDefinition) {
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd := bds[i]
+		_def := newMatchingRuleUse()
+		_def.OID = r.matchingRule(bd.OID)
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		r.attributeTypeList(bd.Applies, _def.Applies)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, MatchingRuleUse{_def})
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+		pushLocal(r.schema.MatchingRuleUses().cast(), MatchingRuleUse{_def})
+	}
+}
+
+func (r *binaryDecoder) objectClasses(bds []binaryDefinition) {
+	defs := make([]ObjectClass, len(bds))
+	r.ocs = make(map[string]ObjectClass, len(bds))
+	for i := 0; i < len(bds); i++ {
+		defs[i] = ObjectClass{newObjectClass()}
+		r.ocs[bds[i].OID] = defs[i]
+	}
+
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd, _def := bds[i], defs[i].objectClass
+		_def.OID = bd.OID
+		_def.Macro = bd.Macro
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		_def.Kind = bd.Kind
+		r.objectClassList(bd.SuperClasses, _def.SuperClasses)
+		r.attributeTypeList(bd.Must, _def.Must)
+		r.attributeTypeList(bd.May, _def.May)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, defs[i])
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	}
+
+	for i := 0; i < len(defs) && r.err == nil; i++ {
+		pushLocal(r.schema.ObjectClasses().cast(), defs[i])
+	}
+	r.ocs = nil
+}
+
+func (r *binaryDecoder) dITContentRules(bds []binaryDefinition) {
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd := bds[i]
+		_def := newDITContentRule()
+		_def.OID = r.objectClass(bd.OID)
+		_def.Macro = bd.Macro
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		r.objectClassList(bd.Aux, _def.Aux)
+		r.attributeTypeList(bd.Must, _def.Must)
+		r.attributeTypeList(bd.May, _def.May)
+		r.attributeTypeList(bd.Not, _def.Not)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, DITContentRule{_def})
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+		pushLocal(r.schema.DITContentRules().cast(), DITContentRule{_def})
+	}
+}
+
+func (r *binaryDecoder) nameForms(bds []binaryDefinition) {
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd := bds[i]
+		_def := newNameForm()
+		_def.OID = bd.OID
+		_def.Macro = bd.Macro
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		_def.Structural = r.objectClass(bd.Structural)
+		r.attributeTypeList(bd.Must, _def.Must)
+		r.attributeTypeList(bd.May, _def.May)
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, NameForm{_def})
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+		pushLocal(r.schema.NameForms().cast(), NameForm{_def})
+	}
+}
+
+func (r *binaryDecoder) dITStructureRules(bds []binaryDefinition) {
+	defs := make([]DITStructureRule, len(bds))
+	r.dss = make(map[uint]DITStructureRule, len(bds))
+	for i := 0; i < len(bds); i++ {
+		defs[i] = DITStructureRule{newDITStructureRule()}
+		r.dss[bds[i].RuleID] = defs[i]
+	}
+
+	for i := 0; i < len(bds) && r.err == nil; i++ {
+		bd, _def := bds[i], defs[i].dITStructureRule
+		_def.ID = bd.RuleID
+		_def.Name = r.names(bd.Name)
+		_def.Desc = bd.Desc
+		_def.Obsolete = bd.Obsolete
+		_def.Form = r.nameForm(bd.Form)
+		for j := 0; j < len(bd.SuperRules); j++ {
+			if sup := r.dITStructureRule(bd.SuperRules[j]); !sup.IsZero() {
+				_def.SuperRules.cast().Push(sup)
+			}
+		}
+		_def.schema = r.schema
+		r.extensions(bd.Extensions, _def.Extensions, defs[i])
+		_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	}
+
+	for i := 0; i < len(defs) && r.err == nil; i++ {
+		pushLocal(r.schema.DITStructureRules().cast(), defs[i])
+	}
+	r.dss = nil
+}
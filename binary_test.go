@@ -0,0 +1,150 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates saving a [Schema] in binary form, and restoring
+it without the need to parse its definitions anew.
+*/
+func ExampleSchema_MarshalBinary() {
+	data, err := NewSchema().MarshalBinary()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var sch Schema
+	if err = sch.UnmarshalBinary(data); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	cn := sch.AttributeTypes().Get(`cn`)
+	fmt.Println(cn.SuperType().Name(), cn.SuperType().Schema() == sch)
+	// Output: name true
+}
+
+func TestSchema_MarshalBinary(t *testing.T) {
+	orig := mySchema.Clone()
+	orig.SetDN(`cn=subschema`)
+	orig.Macros().Set(`binaryMacro`, `1.3.6.1.4.1.56521.999.99`)
+
+	data, err := orig.MarshalBinary()
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	var restored Schema
+	if err = restored.UnmarshalBinary(data); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if restored.Counters() != orig.Counters() {
+		t.Errorf("%s failed: counters mismatch:\nwant: %#v\ngot:  %#v",
+			t.Name(), orig.Counters(), restored.Counters())
+		return
+	} else if restored.DN() != orig.DN() || restored.Options().binary() != orig.Options().binary() {
+		t.Errorf("%s failed: DN or options mismatch", t.Name())
+		return
+	} else if _, found := restored.Macros().Resolve(`binaryMacro`); !found {
+		t.Errorf("%s failed: macros not restored", t.Name())
+		return
+	}
+
+	for i, defs := range orig.collections() {
+		if want, got := defs.String(), restored.collections()[i].String(); want != got {
+			t.Errorf("%s failed: %s mismatch", t.Name(), defs.Type())
+			return
+		}
+	}
+
+	// references are rewired to restored definitions
+	for i := 0; i < restored.ObjectClasses().Len(); i++ {
+		oc := restored.ObjectClasses().Index(i)
+		for j := 0; j < oc.Must().Len(); j++ {
+			if must := oc.Must().Index(j); must != restored.AttributeTypes().Get(must.NumericOID()) {
+				t.Errorf("%s failed: %s not rewired", t.Name(), must.Name())
+				return
+			}
+		}
+	}
+
+	// stale or bogus content is rejected
+	stale := append([]byte{}, data...)
+	stale[len(binaryMagic)+1]++
+	if err = restored.UnmarshalBinary(stale); err != ErrBinaryVersion {
+		t.Errorf("%s failed: want '%v', got '%v'", t.Name(), ErrBinaryVersion, err)
+		return
+	} else if err = restored.UnmarshalBinary([]byte(`bogus`)); err != ErrBinaryFormat {
+		t.Errorf("%s failed: want '%v', got '%v'", t.Name(), ErrBinaryFormat, err)
+		return
+	} else if err = restored.UnmarshalBinary(data[:len(data)/2]); err == nil {
+		t.Errorf("%s failed: truncated content accepted", t.Name())
+		return
+	}
+
+	var zero Schema
+	if _, err = zero.MarshalBinary(); err == nil {
+		t.Errorf("%s failed: expected error marshaling zero instance", t.Name())
+	}
+}
+
+func TestSchema_MarshalBinary_overlay(t *testing.T) {
+	overlay := NewSchema().Overlay(AllowOverride)
+	overlay.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.99.1
+		NAME 'binaryAttr'
+		SUP name )`)
+	gon := overlay.ObjectClasses().Get(`groupOfNames`)
+	overlay.Replace(overlay.NewObjectClass().
+		SetNumericOID(gon.NumericOID()).
+		SetName(`groupOfMembers`).
+		SetKind(gon.Kind()).
+		SetSuperClass(`top`).
+		SetMust(`cn`, `binaryAttr`).
+		SetStringer())
+
+	data, err := overlay.MarshalBinary()
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// base definitions are not included
+	var standalone Schema
+	if err = standalone.UnmarshalBinary(data); err == nil {
+		t.Errorf("%s failed: unresolvable references accepted", t.Name())
+		return
+	}
+
+	restored := overlay.Base().Overlay()
+	if err = restored.UnmarshalBinary(data); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if !restored.IsOverlay() || restored.Counters() != overlay.Counters() {
+		t.Errorf("%s failed: unexpected overlay contents", t.Name())
+		return
+	} else if oc := restored.ObjectClasses().Get(gon.NumericOID()); oc.Name() != `groupOfMembers` {
+		t.Errorf("%s failed: shadow not restored", t.Name())
+		return
+	} else if at := restored.AttributeTypes().Get(`binaryAttr`); at.SuperType() != overlay.Base().AttributeTypes().Get(`name`) {
+		t.Errorf("%s failed: base reference not resolved", t.Name())
+	}
+}
+
+func BenchmarkSchema_UnmarshalBinary(b *testing.B) {
+	data, err := newSchema().MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var sch Schema
+		if err = sch.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
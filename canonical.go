@@ -0,0 +1,551 @@
+package schemax
+
+/*
+canonical.go implements the deterministic canonical rendering of
+definitions, collections and Schema instances.
+*/
+
+import (
+	"sort"
+)
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance, in which each [Definition] appears on its own line, prefixed
+by its type label (e.g.: "attributeType"), such that the return value
+may be parsed using [Schema.ParseRaw].
+
+Collections are rendered in the order shown by [Schema.Counters].  See
+the Canonical method of each collection type (e.g.:
+[AttributeTypes.Canonical]) for details on the ordering of definitions,
+and that of each [Definition] type (e.g.: [AttributeType.Canonical]) for
+details on the form of each line.
+
+Semantically equal instances of [Schema] produce byte-identical return
+values, regardless of the order in which their definitions were written
+and regardless of the [Options] in effect.
+*/
+func (r Schema) Canonical() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	buf := newBuf()
+	for _, defs := range r.collections() {
+		for _, def := range canonicalOrder(defs) {
+			buf.WriteString(def.Type() + ` ` + canonical(def) + string(rune(10)))
+		}
+	}
+
+	return buf.String()
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+
+Definitions appear one per line, ordered such that each [Definition]
+follows any superior upon which it depends; ties are resolved by numeric
+OID.
+*/
+func (r LDAPSyntaxes) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeTypes.Canonical] for details.
+*/
+func (r MatchingRules) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  Definitions appear one per line, ordered such that each
+[AttributeType] follows its super type; ties are resolved by numeric OID.
+See [AttributeType.Canonical] for details on the form of each line.
+*/
+func (r AttributeTypes) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeTypes.Canonical] for details.
+*/
+func (r MatchingRuleUses) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  Definitions appear one per line, ordered such that each
+[ObjectClass] follows its superior classes; ties are resolved by numeric
+OID.  See [AttributeType.Canonical] for details on the form of each line.
+*/
+func (r ObjectClasses) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeTypes.Canonical] for details.
+*/
+func (r DITContentRules) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeTypes.Canonical] for details.
+*/
+func (r NameForms) Canonical() string {
+	return canonicalCollection(r)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  Definitions appear one per line, ordered such that each
+[DITStructureRule] follows its superior rules; ties are resolved by rule
+ID.  See [AttributeType.Canonical] for details on the form of each line.
+*/
+func (r DITStructureRules) Canonical() string {
+	return canonicalCollection(r)
+}
+
+func canonicalCollection(defs Definitions) string {
+	buf := newBuf()
+	for _, def := range canonicalOrder(defs) {
+		buf.WriteString(canonical(def) + string(rune(10)))
+	}
+
+	return buf.String()
+}
+
+/*
+canonical returns the canonical string representation of def, else a
+zero string if def does not bear a Canonical method.
+*/
+func canonical(def Definition) (s string) {
+	if c, ok := def.(interface{ Canonical() string }); ok {
+		s = c.Canonical()
+	}
+
+	return
+}
+
+/*
+canonicalOrder returns the contents of defs ordered such that every
+[Definition] follows those of the same type upon which it depends, with
+ties resolved by numeric OID (or rule ID).
+*/
+func canonicalOrder(defs Definitions) (ordered []Definition) {
+	var pending []Definition
+	for i := 0; i < defs.Len(); i++ {
+		if def := definitionAt(defs, i); def != nil && !def.IsZero() {
+			pending = append(pending, def)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return canonicalLess(pending[i], pending[j])
+	})
+
+	local := make(map[string]bool, len(pending))
+	for _, def := range pending {
+		local[sourceKey(def)] = true
+	}
+
+	done := make(map[string]bool, len(pending))
+	for len(pending) > 0 {
+		// take the lowest definition whose local
+		// dependencies have all been satisfied,
+		// else (in the event of a cycle) the lowest
+		// definition outright.
+		next := 0
+		for i, def := range pending {
+			if canonicalReady(def, local, done) {
+				next = i
+				break
+			}
+		}
+
+		def := pending[next]
+		pending = append(pending[:next], pending[next+1:]...)
+		ordered = append(ordered, def)
+		done[sourceKey(def)] = true
+	}
+
+	return
+}
+
+func canonicalReady(def Definition, local, done map[string]bool) bool {
+	for _, dep := range dependencies(def) {
+		key := sourceKey(dep)
+		if key != sourceKey(def) && local[key] && !done[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func canonicalLess(a, b Definition) bool {
+	x, ok1 := a.(DITStructureRule)
+	y, ok2 := b.(DITStructureRule)
+	if ok1 && ok2 {
+		return x.RuleID() < y.RuleID()
+	}
+
+	return numericOIDLess(a.NumericOID(), b.NumericOID())
+}
+
+/*
+numericOIDLess returns a Boolean value indicative of numeric OID a
+preceding numeric OID b, arc by arc.
+*/
+func numericOIDLess(a, b string) bool {
+	x, y := split(a, `.`), split(b, `.`)
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if len(x[i]) != len(y[i]) {
+			return len(x[i]) < len(y[i])
+		} else if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+
+	return len(x) < len(y)
+}
+
+/*
+canonicalDefinition assembles the canonical form of a [Definition] using
+its identifier followed by the input clauses, omitting zero clauses.
+*/
+func canonicalDefinition(id string, clauses ...string) string {
+	s := `( ` + id
+	for _, clause := range clauses {
+		if len(clause) > 0 {
+			s += ` ` + clause
+		}
+	}
+
+	return s + ` )`
+}
+
+/*
+canonicalClause returns label followed by values, which are sorted and
+expressed as a list if more than one value is present.  A zero string is
+returned if no values are present.
+*/
+func canonicalClause(label string, values []string, quote bool) (s string) {
+	if len(values) == 0 {
+		return
+	}
+
+	vals := make([]string, len(values))
+	copy(vals, values)
+	if quote {
+		sort.Strings(vals)
+		for i := range vals {
+			vals[i] = canonicalQDString(vals[i])
+		}
+	} else {
+		sort.SliceStable(vals, func(i, j int) bool {
+			return numericOIDLess(vals[i], vals[j])
+		})
+	}
+
+	if len(vals) == 1 {
+		return label + ` ` + vals[0]
+	}
+
+	delim := ` $ `
+	if quote {
+		delim = ` `
+	}
+
+	return label + ` ( ` + join(vals, delim) + ` )`
+}
+
+/*
+canonicalQDString returns x quoted, with whitespace condensed and with
+any single quotes and backslashes escaped as "\27" and "\5C" per § 4.1
+of RFC 4512.  Escapes already present within x are honored.
+*/
+func canonicalQDString(x string) string {
+	b := []rune(qdEscapes(condenseWHSP(x)))
+
+	var q string
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c == '\\' && i+1 < len(b) && (b[i+1] == '\'' || b[i+1] == '\\') {
+			i++
+			c = b[i]
+		}
+
+		switch c {
+		case '\'':
+			q += `\27`
+		case '\\':
+			q += `\5C`
+		default:
+			q += string(c)
+		}
+	}
+
+	return `'` + q + `'`
+}
+
+func canonicalFlag(label string, set bool) (s string) {
+	if set {
+		s = label
+	}
+
+	return
+}
+
+func canonicalText(label, value string) (s string) {
+	if len(value) > 0 {
+		s = label + ` ` + canonicalQDString(value)
+	}
+
+	return
+}
+
+func canonicalOID(label string, def Definition) (s string) {
+	if def != nil && !def.IsZero() {
+		s = label + ` ` + def.NumericOID()
+	}
+
+	return
+}
+
+func canonicalAttributeTypes(label string, x AttributeTypes) string {
+	var oids []string
+	for i := 0; i < x.Len(); i++ {
+		oids = append(oids, x.Index(i).NumericOID())
+	}
+
+	return canonicalClause(label, oids, false)
+}
+
+func canonicalObjectClasses(label string, x ObjectClasses) string {
+	var oids []string
+	for i := 0; i < x.Len(); i++ {
+		oids = append(oids, x.Index(i).NumericOID())
+	}
+
+	return canonicalClause(label, oids, false)
+}
+
+/*
+canonicalExtensions returns the extensions of def ordered by name, with
+the values of each extension left in their original order.
+*/
+func canonicalExtensions(def Definition) (s string) {
+	ext := def.Extensions()
+
+	var names []string
+	values := make(map[string][]string, 0)
+	for i := 0; i < ext.len(); i++ {
+		x := ext.index(i)
+		name := uc(x.XString)
+		names = append(names, name)
+		values[name] = x.Values.List()
+	}
+	sort.Strings(names)
+
+	var clauses []string
+	for _, name := range names {
+		vals := values[name]
+		switch len(vals) {
+		case 0:
+			continue
+		case 1:
+			clauses = append(clauses, name+` `+canonicalQDString(vals[0]))
+		default:
+			var q []string
+			for _, v := range vals {
+				q = append(q, canonicalQDString(v))
+			}
+			clauses = append(clauses, name+` ( `+join(q, ` `)+` )`)
+		}
+	}
+
+	return join(clauses, ` `)
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r LDAPSyntax) Canonical() (s string) {
+	if !r.IsZero() {
+		s = canonicalDefinition(r.NumericOID(),
+			canonicalText(`DESC`, r.Description()),
+			canonicalExtensions(r))
+	}
+
+	return
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r MatchingRule) Canonical() (s string) {
+	if !r.IsZero() {
+		s = canonicalDefinition(r.NumericOID(),
+			canonicalClause(`NAME`, r.Names().List(), true),
+			canonicalText(`DESC`, r.Description()),
+			canonicalFlag(`OBSOLETE`, r.Obsolete()),
+			canonicalOID(`SYNTAX`, r.Syntax()),
+			canonicalExtensions(r))
+	}
+
+	return
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance: a single line, unaffected by any [Stringer] or [Options], in
+which references are numeric OIDs and all lists are sorted.
+*/
+func (r AttributeType) Canonical() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	syntax := canonicalOID(`SYNTAX`, r.Syntax())
+	if mub := r.MinimumUpperBounds(); mub > 0 && len(syntax) > 0 {
+		syntax += `{` + uitoa(mub) + `}`
+	}
+
+	var usage string
+	if u := r.Usage(); len(u) > 0 {
+		usage = `USAGE ` + u
+	}
+
+	return canonicalDefinition(r.NumericOID(),
+		canonicalClause(`NAME`, r.Names().List(), true),
+		canonicalText(`DESC`, r.Description()),
+		canonicalFlag(`OBSOLETE`, r.Obsolete()),
+		canonicalOID(`SUP`, r.SuperType()),
+		canonicalOID(`EQUALITY`, r.Equality()),
+		canonicalOID(`ORDERING`, r.Ordering()),
+		canonicalOID(`SUBSTR`, r.Substring()),
+		syntax,
+		canonicalFlag(`SINGLE-VALUE`, r.SingleValue()),
+		canonicalFlag(`COLLECTIVE`, r.Collective()),
+		canonicalFlag(`NO-USER-MODIFICATION`, r.NoUserModification()),
+		usage,
+		canonicalExtensions(r))
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r MatchingRuleUse) Canonical() (s string) {
+	if !r.IsZero() {
+		s = canonicalDefinition(r.NumericOID(),
+			canonicalClause(`NAME`, r.Names().List(), true),
+			canonicalText(`DESC`, r.Description()),
+			canonicalFlag(`OBSOLETE`, r.Obsolete()),
+			canonicalAttributeTypes(`APPLIES`, r.Applies()),
+			canonicalExtensions(r))
+	}
+
+	return
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r ObjectClass) Canonical() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	kind := `STRUCTURAL`
+	switch r.Kind() {
+	case AbstractKind:
+		kind = `ABSTRACT`
+	case AuxiliaryKind:
+		kind = `AUXILIARY`
+	}
+
+	return canonicalDefinition(r.NumericOID(),
+		canonicalClause(`NAME`, r.Names().List(), true),
+		canonicalText(`DESC`, r.Description()),
+		canonicalFlag(`OBSOLETE`, r.Obsolete()),
+		canonicalObjectClasses(`SUP`, r.SuperClasses()),
+		kind,
+		canonicalAttributeTypes(`MUST`, r.Must()),
+		canonicalAttributeTypes(`MAY`, r.May()),
+		canonicalExtensions(r))
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r DITContentRule) Canonical() (s string) {
+	if !r.IsZero() {
+		s = canonicalDefinition(r.NumericOID(),
+			canonicalClause(`NAME`, r.Names().List(), true),
+			canonicalText(`DESC`, r.Description()),
+			canonicalFlag(`OBSOLETE`, r.Obsolete()),
+			canonicalObjectClasses(`AUX`, r.Aux()),
+			canonicalAttributeTypes(`MUST`, r.Must()),
+			canonicalAttributeTypes(`MAY`, r.May()),
+			canonicalAttributeTypes(`NOT`, r.Not()),
+			canonicalExtensions(r))
+	}
+
+	return
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r NameForm) Canonical() (s string) {
+	if !r.IsZero() {
+		s = canonicalDefinition(r.NumericOID(),
+			canonicalClause(`NAME`, r.Names().List(), true),
+			canonicalText(`DESC`, r.Description()),
+			canonicalFlag(`OBSOLETE`, r.Obsolete()),
+			canonicalOID(`OC`, r.OC()),
+			canonicalAttributeTypes(`MUST`, r.Must()),
+			canonicalAttributeTypes(`MAY`, r.May()),
+			canonicalExtensions(r))
+	}
+
+	return
+}
+
+/*
+Canonical returns the canonical string representation of the receiver
+instance.  See [AttributeType.Canonical] for details.
+*/
+func (r DITStructureRule) Canonical() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	var sups []string
+	for i := 0; i < r.SuperRules().Len(); i++ {
+		sups = append(sups, uitoa(r.SuperRules().Index(i).RuleID()))
+	}
+
+	return canonicalDefinition(uitoa(r.RuleID()),
+		canonicalClause(`NAME`, r.Names().List(), true),
+		canonicalText(`DESC`, r.Description()),
+		canonicalFlag(`OBSOLETE`, r.Obsolete()),
+		canonicalOID(`FORM`, r.Form()),
+		canonicalClause(`SUP`, sups, false),
+		canonicalExtensions(r))
+}
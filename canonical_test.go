@@ -0,0 +1,134 @@
+package schemax
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+/*
+This example demonstrates the canonical form of an [AttributeType], in
+which references are expressed as numeric OIDs and lists are sorted.
+*/
+func ExampleAttributeType_Canonical() {
+	cn := mySchema.AttributeTypes().Get(`cn`)
+	fmt.Println(cn.Canonical())
+	// Output: ( 2.5.4.3 NAME ( 'cn' 'commonName' ) DESC 'RFC4519: common name(s) for which the entity is known by' SUP 2.5.4.41 X-ORIGIN 'RFC4519' )
+}
+
+/*
+This example demonstrates the canonical ordering of a collection, in
+which superior definitions precede their subordinates.
+*/
+func ExampleObjectClasses_Canonical() {
+	sch, _ := NewSchema().Subset(`account`)
+	fmt.Print(sch.ObjectClasses().Canonical())
+	// Output:
+	// ( 2.5.6.0 NAME 'top' ABSTRACT MUST 2.5.4.0 X-ORIGIN 'RFC4512' )
+	// ( 0.9.2342.19200300.100.4.5 NAME 'account' SUP 2.5.6.0 STRUCTURAL MUST 0.9.2342.19200300.100.1.1 MAY ( 0.9.2342.19200300.100.1.9 $ 2.5.4.7 $ 2.5.4.10 $ 2.5.4.11 $ 2.5.4.13 $ 2.5.4.34 ) X-ORIGIN 'RFC4524' )
+}
+
+func TestSchema_Canonical(t *testing.T) {
+	want := mySchema.Canonical()
+
+	// reparsing the canonical form is lossless
+	reparsed := NewEmptySchema()
+	if err := reparsed.ParseRaw([]byte(want)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := reparsed.Canonical(); got != want {
+		t.Errorf("%s failed: canonical form not stable across reparse", t.Name())
+		return
+	}
+
+	// neither insertion order nor options are significant
+	dir, err := os.MkdirTemp(``, `schemax`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	reordered := NewEmptySchema(HangingIndents)
+	if err = mySchema.WriteDirectory(dir, LayoutByOrigin); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = reordered.ParseDirectory(dir); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if reordered.AttributeTypes().String() == mySchema.AttributeTypes().String() {
+		t.Errorf("%s failed: expected differing String output", t.Name())
+		return
+	}
+
+	if got := reordered.Canonical(); got != want {
+		t.Errorf("%s failed: canonical forms differ:\n%s", t.Name(), firstDiff(want, got))
+		return
+	}
+
+	// name order and whitespace are normalized
+	cn := reordered.NewAttributeType().
+		SetNumericOID(`2.5.4.3`).
+		SetName(`commonName`, `cn`).
+		SetDescription(`RFC4519:  common   name(s) for which the entity is known by`).
+		SetSuperType(`name`).
+		SetExtension(`X-ORIGIN`, `RFC4519`)
+	if want, got := mySchema.AttributeTypes().Get(`cn`).Canonical(), cn.Canonical(); want != got {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+		return
+	}
+
+	// quotes and backslashes are escaped per RFC 4512,
+	// and such escapes are honored when parsed
+	want = `( 2.5.4.3 NAME ( 'cn' 'commonName' ) DESC 'it\27s a \5C' SUP 2.5.4.41 X-ORIGIN 'RFC4519' )`
+	for _, d := range []string{`it's a \`, `it\'s a \\`, `it\27s a \5c`} {
+		desc := NewSchema().AttributeTypes().Get(`cn`).SetDescription(d)
+		if got := desc.Canonical(); got != want {
+			t.Errorf("%s failed: unexpected quoting: %s", t.Name(), got)
+			return
+		}
+	}
+
+	escaped := NewSchema()
+	want = `( 1.3.6.1.4.1.56521.999.3 NAME 'escapedName' DESC 'it\27s a \5C' SUP 2.5.4.41 )`
+	if err := escaped.ParseAttributeType(want); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := escaped.AttributeTypes().Get(`escapedName`).Canonical(); got != want {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+		return
+	}
+
+	var zero Schema
+	if zero.Canonical() != `` || (AttributeType{}).Canonical() != `` {
+		t.Errorf("%s failed: expected zero string", t.Name())
+	}
+}
+
+func TestNumericOIDLess(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b string
+		less bool
+	}{
+		{`2.5.4.3`, `2.5.4.13`, true},
+		{`2.5.4.13`, `2.5.4.3`, false},
+		{`2.5.4`, `2.5.4.0`, true},
+		{`1.3.6.1`, `2.5`, true},
+		{`2.5.4.3`, `2.5.4.3`, false},
+	} {
+		if got := numericOIDLess(tc.a, tc.b); got != tc.less {
+			t.Errorf("%s[%d] failed: want %t, got %t", t.Name(), idx, tc.less, got)
+		}
+	}
+}
+
+func firstDiff(a, b string) string {
+	x, y := split(a, "\n"), split(b, "\n")
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return "want: " + x[i] + "\ngot:  " + y[i]
+		}
+	}
+
+	return ``
+}
@@ -0,0 +1,591 @@
+package schemax
+
+/*
+clone.go implements deep copy capabilities for the Schema type.
+*/
+
+/*
+Clone returns a fully independent copy of the receiver instance.
+
+All [Definition] instances are reallocated, and all references between
+them (e.g.: an [ObjectClass] MUST clause, or an [AttributeType] super
+type) are rewired such that they point to the respective copies within
+the return instance rather than to the originals.  The [Macros] instance,
+[Options] bit settings and DN are also copied.
+
+Optional [DataCopier] input shall be used to copy any user-assigned data
+values (see the various SetData methods).  If no [DataCopier] is provided,
+the data values are shared between the receiver and the return instance.
+
+Note that [SyntaxQualifier], [AssertionMatcher], [ValueQualifier] and
+[Stringer] closures are carried over as-is.
+
+If the receiver is an overlay (see [Schema.Overlay]), the return instance
+is an overlay of the same base, which is shared rather than copied.
+
+A zero instance of [Schema] is returned if the receiver is zero.
+*/
+func (r Schema) Clone(copier ...DataCopier) (c Schema) {
+	if r.IsZero() {
+		return
+	}
+
+	c = r.cloneEmpty()
+	cl := newCloner(c, copier...)
+	cl.base = r.Base()
+
+	for i := 0; i < r.LDAPSyntaxes().len(); i++ {
+		c.LDAPSyntaxes().cast().Push(cl.lDAPSyntax(r.LDAPSyntaxes().index(i)))
+	}
+
+	for i := 0; i < r.MatchingRules().len(); i++ {
+		c.MatchingRules().cast().Push(cl.matchingRule(r.MatchingRules().index(i)))
+	}
+
+	for i := 0; i < r.AttributeTypes().len(); i++ {
+		c.AttributeTypes().cast().Push(cl.attributeType(r.AttributeTypes().index(i)))
+	}
+
+	for i := 0; i < r.MatchingRuleUses().len(); i++ {
+		c.MatchingRuleUses().cast().Push(cl.matchingRuleUse(r.MatchingRuleUses().index(i)))
+	}
+
+	for i := 0; i < r.ObjectClasses().len(); i++ {
+		c.ObjectClasses().cast().Push(cl.objectClass(r.ObjectClasses().index(i)))
+	}
+
+	for i := 0; i < r.DITContentRules().len(); i++ {
+		c.DITContentRules().cast().Push(cl.dITContentRule(r.DITContentRules().index(i)))
+	}
+
+	for i := 0; i < r.NameForms().len(); i++ {
+		c.NameForms().cast().Push(cl.nameForm(r.NameForms().index(i)))
+	}
+
+	for i := 0; i < r.DITStructureRules().len(); i++ {
+		c.DITStructureRules().cast().Push(cl.dITStructureRule(r.DITStructureRules().index(i)))
+	}
+
+	// The base is associated only once all copies
+	// are in place, as it would otherwise disallow
+	// pushes of copied shadows.
+	if !cl.base.IsZero() {
+		c.setBase(cl.base)
+	}
+
+	return
+}
+
+/*
+cloneEmpty returns a new instance of [Schema] devoid of definitions, but
+bearing copies of the receiver's DN, [Macros], [Options] and [Formatting]
+instances, as well as any registered templates.
+*/
+func (r Schema) cloneEmpty() (c Schema) {
+	c = initSchema()
+	c.cast().SetID(r.DN())
+	c.setMacros(r.Macros().clone())
+	c.cast().Auxiliary()[`options`] = r.Options().clone()
+	c.cast().Auxiliary()[`sources`] = r.sources().clone()
+	c.cast().Auxiliary()[`templates`] = r.templates().clone()
+	if f, ok := r.cast().Auxiliary()[`formatting`].(*Formatting); ok && f != nil {
+		c.SetFormatting(*f)
+	}
+
+	return
+}
+
+/*
+clone returns a copy of the receiver instance.
+*/
+func (r Macros) clone() (m Macros) {
+	m = newMacros()
+	for k, v := range r.macros {
+		m.macros[k] = v
+	}
+
+	return
+}
+
+/*
+clone returns a copy of the receiver instance bearing identical bit
+settings.
+*/
+func (r Options) clone() (o Options) {
+	o = newOpts()
+	for i := 0; i < 16; i++ {
+		if opt := Option(1 << i); r.Positive(opt) {
+			o.Shift(opt)
+		}
+	}
+
+	return
+}
+
+/*
+cloner tracks the copies made of each original definition such that
+any given definition is copied exactly once, regardless of how many
+times it is referenced.
+*/
+type cloner struct {
+	schema Schema
+	copier DataCopier
+
+	// resolve indicates that references should be satisfied
+	// by definitions already present within schema wherever
+	// possible, rather than by copies. The root definition
+	// (if any) is always copied.
+	resolve bool
+	root    any
+
+	// base is the base of the overlay being copied, if any.
+	// Definitions of the base are never copied.
+	base Schema
+
+	ls map[*lDAPSyntax]LDAPSyntax
+	mr map[*matchingRule]MatchingRule
+	at map[*attributeType]AttributeType
+	mu map[*matchingRuleUse]MatchingRuleUse
+	oc map[*objectClass]ObjectClass
+	dc map[*dITContentRule]DITContentRule
+	nf map[*nameForm]NameForm
+	ds map[*dITStructureRule]DITStructureRule
+}
+
+func newCloner(s Schema, copier ...DataCopier) *cloner {
+	cl := &cloner{
+		schema: s,
+		ls:     make(map[*lDAPSyntax]LDAPSyntax),
+		mr:     make(map[*matchingRule]MatchingRule),
+		at:     make(map[*attributeType]AttributeType),
+		mu:     make(map[*matchingRuleUse]MatchingRuleUse),
+		oc:     make(map[*objectClass]ObjectClass),
+		dc:     make(map[*dITContentRule]DITContentRule),
+		nf:     make(map[*nameForm]NameForm),
+		ds:     make(map[*dITStructureRule]DITStructureRule),
+	}
+
+	if len(copier) > 0 {
+		cl.copier = copier[0]
+	}
+	cl.base = s.Base()
+
+	return cl
+}
+
+/*
+existing returns a Boolean value indicative of whether a reference to
+ptr should be satisfied by a definition already present within the
+target schema.
+*/
+func (r *cloner) existing(ptr any) bool {
+	return r.resolve && ptr != r.root
+}
+
+/*
+shared returns a Boolean value indicative of whether ptr, which resides
+within s, is a definition of the base of the target schema, and should
+thus be referenced rather than copied.  The root definition (if any) is
+always copied.
+*/
+func (r *cloner) shared(ptr any, s Schema) bool {
+	return ptr != r.root && !r.base.IsZero() && s == r.base
+}
+
+func (r *cloner) data(x any) any {
+	if r.copier == nil || x == nil {
+		return x
+	}
+
+	return r.copier(x)
+}
+
+func (r *cloner) macro(x []string) (m []string) {
+	if len(x) > 0 {
+		m = make([]string, len(x))
+		copy(m, x)
+	}
+
+	return
+}
+
+func (r *cloner) name(x QuotedDescriptorList) (n QuotedDescriptorList) {
+	n = NewName()
+	for i := 0; i < x.len(); i++ {
+		n.cast().Push(x.index(i))
+	}
+
+	return
+}
+
+func (r *cloner) extensions(x Extensions, def Definition) (e Extensions) {
+	e = NewExtensions()
+	for i := 0; i < x.len(); i++ {
+		ext := x.index(i)
+		values := newQStringList(ext.Values.cast().ID())
+		for j := 0; j < ext.Values.len(); j++ {
+			values.cast().Push(ext.Values.index(j))
+		}
+
+		e.Push(Extension{&extension{
+			XString:  ext.XString,
+			Values:   values,
+			hindent:  ext.hindent,
+			sortExts: ext.sortExts,
+			stringer: ext.stringer,
+		}})
+	}
+	e.setDefinition(def)
+
+	return
+}
+
+func (r *cloner) attributeTypes(x AttributeTypes) (l AttributeTypes) {
+	l = NewAttributeTypeOIDList(x.cast().ID())
+	for i := 0; i < x.len(); i++ {
+		l.cast().Push(r.attributeType(x.index(i)))
+	}
+
+	return
+}
+
+func (r *cloner) objectClasses(x ObjectClasses) (l ObjectClasses) {
+	l = NewObjectClassOIDList(x.cast().ID())
+	for i := 0; i < x.len(); i++ {
+		l.cast().Push(r.objectClass(x.index(i)))
+	}
+
+	return
+}
+
+func (r *cloner) dITStructureRules(x DITStructureRules) (l DITStructureRules) {
+	l = NewDITStructureRuleIDList()
+	for i := 0; i < x.len(); i++ {
+		l.cast().Push(r.dITStructureRule(x.index(i)))
+	}
+
+	return
+}
+
+func (r *cloner) lDAPSyntax(x LDAPSyntax) LDAPSyntax {
+	if x.IsZero() {
+		return x
+	} else if ls, found := r.ls[x.lDAPSyntax]; found {
+		return ls
+	}
+
+	if r.shared(x.lDAPSyntax, x.lDAPSyntax.schema) {
+		return x
+	}
+
+	if r.existing(x.lDAPSyntax) {
+		if ls := r.schema.LDAPSyntaxes().get(x.NumericOID()); !ls.IsZero() {
+			r.ls[x.lDAPSyntax] = ls
+			return ls
+		}
+	}
+
+	ls := LDAPSyntax{new(lDAPSyntax)}
+	r.ls[x.lDAPSyntax] = ls
+
+	ls.lDAPSyntax.OID = x.lDAPSyntax.OID
+	ls.lDAPSyntax.Macro = r.macro(x.lDAPSyntax.Macro)
+	ls.lDAPSyntax.Desc = x.lDAPSyntax.Desc
+	ls.lDAPSyntax.Extensions = r.extensions(x.lDAPSyntax.Extensions, ls)
+	ls.lDAPSyntax.schema = r.schema
+	ls.lDAPSyntax.stringer, ls.lDAPSyntax.rendering = copyStringer(x.lDAPSyntax.stringer, x.lDAPSyntax.rendering, ls.lDAPSyntax.prepareString)
+	ls.lDAPSyntax.synQual = x.lDAPSyntax.synQual
+	ls.lDAPSyntax.data = r.data(x.lDAPSyntax.data)
+
+	return ls
+}
+
+func (r *cloner) matchingRule(x MatchingRule) MatchingRule {
+	if x.IsZero() {
+		return x
+	} else if mr, found := r.mr[x.matchingRule]; found {
+		return mr
+	}
+
+	if r.shared(x.matchingRule, x.matchingRule.schema) {
+		return x
+	}
+
+	if r.existing(x.matchingRule) {
+		if mr := r.schema.MatchingRules().get(x.NumericOID()); !mr.IsZero() {
+			r.mr[x.matchingRule] = mr
+			return mr
+		}
+	}
+
+	mr := MatchingRule{new(matchingRule)}
+	r.mr[x.matchingRule] = mr
+
+	mr.matchingRule.OID = x.matchingRule.OID
+	mr.matchingRule.Macro = r.macro(x.matchingRule.Macro)
+	mr.matchingRule.Name = r.name(x.matchingRule.Name)
+	mr.matchingRule.Desc = x.matchingRule.Desc
+	mr.matchingRule.Obsolete = x.matchingRule.Obsolete
+	mr.matchingRule.Syntax = r.lDAPSyntax(x.matchingRule.Syntax)
+	mr.matchingRule.Extensions = r.extensions(x.matchingRule.Extensions, mr)
+	mr.matchingRule.schema = r.schema
+	mr.matchingRule.stringer, mr.matchingRule.rendering = copyStringer(x.matchingRule.stringer, x.matchingRule.rendering, mr.matchingRule.prepareString)
+	mr.matchingRule.assMatch = x.matchingRule.assMatch
+	mr.matchingRule.data = r.data(x.matchingRule.data)
+
+	return mr
+}
+
+func (r *cloner) attributeType(x AttributeType) AttributeType {
+	if x.IsZero() {
+		return x
+	} else if at, found := r.at[x.attributeType]; found {
+		return at
+	}
+
+	if r.shared(x.attributeType, x.attributeType.schema) {
+		return x
+	}
+
+	if r.existing(x.attributeType) {
+		if at := r.schema.AttributeTypes().get(x.NumericOID()); !at.IsZero() {
+			r.at[x.attributeType] = at
+			return at
+		}
+	}
+
+	at := AttributeType{new(attributeType)}
+	r.at[x.attributeType] = at
+
+	at.attributeType.OID = x.attributeType.OID
+	at.attributeType.Macro = r.macro(x.attributeType.Macro)
+	at.attributeType.Desc = x.attributeType.Desc
+	at.attributeType.Name = r.name(x.attributeType.Name)
+	at.attributeType.Obsolete = x.attributeType.Obsolete
+	at.attributeType.Single = x.attributeType.Single
+	at.attributeType.Collective = x.attributeType.Collective
+	at.attributeType.NoUserMod = x.attributeType.NoUserMod
+	at.attributeType.SuperType = r.attributeType(x.attributeType.SuperType)
+	at.attributeType.Equality = r.matchingRule(x.attributeType.Equality)
+	at.attributeType.Ordering = r.matchingRule(x.attributeType.Ordering)
+	at.attributeType.Substring = r.matchingRule(x.attributeType.Substring)
+	at.attributeType.Syntax = r.lDAPSyntax(x.attributeType.Syntax)
+	at.attributeType.MUB = x.attributeType.MUB
+	at.attributeType.Usage = x.attributeType.Usage
+	at.attributeType.Extensions = r.extensions(x.attributeType.Extensions, at)
+	at.attributeType.schema = r.schema
+	at.attributeType.stringer, at.attributeType.rendering = copyStringer(x.attributeType.stringer, x.attributeType.rendering, at.attributeType.prepareString)
+	at.attributeType.valQual = x.attributeType.valQual
+	at.attributeType.data = r.data(x.attributeType.data)
+
+	return at
+}
+
+func (r *cloner) matchingRuleUse(x MatchingRuleUse) MatchingRuleUse {
+	if x.IsZero() {
+		return x
+	} else if mu, found := r.mu[x.matchingRuleUse]; found {
+		return mu
+	}
+
+	if r.shared(x.matchingRuleUse, x.matchingRuleUse.schema) {
+		return x
+	}
+
+	if r.existing(x.matchingRuleUse) {
+		if mu := r.schema.MatchingRuleUses().get(x.NumericOID()); !mu.IsZero() {
+			r.mu[x.matchingRuleUse] = mu
+			return mu
+		}
+	}
+
+	mu := MatchingRuleUse{new(matchingRuleUse)}
+	r.mu[x.matchingRuleUse] = mu
+
+	mu.matchingRuleUse.OID = r.matchingRule(x.matchingRuleUse.OID)
+	mu.matchingRuleUse.Name = r.name(x.matchingRuleUse.Name)
+	mu.matchingRuleUse.Desc = x.matchingRuleUse.Desc
+	mu.matchingRuleUse.Obsolete = x.matchingRuleUse.Obsolete
+	mu.matchingRuleUse.Applies = r.attributeTypes(x.matchingRuleUse.Applies)
+	mu.matchingRuleUse.Extensions = r.extensions(x.matchingRuleUse.Extensions, mu)
+	mu.matchingRuleUse.schema = r.schema
+	mu.matchingRuleUse.stringer, mu.matchingRuleUse.rendering = copyStringer(x.matchingRuleUse.stringer, x.matchingRuleUse.rendering, mu.matchingRuleUse.prepareString)
+	mu.matchingRuleUse.data = r.data(x.matchingRuleUse.data)
+
+	return mu
+}
+
+func (r *cloner) objectClass(x ObjectClass) ObjectClass {
+	if x.IsZero() {
+		return x
+	} else if oc, found := r.oc[x.objectClass]; found {
+		return oc
+	}
+
+	if r.shared(x.objectClass, x.objectClass.schema) {
+		return x
+	}
+
+	if r.existing(x.objectClass) {
+		if oc := r.schema.ObjectClasses().get(x.NumericOID()); !oc.IsZero() {
+			r.oc[x.objectClass] = oc
+			return oc
+		}
+	}
+
+	oc := ObjectClass{new(objectClass)}
+	r.oc[x.objectClass] = oc
+
+	oc.objectClass.OID = x.objectClass.OID
+	oc.objectClass.Macro = r.macro(x.objectClass.Macro)
+	oc.objectClass.Desc = x.objectClass.Desc
+	oc.objectClass.Name = r.name(x.objectClass.Name)
+	oc.objectClass.Obsolete = x.objectClass.Obsolete
+	oc.objectClass.SuperClasses = r.objectClasses(x.objectClass.SuperClasses)
+	oc.objectClass.Kind = x.objectClass.Kind
+	oc.objectClass.Must = r.attributeTypes(x.objectClass.Must)
+	oc.objectClass.May = r.attributeTypes(x.objectClass.May)
+	oc.objectClass.Extensions = r.extensions(x.objectClass.Extensions, oc)
+	oc.objectClass.schema = r.schema
+	oc.objectClass.stringer, oc.objectClass.rendering = copyStringer(x.objectClass.stringer, x.objectClass.rendering, oc.objectClass.prepareString)
+	oc.objectClass.data = r.data(x.objectClass.data)
+
+	return oc
+}
+
+func (r *cloner) dITContentRule(x DITContentRule) DITContentRule {
+	if x.IsZero() {
+		return x
+	} else if dc, found := r.dc[x.dITContentRule]; found {
+		return dc
+	}
+
+	if r.shared(x.dITContentRule, x.dITContentRule.schema) {
+		return x
+	}
+
+	if r.existing(x.dITContentRule) {
+		if dc := r.schema.DITContentRules().get(x.NumericOID()); !dc.IsZero() {
+			r.dc[x.dITContentRule] = dc
+			return dc
+		}
+	}
+
+	dc := DITContentRule{new(dITContentRule)}
+	r.dc[x.dITContentRule] = dc
+
+	dc.dITContentRule.OID = r.objectClass(x.dITContentRule.OID)
+	dc.dITContentRule.Macro = r.macro(x.dITContentRule.Macro)
+	dc.dITContentRule.Desc = x.dITContentRule.Desc
+	dc.dITContentRule.Name = r.name(x.dITContentRule.Name)
+	dc.dITContentRule.Obsolete = x.dITContentRule.Obsolete
+	dc.dITContentRule.Aux = r.objectClasses(x.dITContentRule.Aux)
+	dc.dITContentRule.Must = r.attributeTypes(x.dITContentRule.Must)
+	dc.dITContentRule.May = r.attributeTypes(x.dITContentRule.May)
+	dc.dITContentRule.Not = r.attributeTypes(x.dITContentRule.Not)
+	dc.dITContentRule.Extensions = r.extensions(x.dITContentRule.Extensions, dc)
+	dc.dITContentRule.schema = r.schema
+	dc.dITContentRule.stringer, dc.dITContentRule.rendering = copyStringer(x.dITContentRule.stringer, x.dITContentRule.rendering, dc.dITContentRule.prepareString)
+	dc.dITContentRule.data = r.data(x.dITContentRule.data)
+
+	return dc
+}
+
+func (r *cloner) nameForm(x NameForm) NameForm {
+	if x.IsZero() {
+		return x
+	} else if nf, found := r.nf[x.nameForm]; found {
+		return nf
+	}
+
+	if r.shared(x.nameForm, x.nameForm.schema) {
+		return x
+	}
+
+	if r.existing(x.nameForm) {
+		if nf := r.schema.NameForms().get(x.NumericOID()); !nf.IsZero() {
+			r.nf[x.nameForm] = nf
+			return nf
+		}
+	}
+
+	nf := NameForm{new(nameForm)}
+	r.nf[x.nameForm] = nf
+
+	nf.nameForm.OID = x.nameForm.OID
+	nf.nameForm.Macro = r.macro(x.nameForm.Macro)
+	nf.nameForm.Desc = x.nameForm.Desc
+	nf.nameForm.Name = r.name(x.nameForm.Name)
+	nf.nameForm.Obsolete = x.nameForm.Obsolete
+	nf.nameForm.Structural = r.objectClass(x.nameForm.Structural)
+	nf.nameForm.Must = r.attributeTypes(x.nameForm.Must)
+	nf.nameForm.May = r.attributeTypes(x.nameForm.May)
+	nf.nameForm.Extensions = r.extensions(x.nameForm.Extensions, nf)
+	nf.nameForm.schema = r.schema
+	nf.nameForm.stringer, nf.nameForm.rendering = copyStringer(x.nameForm.stringer, x.nameForm.rendering, nf.nameForm.prepareString)
+	nf.nameForm.data = r.data(x.nameForm.data)
+
+	return nf
+}
+
+func (r *cloner) dITStructureRule(x DITStructureRule) DITStructureRule {
+	if x.IsZero() {
+		return x
+	} else if ds, found := r.ds[x.dITStructureRule]; found {
+		return ds
+	}
+
+	if r.shared(x.dITStructureRule, x.dITStructureRule.schema) {
+		return x
+	}
+
+	if r.existing(x.dITStructureRule) {
+		if ds := r.schema.DITStructureRules().get(x.RuleID()); !ds.IsZero() {
+			r.ds[x.dITStructureRule] = ds
+			return ds
+		}
+	}
+
+	ds := DITStructureRule{new(dITStructureRule)}
+	r.ds[x.dITStructureRule] = ds
+
+	ds.dITStructureRule.ID = x.dITStructureRule.ID
+	ds.dITStructureRule.Desc = x.dITStructureRule.Desc
+	ds.dITStructureRule.Name = r.name(x.dITStructureRule.Name)
+	ds.dITStructureRule.Obsolete = x.dITStructureRule.Obsolete
+	ds.dITStructureRule.Form = r.nameForm(x.dITStructureRule.Form)
+	ds.dITStructureRule.SuperRules = r.dITStructureRules(x.dITStructureRule.SuperRules)
+	ds.dITStructureRule.Extensions = r.extensions(x.dITStructureRule.Extensions, ds)
+	ds.dITStructureRule.schema = r.schema
+	ds.dITStructureRule.stringer, ds.dITStructureRule.rendering = copyStringer(x.dITStructureRule.stringer, x.dITStructureRule.rendering, ds.dITStructureRule.prepareString)
+	ds.dITStructureRule.data = r.data(x.dITStructureRule.data)
+
+	return ds
+}
+
+/*
+definition returns a copy of x by way of the appropriate type-specific
+cloner method. A nil instance is returned if x is not a known type.
+*/
+func (r *cloner) definition(x Definition) (def Definition) {
+	switch tv := x.(type) {
+	case LDAPSyntax:
+		def = r.lDAPSyntax(tv)
+	case MatchingRule:
+		def = r.matchingRule(tv)
+	case AttributeType:
+		def = r.attributeType(tv)
+	case MatchingRuleUse:
+		def = r.matchingRuleUse(tv)
+	case ObjectClass:
+		def = r.objectClass(tv)
+	case DITContentRule:
+		def = r.dITContentRule(tv)
+	case NameForm:
+		def = r.nameForm(tv)
+	case DITStructureRule:
+		def = r.dITStructureRule(tv)
+	}
+
+	return
+}
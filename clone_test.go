@@ -0,0 +1,126 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the creation of a fully independent copy of
+an existing [Schema] instance.  Changes made to the clone shall not be
+reflected within the original instance, and vice versa.
+*/
+func ExampleSchema_Clone() {
+	orig := NewSchema(AllowOverride)
+	clone := orig.Clone()
+
+	cn := clone.AttributeTypes().Get(`cn`)
+	cn.SetDescription(`Cloned commonName`)
+
+	fmt.Println(orig.AttributeTypes().Get(`cn`).Description())
+	// Output: RFC4519: common name(s) for which the entity is known by
+}
+
+/*
+This example demonstrates the use of a [DataCopier] closure to produce
+independent copies of any user-assigned data values during a clone
+operation.
+*/
+func ExampleSchema_Clone_withDataCopier() {
+	orig := NewSchema()
+	orig.AttributeTypes().Get(`cn`).SetData([]string{`original`})
+
+	clone := orig.Clone(func(x any) any {
+		if slice, ok := x.([]string); ok {
+			return append([]string{}, slice...)
+		}
+		return x
+	})
+
+	data := clone.AttributeTypes().Get(`cn`).Data().([]string)
+	data[0] = `modified`
+
+	fmt.Println(orig.AttributeTypes().Get(`cn`).Data())
+	// Output: [original]
+}
+
+func TestSchema_Clone(t *testing.T) {
+	clone := mySchema.Clone()
+
+	if ocnt, ccnt := mySchema.Counters(), clone.Counters(); ocnt != ccnt {
+		t.Errorf("%s failed: counters mismatch; want %v, got %v",
+			t.Name(), ocnt, ccnt)
+		return
+	}
+
+	if clone.DN() != mySchema.DN() {
+		t.Errorf("%s failed: DN mismatch", t.Name())
+		return
+	}
+
+	for _, opt := range []Option{AllowOverride, SortExtensions, SortLists, HangingIndents} {
+		if !clone.Options().Positive(opt) {
+			t.Errorf("%s failed: option %d not carried over", t.Name(), opt)
+			return
+		}
+	}
+
+	if _, found := clone.Macros().Resolve(`nisSchema`); !found {
+		t.Errorf("%s failed: macros not carried over", t.Name())
+		return
+	}
+
+	// verify references are rewired to the clone
+	cper := clone.ObjectClasses().Get(`person`)
+	oper := mySchema.ObjectClasses().Get(`person`)
+	if cper.objectClass == oper.objectClass {
+		t.Errorf("%s failed: objectClass pointer was not reallocated", t.Name())
+		return
+	} else if cper.String() != oper.String() {
+		t.Errorf("%s failed: string mismatch:\nwant: %s\ngot:  %s",
+			t.Name(), oper, cper)
+		return
+	}
+
+	must := cper.Must().Index(0)
+	if must.attributeType != clone.AttributeTypes().Get(must.NumericOID()).attributeType {
+		t.Errorf("%s failed: MUST clause not rewired", t.Name())
+		return
+	} else if must.Schema().cast().ID() != clone.DN() {
+		t.Errorf("%s failed: schema reference not rewired", t.Name())
+		return
+	}
+
+	for i := 0; i < clone.DITStructureRules().Len(); i++ {
+		ds := clone.DITStructureRules().Index(i)
+		form := ds.Form()
+		if form.nameForm != clone.NameForms().Get(form.NumericOID()).nameForm {
+			t.Errorf("%s failed: FORM clause not rewired for rule %s", t.Name(), ds.ID())
+			return
+		}
+	}
+
+	// modifications to the clone must not affect the original
+	clone.Macros().Set(`cloneOnly`, `1.3.6.1.4.1.56521.999`)
+	if _, found := mySchema.Macros().Resolve(`cloneOnly`); found {
+		t.Errorf("%s failed: macros are shared", t.Name())
+		return
+	}
+
+	clone.Options().Unshift(AllowOverride)
+	if !mySchema.Options().Positive(AllowOverride) {
+		t.Errorf("%s failed: options are shared", t.Name())
+		return
+	}
+
+	cper.Extensions().Set(`X-CLONE`, `TRUE`)
+	if oper.Extensions().Exists(`X-CLONE`) {
+		t.Errorf("%s failed: extensions are shared", t.Name())
+		return
+	}
+
+	var zero Schema
+	if !zero.Clone().IsZero() {
+		t.Errorf("%s failed: expected zero clone", t.Name())
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Command schemax-gen generates Go source code from LDAP schema files using
+the [schemax.Schema.GoCode] method.  It is intended for use with "go
+generate", e.g.:
+
+	//go:generate go run github.com/JesseCoretta/go-schemax/cmd/schemax-gen -o ldap_gen.go -classes inetOrgPerson,groupOfNames ./schema
+
+Each argument is a ".schema" file or a directory thereof, which are parsed
+in the order given atop the package built-in definitions.
+
+Usage:
+
+	schemax-gen [flags] [file or directory ...]
+
+Flags:
+
+	-builtin
+	      load all built-in definitions prior to parsing (default true);
+	      if false, only the built-in syntaxes and matching rules are loaded
+	-classes string
+	      comma-separated names or OIDs of the object classes to generate;
+	      all classes are generated if unset
+	-o string
+	      output file (default standard output)
+	-pkg string
+	      package name (default $GOPACKAGE, else "schema")
+	-tag string
+	      struct tag key (default "ldap")
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JesseCoretta/go-schemax"
+)
+
+func main() {
+	builtin := flag.Bool(`builtin`, true, `load all built-in definitions prior to parsing`)
+	classes := flag.String(`classes`, ``, `comma-separated names or OIDs of the object classes to generate`)
+	out := flag.String(`o`, ``, `output file (default standard output)`)
+	pkg := flag.String(`pkg`, os.Getenv(`GOPACKAGE`), `package name`)
+	tag := flag.String(`tag`, `ldap`, `struct tag key`)
+	flag.Parse()
+
+	if err := run(*builtin, *classes, *out, *pkg, *tag, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, `schemax-gen: `+err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(builtin bool, classes, out, pkg, tag string, paths []string) (err error) {
+	var s schemax.Schema
+	if builtin {
+		s = schemax.NewSchema()
+	} else {
+		s = schemax.NewBasicSchema()
+	}
+
+	for _, path := range paths {
+		var fi os.FileInfo
+		if fi, err = os.Stat(path); err != nil {
+			return
+		} else if fi.IsDir() {
+			err = s.ParseDirectory(path)
+		} else {
+			err = s.ParseFile(path)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	cfg := schemax.GoCodeConfig{Package: pkg, Tag: tag}
+	if len(classes) > 0 {
+		cfg.ObjectClasses = strings.Split(classes, `,`)
+	}
+
+	var src []byte
+	if src, err = s.GoCode(cfg); err != nil {
+		return
+	}
+
+	if len(out) == 0 {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(out, src, 0644)
+	}
+
+	return
+}
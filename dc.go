@@ -106,6 +106,10 @@ func (r DITContentRule) SetData(x any) DITContentRule {
 }
 
 func (r *dITContentRule) setData(x any) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -141,6 +145,10 @@ func (r DITContentRule) SetSchema(schema Schema) DITContentRule {
 }
 
 func (r *dITContentRule) setSchema(schema Schema) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -409,6 +417,10 @@ func (r DITContentRule) SetAux(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setAux(m ...any) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var oc ObjectClass
@@ -651,7 +663,7 @@ func (r DITContentRule) Parse(raw string) (err error) {
 
 func (r *dITContentRule) parse(raw string) error {
 	// parseLS wraps the antlr4512 DITContentRule parser/lexer
-	mp, err := parseDC(raw)
+	mp, err := parseDC(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -683,6 +695,11 @@ func (r DITContentRule) SetName(x ...string) DITContentRule {
 }
 
 func (r *dITContentRule) setName(x ...string) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, DITContentRule{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -702,6 +719,10 @@ func (r DITContentRule) SetObsolete() DITContentRule {
 }
 
 func (r *dITContentRule) setObsolete() {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -723,6 +744,10 @@ func (r DITContentRule) SetDescription(desc string) DITContentRule {
 }
 
 func (r *dITContentRule) setDescription(desc string) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -755,6 +780,10 @@ func (r DITContentRule) SetExtension(x string, xstrs ...string) DITContentRule {
 }
 
 func (r *dITContentRule) setExtension(x string, xstrs ...string) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -776,6 +805,10 @@ func (r DITContentRule) SetNumericOID(id string) DITContentRule {
 }
 
 func (r *dITContentRule) setNumericOID(id string) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -817,6 +850,10 @@ func (r DITContentRule) SetMust(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setMust(m ...any) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -862,6 +899,10 @@ func (r DITContentRule) SetMay(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setMay(m ...any) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -907,6 +948,10 @@ func (r DITContentRule) SetNot(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setNot(m ...any) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -971,22 +1016,21 @@ func (r DITContentRule) SetStringer(function ...Stringer) DITContentRule {
 }
 
 func (r *dITContentRule) setStringer(function ...Stringer) {
+	if !modify(DITContentRule{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
 	}
 
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// text/template op is deferred until first use
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 	} else {
 		r.stringer = stringer
+		r.rendering = nil
 	}
 }
 
@@ -1009,35 +1053,12 @@ func (r *dITContentRule) prepareString() (str string, err error) {
 	// Create a new template instance bearing the "Type" value
 	// string literal as its name.  Declare custom templating
 	// functions enveloped within a template.FuncMap instance.
-	t := newTemplate(r.Type()).
-		Funcs(funcMap(map[string]any{
-			// ExtensionSet used by all definitions for
-			// "X-" extensions (e.g.: X-ORIGIN, X-SUBSTR)
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			// StructuralOID refers to the OID shared with the
-			// structural OC upon which this rule is based
-			`StructuralOID`: r.OID.NumericOID,
-			// AuxLen refers to the number of AUXILIARY ObjectClasses
-			// present within the rule's AUX clause.
-			`AuxLen`: r.Aux.len,
-			// MayLen refers to the number of AttributeTypes
-			// present within the rule's MAY clause.
-			`MayLen`: r.May.len,
-			// MustLen refers to the number of AttributeTypes
-			// present within the rule's MUST clause.
-			`MustLen`: r.Must.len,
-			// NotLen refers to the number of AttributeTypes
-			// present within the rule's NOT clause.
-			`NotLen`: r.Not.len,
-			// Obsolete indicates definition obsolescence
-			// in the form of a Boolean value.
-			`Obsolete`: func() bool { return r.Obsolete },
-		}))
+	t := newTemplate(r.Type()).Funcs(funcMap(r.tmplFuncs()))
 
 	// Parse raw template into *template.Template instance
 	// and ensure the raw directives represent legal, well
 	// formed and error-free templating instructions.
-	if t, err = t.Parse(dITContentRuleTmpl); err == nil {
+	if t, err = t.Parse(r.schema.template(`dITContentRule`, dITContentRuleTmpl)); err == nil {
 		// Execute the now-verified template, funnel our
 		// needed objects and settings values into an
 		// anonymous struct instance.
@@ -1046,7 +1067,7 @@ func (r *dITContentRule) prepareString() (str string, err error) {
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			// Dump our templated output from
 			// the *bytes.Buffer instance into
@@ -1058,6 +1079,37 @@ func (r *dITContentRule) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *dITContentRule) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		// ExtensionSet used by all definitions for
+		// "X-" extensions (e.g.: X-ORIGIN, X-SUBSTR)
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		// StructuralOID refers to the OID shared with the
+		// structural OC upon which this rule is based
+		`StructuralOID`: func() string { return r.schema.macroOID(f, r.OID.NumericOID()) },
+		// AuxLen refers to the number of AUXILIARY ObjectClasses
+		// present within the rule's AUX clause.
+		`AuxLen`: r.Aux.len,
+		// MayLen refers to the number of AttributeTypes
+		// present within the rule's MAY clause.
+		`MayLen`: r.May.len,
+		// MustLen refers to the number of AttributeTypes
+		// present within the rule's MUST clause.
+		`MustLen`: r.Must.len,
+		// NotLen refers to the number of AttributeTypes
+		// present within the rule's NOT clause.
+		`NotLen`: r.Not.len,
+		// Obsolete indicates definition obsolescence
+		// in the form of a Boolean value.
+		`Obsolete`: func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `OBSOLETE`, `AUX`, `MUST`, `MAY`, `NOT`)
+}
+
 /*
 Name returns the string form of the principal name of the receiver instance, if set.
 */
@@ -1108,6 +1160,12 @@ func (r DITContentRule) NumericOID() (noid string) {
 
 func (r DITContentRule) setOID(_ string) {}
 
+func (r DITContentRule) resetRendering() {
+	if !r.IsZero() {
+		r.dITContentRule.rendering.reset()
+	}
+}
+
 /*
 Push returns an error following an attempt to push a [DITContentRule]
 into the receiver stack instance.
@@ -1123,7 +1181,7 @@ func (r DITContentRules) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1161,12 +1219,21 @@ the principal identifier of an [DITContentRule] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r DITContentRules) Get(id string) DITContentRule {
 	return r.get(id)
 }
 
 func (r DITContentRules) get(id string) (dc DITContentRule) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		dc, _ = def.(DITContentRule)
+		return
+	}
+
 	for i := 0; i < r.len() && dc.IsZero(); i++ {
 		if _dc := r.index(i); !_dc.IsZero() {
 			if _dc.NumericOID() == id {
@@ -1230,6 +1297,9 @@ func (r *dITContentRule) replace(x DITContentRule) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, DITContentRule{r})()
+
 	r.OID = x.dITContentRule.OID
 	r.Name = x.dITContentRule.Name
 	r.Desc = x.dITContentRule.Desc
@@ -1241,6 +1311,14 @@ func (r *dITContentRule) replace(x DITContentRule) {
 	r.Extensions = x.dITContentRule.Extensions
 	r.data = x.dITContentRule.data
 	r.schema = x.dITContentRule.schema
-	r.stringer = x.dITContentRule.stringer
+	r.stringer, r.rendering = copyStringer(x.dITContentRule.stringer, x.dITContentRule.rendering, r.prepareString)
 	r.data = x.dITContentRule.data
+
+	if member(old.schema, DITContentRule{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  DITContentRule{&old},
+			New:  DITContentRule{r},
+		})
+	}
 }
@@ -5,6 +5,10 @@ defmap.go defines map related methods to allow simple interaction
 with instances of types that have been marshaled into map form.
 */
 
+import (
+	"github.com/JesseCoretta/go-antlr4512"
+)
+
 func (r DefinitionMaps) Len() int {
 	return len(r)
 }
@@ -25,9 +29,9 @@ func (r DefinitionMaps) Index(idx int) DefinitionMap {
 }
 
 func (r DefinitionMap) Get(id string) (val []string) {
-	var key string
-	for key, val = range r {
+	for key, v := range r {
 		if eq(key, id) {
+			val = v
 			break
 		}
 	}
@@ -90,3 +94,237 @@ func (r DefinitionMap) Type() (t string) {
 
 	return
 }
+
+/*
+FromMaps returns an error following an attempt to construct and push a
+[Definition] into the receiver instance for each [DefinitionMap] within
+defs, in the order given.  Processing stops at the first error.
+
+See [Schema.FromMap] for details.
+*/
+func (r Schema) FromMaps(defs DefinitionMaps) (err error) {
+	for i := 0; i < defs.Len() && err == nil; i++ {
+		err = r.FromMap(defs[i])
+	}
+
+	return
+}
+
+/*
+FromMap returns an error following an attempt to construct a [Definition]
+using def, and to push it into the appropriate collection of the receiver
+instance.  This is the inverse of the various Map methods, such as
+[AttributeType.Map].
+
+The type of [Definition] constructed is determined by the value of the
+TYPE key (see [DefinitionMap.Type]), e.g.: "attributeType".  The remaining
+keys are interpreted just as the clauses of the same names are parsed;
+references to other definitions (e.g.: SUP, MUST) may be expressed using
+either numeric OIDs or names, and are resolved through the receiver.  Keys
+bearing the "X-" prefix are interpreted as extensions.  The RAW key, if
+present, is ignored.
+
+A SYNTAX value may optionally bear a minimum upper bound, e.g.:
+
+	1.3.6.1.4.1.1466.115.121.1.15{64}
+
+An error is returned if the type is unknown, if a reference cannot be
+resolved, if the [Definition] is already present within the receiver or
+if it fails compliancy checks.
+*/
+func (r Schema) FromMap(def DefinitionMap) (err error) {
+	if r.IsZero() {
+		return ErrNilReceiver
+	}
+
+	var x Definition
+	switch typ := def.Type(); typ {
+	case `ldapSyntax`:
+		x, err = r.marshalLS(def.lDAPSyntax())
+	case `matchingRule`:
+		x, err = r.marshalMR(def.matchingRule())
+	case `attributeType`:
+		x, err = r.marshalAT(def.attributeType())
+	case `matchingRuleUse`:
+		var mu MatchingRuleUse
+		if mu, err = r.marshalMU(def.matchingRuleUse()); err == nil && mu.IsZero() {
+			err = mkerr(ErrMatchingRuleNotFound.Error() + `: ` + def.value(`NUMERICOID`))
+		}
+		x = mu
+	case `objectClass`:
+		x, err = r.marshalOC(def.objectClass())
+	case `dITContentRule`:
+		x, err = r.marshalDC(def.dITContentRule())
+	case `nameForm`:
+		x, err = r.marshalNF(def.nameForm())
+	case `dITStructureRule`:
+		x, err = r.marshalDS(def.dITStructureRule())
+	default:
+		err = mkerr(ErrInvalidType.Error() + `: ` + typ)
+	}
+
+	if err == nil {
+		if x.IsZero() {
+			err = mkerr(ErrNotUnique.Error() + `: ` + def.Type())
+		} else {
+			err = r.collection(x.Type()).Push(x)
+		}
+	}
+
+	return
+}
+
+/*
+value returns the first value assigned to key within the receiver
+instance, or a zero string if not found.
+*/
+func (r DefinitionMap) value(key string) (val string) {
+	if v := r.Get(key); len(v) > 0 {
+		val = v[0]
+	}
+
+	return
+}
+
+/*
+bool returns a Boolean value indicative of the first value assigned to
+key within the receiver instance being "TRUE" (case is not significant).
+*/
+func (r DefinitionMap) bool(key string) bool {
+	return eq(r.value(key), `true`)
+}
+
+/*
+extensions returns the extension key/value pairs (those bearing the "X-"
+prefix) present within the receiver instance.
+*/
+func (r DefinitionMap) extensions() (ext map[string][]string) {
+	ext = make(map[string][]string, 0)
+	for k, v := range r {
+		if hasPfx(uc(k), `X-`) {
+			ext[uc(k)] = v
+		}
+	}
+
+	return
+}
+
+/*
+syntax returns the numeric OID and minimum upper bound, if any, of the
+SYNTAX value within the receiver instance.
+*/
+func (r DefinitionMap) syntax() (oid string, mub uint) {
+	oid = r.value(`SYNTAX`)
+	if idx := stridx(oid, `{`); idx != -1 && hasSfx(oid, `}`) {
+		if n, err := atoi(oid[idx+1 : len(oid)-1]); err == nil && n >= 0 {
+			mub = uint(n)
+		}
+		oid = oid[:idx]
+	}
+
+	return
+}
+
+func (r DefinitionMap) lDAPSyntax() antlr4512.LDAPSyntax {
+	return antlr4512.LDAPSyntax{
+		OID:        r.value(`NUMERICOID`),
+		Desc:       r.value(`DESC`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) matchingRule() antlr4512.MatchingRule {
+	return antlr4512.MatchingRule{
+		OID:        r.value(`NUMERICOID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		Syntax:     r.value(`SYNTAX`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) attributeType() antlr4512.AttributeType {
+	syn, mub := r.syntax()
+	return antlr4512.AttributeType{
+		OID:        r.value(`NUMERICOID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		SuperType:  r.value(`SUP`),
+		Equality:   r.value(`EQUALITY`),
+		Ordering:   r.value(`ORDERING`),
+		Substring:  r.value(`SUBSTR`),
+		Syntax:     syn,
+		MUB:        mub,
+		Single:     r.bool(`SINGLE-VALUE`),
+		Collective: r.bool(`COLLECTIVE`),
+		Immutable:  r.bool(`NO-USER-MODIFICATION`),
+		Usage:      r.value(`USAGE`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) matchingRuleUse() antlr4512.MatchingRuleUse {
+	return antlr4512.MatchingRuleUse{
+		OID:        r.value(`NUMERICOID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		Applies:    r.Get(`APPLIES`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) objectClass() antlr4512.ObjectClass {
+	return antlr4512.ObjectClass{
+		OID:          r.value(`NUMERICOID`),
+		Name:         r.Get(`NAME`),
+		Desc:         r.value(`DESC`),
+		Obsolete:     r.bool(`OBSOLETE`),
+		SuperClasses: r.Get(`SUP`),
+		Kind:         uc(r.value(`KIND`)),
+		Must:         r.Get(`MUST`),
+		May:          r.Get(`MAY`),
+		Extensions:   r.extensions(),
+	}
+}
+
+func (r DefinitionMap) dITContentRule() antlr4512.DITContentRule {
+	return antlr4512.DITContentRule{
+		OID:        r.value(`NUMERICOID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		Aux:        r.Get(`AUX`),
+		Must:       r.Get(`MUST`),
+		May:        r.Get(`MAY`),
+		Not:        r.Get(`NOT`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) nameForm() antlr4512.NameForm {
+	return antlr4512.NameForm{
+		OID:        r.value(`NUMERICOID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		OC:         r.value(`OC`),
+		Must:       r.Get(`MUST`),
+		May:        r.Get(`MAY`),
+		Extensions: r.extensions(),
+	}
+}
+
+func (r DefinitionMap) dITStructureRule() antlr4512.DITStructureRule {
+	return antlr4512.DITStructureRule{
+		ID:         r.value(`RULEID`),
+		Name:       r.Get(`NAME`),
+		Desc:       r.value(`DESC`),
+		Obsolete:   r.bool(`OBSOLETE`),
+		Form:       r.value(`FORM`),
+		SuperRules: r.Get(`SUP`),
+		Extensions: r.extensions(),
+	}
+}
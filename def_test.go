@@ -63,3 +63,137 @@ func TestDefinitionMaps_codecov(t *testing.T) {
 	classes := mySchema.ObjectClasses()
 	_ = classes.Maps().Index(222)
 }
+
+/*
+This example demonstrates the construction of an [AttributeType] using
+an instance of [DefinitionMap], such as one produced by the Map method
+of another [AttributeType] and thereafter modified.
+*/
+func ExampleSchema_FromMap() {
+	sch := NewSchema()
+
+	def := sch.AttributeTypes().Get(`cn`).Map()
+	def[`NUMERICOID`] = []string{`1.3.6.1.4.1.56521.999.97.1`}
+	def[`NAME`] = []string{`mapName`}
+	def[`X-ORIGIN`] = []string{`Example`}
+
+	if err := sch.FromMap(def); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.AttributeTypes().Get(`mapName`))
+	// Output: ( 1.3.6.1.4.1.56521.999.97.1 NAME 'mapName' DESC 'RFC4519: common name(s) for which the entity is known by' SUP name X-ORIGIN 'Example' )
+}
+
+func TestSchema_FromMaps(t *testing.T) {
+	sch := NewEmptySchema(SortExtensions, SortLists, HangingIndents)
+	for _, defs := range mySchema.collections() {
+		var maps DefinitionMaps
+		switch tv := defs.(type) {
+		case LDAPSyntaxes:
+			maps = tv.Maps()
+		case MatchingRules:
+			maps = tv.Maps()
+		case AttributeTypes:
+			maps = tv.Maps()
+		case MatchingRuleUses:
+			maps = tv.Maps()
+		case ObjectClasses:
+			maps = tv.Maps()
+		case DITContentRules:
+			maps = tv.Maps()
+		case NameForms:
+			maps = tv.Maps()
+		case DITStructureRules:
+			maps = tv.Maps()
+		}
+
+		if err := sch.FromMaps(maps); err != nil {
+			t.Errorf("%s failed [%s]: %v", t.Name(), defs.Type(), err)
+			return
+		}
+	}
+
+	if sch.Counters() != mySchema.Counters() {
+		t.Errorf("%s failed: counters mismatch:\nwant: %#v\ngot:  %#v",
+			t.Name(), mySchema.Counters(), sch.Counters())
+		return
+	}
+
+	for _, id := range []string{`groupOfNames`, `2.5.6.6`, `posixAccount`} {
+		if want, got := mySchema.ObjectClasses().Get(id).String(),
+			sch.ObjectClasses().Get(id).String(); want != got {
+			t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+			return
+		}
+	}
+
+	// syntax length is honored
+	def := sch.AttributeTypes().Get(`cn`).Map()
+	def[`NUMERICOID`] = []string{`1.3.6.1.4.1.56521.999.97.2`}
+	def[`NAME`] = []string{`mapLength`}
+	def[`SYNTAX`] = []string{`1.3.6.1.4.1.1466.115.121.1.15{64}`}
+	if err := sch.FromMap(def); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if at := sch.AttributeTypes().Get(`mapLength`); at.MinimumUpperBounds() != 64 {
+		t.Errorf("%s failed: want 64, got %d", t.Name(), at.MinimumUpperBounds())
+		return
+	}
+
+	// syntax length survives a round trip
+	def = sch.AttributeTypes().Get(`mapLength`).Map()
+	if got := def[`SYNTAX`][0]; got != `1.3.6.1.4.1.1466.115.121.1.15{64}` {
+		t.Errorf("%s failed: unexpected SYNTAX value %s", t.Name(), got)
+		return
+	}
+	def[`NUMERICOID`] = []string{`1.3.6.1.4.1.56521.999.97.5`}
+	def[`NAME`] = []string{`mapLengthAgain`}
+	if err := sch.FromMap(def); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if at := sch.AttributeTypes().Get(`mapLengthAgain`); at.MinimumUpperBounds() != 64 {
+		t.Errorf("%s failed: want 64, got %d", t.Name(), at.MinimumUpperBounds())
+		return
+	}
+
+	// extensions are ordered by name, regardless of SortExtensions
+	unsorted := NewSchema()
+	if err := unsorted.FromMap(DefinitionMap{
+		`TYPE`:       {`attributeType`},
+		`NUMERICOID`: {`1.3.6.1.4.1.56521.999.97.6`},
+		`NAME`:       {`mapExtensions`},
+		`SYNTAX`:     {`1.3.6.1.4.1.1466.115.121.1.15`},
+		`X-ZULU`:     {`z`},
+		`X-ALPHA`:    {`a`},
+		`X-MIKE`:     {`m`},
+	}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	ext := unsorted.AttributeTypes().Get(`mapExtensions`).Extensions()
+	for i, want := range []string{`X-ALPHA`, `X-MIKE`, `X-ZULU`} {
+		if got := ext.Index(i).XString; got != want {
+			t.Errorf("%s failed: want %s at %d, got %s", t.Name(), want, i, got)
+			return
+		}
+	}
+
+	for _, bogus := range []DefinitionMap{
+		def, // duplicate
+		{`TYPE`: {`bogus`}},
+		{`TYPE`: {`objectClass`}, `NUMERICOID`: {`1.3.6.1.4.1.56521.999.97.3`}, `SUP`: {`bogusClass`}},
+		{`TYPE`: {`matchingRuleUse`}, `NUMERICOID`: {`1.3.6.1.4.1.56521.999.97.4`}},
+	} {
+		if err := sch.FromMap(bogus); err == nil {
+			t.Errorf("%s failed: expected error for %v", t.Name(), bogus)
+			return
+		}
+	}
+
+	var zero Schema
+	if err := zero.FromMap(def); err != ErrNilReceiver {
+		t.Errorf("%s failed: want '%v', got '%v'", t.Name(), ErrNilReceiver, err)
+	}
+}
@@ -0,0 +1,352 @@
+package schemax
+
+/*
+diagram.go implements the export of definition hierarchies as Graphviz
+DOT or Mermaid diagram text.
+*/
+
+/*
+DiagramFormat describes the diagram language produced by way of methods
+such as [Schema.ObjectClassDiagram].
+*/
+type DiagramFormat uint8
+
+const (
+	DOTDiagram     DiagramFormat = iota // Graphviz DOT "digraph" text
+	MermaidDiagram                      // Mermaid "flowchart" text
+)
+
+/*
+DiagramFilter limits the definitions drawn by methods such as
+[Schema.ObjectClassDiagram].  A zero instance of this type results in
+the drawing of all definitions of the relevant type.
+*/
+type DiagramFilter struct {
+	// Roots contains the names or numeric OIDs -- or rule IDs in the
+	// case of DITStructureRule instances -- of the definitions whose
+	// trees are to be drawn.  Each such definition is drawn alongside
+	// all of its direct and indirect subordinates.  An error is returned
+	// if any value cannot be resolved.
+	Roots []string
+
+	// Origins contains X-ORIGIN extension values, e.g.: "RFC4519". If
+	// non-empty, only definitions bearing at least one of these values
+	// are drawn.  Case is not significant in the matching process.
+	Origins []string
+}
+
+/*
+ObjectClassDiagram returns the [ObjectClass] hierarchy of the receiver
+instance in format, alongside an error.  Each class is drawn bearing its
+name, numeric OID and kind, with an edge leading from each class to each
+of its super classes (see [ObjectClass.SuperClasses]).
+
+See [DiagramFilter] for the means of limiting the classes drawn.
+*/
+func (r Schema) ObjectClassDiagram(format DiagramFormat, filter DiagramFilter) (string, error) {
+	ocs := r.ObjectClasses()
+	return r.diagram(format, filter, diagramGraph{
+		name: `objectClasses`,
+		defs: func() (defs []Definition) {
+			for i := 0; i < ocs.Len(); i++ {
+				defs = append(defs, ocs.Index(i))
+			}
+			return
+		},
+		lookup: func(id string) (Definition, error) {
+			oc := ocs.Get(id)
+			if oc.IsZero() {
+				return nil, mkerr(ErrObjectClassNotFound.Error() + `: ` + id)
+			}
+			return oc, nil
+		},
+		subs: func(def Definition) []Definition {
+			return diagramClasses(def.(ObjectClass).SubClasses())
+		},
+		sups: func(def Definition) []Definition {
+			return diagramClasses(def.(ObjectClass).SuperClasses())
+		},
+		label: func(def Definition) []string {
+			oc := def.(ObjectClass)
+			kinds := map[uint]string{AbstractKind: `ABSTRACT`, StructuralKind: `STRUCTURAL`, AuxiliaryKind: `AUXILIARY`}
+			return []string{docLabel(oc), oc.NumericOID(), kinds[oc.Kind()]}
+		},
+	})
+}
+
+/*
+AttributeTypeDiagram returns the [AttributeType] super type trees of the
+receiver instance in format, alongside an error.  Each type is drawn
+bearing its name and numeric OID, with an edge leading from each type to
+its super type (see [AttributeType.SuperType]).
+
+See [DiagramFilter] for the means of limiting the types drawn.  Note that
+absent any [DiagramFilter.Roots], types which neither extend nor are
+extended by another type are drawn as lone nodes.
+*/
+func (r Schema) AttributeTypeDiagram(format DiagramFormat, filter DiagramFilter) (string, error) {
+	ats := r.AttributeTypes()
+	return r.diagram(format, filter, diagramGraph{
+		name: `attributeTypes`,
+		defs: func() (defs []Definition) {
+			for i := 0; i < ats.Len(); i++ {
+				defs = append(defs, ats.Index(i))
+			}
+			return
+		},
+		lookup: func(id string) (Definition, error) {
+			at := ats.Get(id)
+			if at.IsZero() {
+				return nil, mkerr(ErrAttributeTypeNotFound.Error() + `: ` + id)
+			}
+			return at, nil
+		},
+		subs: func(def Definition) (defs []Definition) {
+			subs := def.(AttributeType).SubTypes()
+			for i := 0; i < subs.Len(); i++ {
+				defs = append(defs, subs.Index(i))
+			}
+			return
+		},
+		sups: func(def Definition) (defs []Definition) {
+			if sup := def.(AttributeType).SuperType(); !sup.IsZero() {
+				defs = append(defs, sup)
+			}
+			return
+		},
+		label: func(def Definition) []string {
+			return []string{docLabel(def), def.NumericOID()}
+		},
+	})
+}
+
+/*
+DITStructureRuleDiagram returns the [DITStructureRule] trees of the
+receiver instance in format, alongside an error.  Each rule is drawn
+bearing its rule ID and name, as well as its [NameForm] and the structural
+[ObjectClass] thereof, with an edge leading from each rule to each of its
+superior rules (see [DITStructureRule.SuperRules]).
+
+See [DiagramFilter] for the means of limiting the rules drawn.
+*/
+func (r Schema) DITStructureRuleDiagram(format DiagramFormat, filter DiagramFilter) (string, error) {
+	dss := r.DITStructureRules()
+	return r.diagram(format, filter, diagramGraph{
+		name: `dITStructureRules`,
+		defs: func() (defs []Definition) {
+			for i := 0; i < dss.Len(); i++ {
+				defs = append(defs, dss.Index(i))
+			}
+			return
+		},
+		lookup: func(id string) (Definition, error) {
+			ds := dss.Get(id)
+			if ds.IsZero() {
+				return nil, mkerr(ErrDITStructureRuleNotFound.Error() + `: ` + id)
+			}
+			return ds, nil
+		},
+		subs: func(def Definition) []Definition {
+			return diagramRules(def.(DITStructureRule).SubRules())
+		},
+		sups: func(def Definition) []Definition {
+			return diagramRules(def.(DITStructureRule).SuperRules())
+		},
+		label: func(def Definition) (l []string) {
+			ds := def.(DITStructureRule)
+			l = []string{uitoa(ds.RuleID())}
+			if name := ds.Name(); len(name) > 0 {
+				l[0] += `: ` + name
+			}
+
+			if nf := ds.Form(); !nf.IsZero() {
+				form := `FORM ` + docLabel(nf)
+				if oc := nf.OC(); !oc.IsZero() {
+					form += ` (` + docLabel(oc) + `)`
+				}
+				l = append(l, form)
+			}
+			return
+		},
+	})
+}
+
+/*
+diagramGraph describes the definitions of a single type for the purpose
+of drawing their hierarchy.
+*/
+type diagramGraph struct {
+	name   string
+	defs   func() []Definition
+	lookup func(string) (Definition, error)
+	subs   func(Definition) []Definition
+	sups   func(Definition) []Definition
+	label  func(Definition) []string
+}
+
+/*
+diagramClasses returns the members of ocs as a slice of [Definition].
+*/
+func diagramClasses(ocs ObjectClasses) (defs []Definition) {
+	for i := 0; i < ocs.Len(); i++ {
+		defs = append(defs, ocs.Index(i))
+	}
+
+	return
+}
+
+/*
+diagramRules returns the members of dss as a slice of [Definition].
+*/
+func diagramRules(dss DITStructureRules) (defs []Definition) {
+	for i := 0; i < dss.Len(); i++ {
+		defs = append(defs, dss.Index(i))
+	}
+
+	return
+}
+
+/*
+diagram returns the text of the diagram of g in format, limited per
+filter, alongside an error.
+*/
+func (r Schema) diagram(format DiagramFormat, filter DiagramFilter, g diagramGraph) (d string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if format != DOTDiagram && format != MermaidDiagram {
+		err = mkerr(ErrInvalidInput.Error() + `: unknown DiagramFormat`)
+		return
+	}
+
+	// Gather the definitions to be drawn, if limited
+	// to the trees of one or more roots.
+	var wanted map[string]bool
+	if len(filter.Roots) > 0 {
+		wanted = make(map[string]bool)
+		var queue []Definition
+		for _, id := range filter.Roots {
+			var root Definition
+			if root, err = g.lookup(id); err != nil {
+				return
+			}
+			queue = append(queue, root)
+		}
+
+		for len(queue) > 0 {
+			def := queue[0]
+			queue = queue[1:]
+			if key := diagramKey(def); !wanted[key] {
+				wanted[key] = true
+				queue = append(queue, g.subs(def)...)
+			}
+		}
+	}
+
+	// Assign node IDs in collection order, such that
+	// the output is stable.
+	var nodes []Definition
+	ids := make(map[string]string)
+	for _, def := range g.defs() {
+		key := diagramKey(def)
+		if (wanted == nil || wanted[key]) && diagramOrigin(def, filter.Origins) {
+			ids[key] = `n` + itoa(len(nodes))
+			nodes = append(nodes, def)
+		}
+	}
+
+	d = r.drawDiagram(format, g, nodes, ids)
+
+	return
+}
+
+/*
+diagramKey returns the numeric OID of def, or its rule ID in the case of
+a [DITStructureRule].
+*/
+func diagramKey(def Definition) (key string) {
+	if ds, ok := def.(DITStructureRule); ok {
+		key = uitoa(ds.RuleID())
+	} else {
+		key = def.NumericOID()
+	}
+
+	return
+}
+
+/*
+diagramOrigin returns a Boolean value indicative of whether def bears an
+X-ORIGIN value present within origins, or whether origins is empty.
+*/
+func diagramOrigin(def Definition, origins []string) bool {
+	if len(origins) == 0 {
+		return true
+	}
+
+	if ext, found := def.Extensions().get(`X-ORIGIN`); found {
+		for _, origin := range origins {
+			if ext.contains(origin) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/*
+drawDiagram returns the text of the diagram comprised of nodes in format.
+*/
+func (r Schema) drawDiagram(format DiagramFormat, g diagramGraph, nodes []Definition, ids map[string]string) string {
+	lf := string(rune(10))
+	ind := pad(4)
+
+	var b []string
+	switch format {
+	case DOTDiagram:
+		b = append(b, `digraph `+g.name+` {`,
+			ind+`rankdir=BT;`,
+			ind+`node [shape=box];`)
+	case MermaidDiagram:
+		b = append(b, `flowchart BT`)
+	}
+
+	for _, def := range nodes {
+		label := g.label(def)
+		switch format {
+		case DOTDiagram:
+			for i := range label {
+				label[i] = repAll(repAll(label[i], `\`, `\\`), `"`, `\"`)
+			}
+			b = append(b, ind+ids[diagramKey(def)]+` [label="`+join(label, `\n`)+`"];`)
+		case MermaidDiagram:
+			for i := range label {
+				label[i] = repAll(label[i], `"`, `#quot;`)
+			}
+			b = append(b, ind+ids[diagramKey(def)]+`["`+join(label, `<br/>`)+`"]`)
+		}
+	}
+
+	arrow := ` -> `
+	if format == MermaidDiagram {
+		arrow = ` --> `
+	}
+
+	for _, def := range nodes {
+		for _, sup := range g.sups(def) {
+			if id, drawn := ids[diagramKey(sup)]; drawn {
+				edge := ind + ids[diagramKey(def)] + arrow + id
+				if format == DOTDiagram {
+					edge += `;`
+				}
+				b = append(b, edge)
+			}
+		}
+	}
+
+	if format == DOTDiagram {
+		b = append(b, `}`)
+	}
+
+	return join(b, lf) + lf
+}
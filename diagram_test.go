@@ -0,0 +1,104 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the export of the "person" [ObjectClass] tree
+as Graphviz DOT text.
+*/
+func ExampleSchema_ObjectClassDiagram() {
+	dot, err := mySchema.ObjectClassDiagram(DOTDiagram, DiagramFilter{
+		Roots: []string{`person`},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(dot)
+	// Output: digraph objectClasses {
+	//     rankdir=BT;
+	//     node [shape=box];
+	//     n0 [label="person\n2.5.6.6\nSTRUCTURAL"];
+	//     n1 [label="organizationalPerson\n2.5.6.7\nSTRUCTURAL"];
+	//     n2 [label="residentialPerson\n2.5.6.10\nSTRUCTURAL"];
+	//     n3 [label="inetOrgPerson\n2.16.840.1.113730.3.2.2\nSTRUCTURAL"];
+	//     n1 -> n0;
+	//     n2 -> n0;
+	//     n3 -> n1;
+	// }
+}
+
+/*
+This example demonstrates the export of all [DITStructureRule] trees as
+Mermaid flowchart text.
+*/
+func ExampleSchema_DITStructureRuleDiagram() {
+	mmd, err := mySchema.DITStructureRuleDiagram(MermaidDiagram, DiagramFilter{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(mmd)
+	// Output: flowchart BT
+	//     n0["0: rootArcStructure<br/>FORM nRootArcForm (rootArc)"]
+	//     n1["1: arcStructure<br/>FORM nArcForm (arc)"]
+	//     n2["2: dotNotArcStructure<br/>FORM dotNotationArcForm (arc)"]
+	//     n1 --> n0
+	//     n2 --> n0
+}
+
+func TestSchema_AttributeTypeDiagram(t *testing.T) {
+	// The RFC4519 subtypes of name, excluding any
+	// subtypes originating elsewhere.
+	mmd, err := mySchema.AttributeTypeDiagram(MermaidDiagram, DiagramFilter{
+		Roots:   []string{`name`},
+		Origins: []string{`rfc4519`},
+	})
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	for _, frag := range []string{
+		"flowchart BT\n    n0[\"name<br/>2.5.4.41\"]\n",
+		`n2["cn<br/>2.5.4.3"]`,
+		"    n2 --> n0\n",
+	} {
+		if stridx(mmd, frag) == -1 {
+			t.Errorf("%s failed: %q not found in:\n%s", t.Name(), frag, mmd)
+			return
+		}
+	}
+
+	if stridx(mmd, `commonName`) != -1 || stridx(mmd, `1.3.6.1.4.1.56521`) != -1 {
+		t.Errorf("%s failed: X-ORIGIN filter not honored:\n%s", t.Name(), mmd)
+		return
+	}
+
+	// An origin-only filter severs edges to undrawn supertypes.
+	var dot string
+	if dot, err = mySchema.AttributeTypeDiagram(DOTDiagram, DiagramFilter{
+		Origins: []string{`draft-coretta-oiddir-schema`},
+	}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if stridx(dot, `"name\n`) != -1 {
+		t.Errorf("%s failed: unexpected node for name:\n%s", t.Name(), dot)
+		return
+	}
+
+	if _, err = mySchema.AttributeTypeDiagram(DOTDiagram, DiagramFilter{
+		Roots: []string{`bogusType`},
+	}); err == nil {
+		t.Errorf("%s failed: expected error for unknown root", t.Name())
+	} else if _, err = mySchema.ObjectClassDiagram(DiagramFormat(7), DiagramFilter{}); err == nil {
+		t.Errorf("%s failed: expected error for unknown format", t.Name())
+	} else if _, err = (Schema{}).DITStructureRuleDiagram(DOTDiagram, DiagramFilter{}); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+	}
+}
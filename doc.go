@@ -0,0 +1,709 @@
+package schemax
+
+/*
+doc.go implements the generation of human-readable reference material for
+Schema instances in the form of linked Markdown or static HTML pages.
+*/
+
+import (
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+/*
+DocFormat describes the format of the pages produced by way of the
+[Schema.Documentation] and [Schema.WriteDocumentation] methods.
+*/
+type DocFormat uint8
+
+const (
+	MarkdownDocs DocFormat = iota // Markdown pages ending in ".md"
+	HTMLDocs                      // static HTML pages ending in ".html"
+)
+
+/*
+ext returns the file name extension of pages of the receiver format.
+*/
+func (r DocFormat) ext() (x string) {
+	switch r {
+	case MarkdownDocs:
+		x = `.md`
+	case HTMLDocs:
+		x = `.html`
+	}
+
+	return
+}
+
+/*
+newPage returns a new [docPage] of the receiver format bearing title.
+*/
+func (r DocFormat) newPage(title string) (p docPage) {
+	switch r {
+	case HTMLDocs:
+		p = newHTMLPage(title)
+	default:
+		p = newMarkdownPage(title)
+	}
+
+	return
+}
+
+/*
+Documentation returns a linked set of pages describing the definitions
+within the receiver instance, keyed by slash-delimited path relative to
+the root of the set, alongside an error.  The pages are as follows:
+
+  - "index", which lists all [ObjectClass] and [AttributeType] instances
+    by name and by numeric OID, as well as all [NameForm], [DITContentRule]
+    and [DITStructureRule] instances
+  - "objectClasses/<name>", one per [ObjectClass], bearing its inherited
+    MUST and MAY attributes (see [ObjectClass.AllMust] and [ObjectClass.AllMay])
+    alongside the class which declares each, its super and sub classes,
+    as well as any [DITContentRule], [NameForm] and [DITStructureRule]
+    which concern it
+  - "attributeTypes/<name>", one per [AttributeType], bearing its effective
+    syntax and matching rules (see [AttributeType.EffectiveSyntax] et al.),
+    its super and sub types, and the classes which make use of it
+
+Each path bears the extension of format, e.g.: "index.md".  Definitions
+which lack a name are identified by numeric OID.  Should the receiver be
+an overlay (see [Schema.Overlay]), the definitions of its base are not
+documented.
+
+An error is returned if the receiver is zero, or if format is unknown.
+See [Schema.WriteDocumentation] to write the pages to the filesystem.
+*/
+func (r Schema) Documentation(format DocFormat) (pages map[string][]byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if len(format.ext()) == 0 {
+		err = mkerr(ErrInvalidInput.Error() + `: unknown DocFormat`)
+		return
+	}
+
+	g := docGen{schema: r, format: format, pages: make(map[string][]byte, 0)}
+	g.index()
+
+	ocs := r.ObjectClasses()
+	for i := 0; i < ocs.Len(); i++ {
+		g.objectClass(ocs.Index(i))
+	}
+
+	ats := r.AttributeTypes()
+	for i := 0; i < ats.Len(); i++ {
+		g.attributeType(ats.Index(i))
+	}
+
+	pages = g.pages
+
+	return
+}
+
+/*
+WriteDocumentation returns an error following an attempt to write the
+pages produced by [Schema.Documentation] beneath dir, which is created
+if it does not exist.  Existing pages of the same names are overwritten.
+*/
+func (r Schema) WriteDocumentation(dir string, format DocFormat) (err error) {
+	var pages map[string][]byte
+	if pages, err = r.Documentation(format); err != nil {
+		return
+	}
+
+	for path, content := range pages {
+		name := filepath.Join(dir, filepath.FromSlash(path))
+		if err = os.MkdirAll(filepath.Dir(name), 0755); err == nil {
+			err = os.WriteFile(name, content, 0644)
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return
+}
+
+/*
+docGen produces the pages returned by [Schema.Documentation].
+*/
+type docGen struct {
+	schema Schema
+	format DocFormat
+	pages  map[string][]byte
+}
+
+/*
+path returns the slash-delimited path of the page describing def, or a
+zero string if def is not eligible for its own page.
+*/
+func (r docGen) path(def Definition) (p string) {
+	if def == nil || def.IsZero() {
+		return
+	}
+
+	switch def.Type() {
+	case `objectClass`:
+		p = `objectClasses/`
+	case `attributeType`:
+		p = `attributeTypes/`
+	default:
+		return
+	}
+
+	slug := def.Name()
+	if len(slug) == 0 {
+		slug = def.NumericOID()
+	}
+
+	return p + fileGroupName(slug) + r.format.ext()
+}
+
+/*
+docLabel returns the name of def, else its numeric OID.
+*/
+func docLabel(def Definition) (l string) {
+	if l = def.Name(); len(l) == 0 {
+		l = def.NumericOID()
+	}
+
+	return
+}
+
+/*
+syntaxLabel returns the description of ls, else its numeric OID.
+*/
+func syntaxLabel(ls LDAPSyntax) (l string) {
+	if l = ls.Description(); len(l) == 0 {
+		l = ls.NumericOID()
+	}
+
+	return
+}
+
+/*
+link returns a link to the page describing def, relative to the page
+found at from.  If def has no page of its own, its label is returned.
+*/
+func (r docGen) link(p docPage, from string, def Definition) string {
+	if def == nil || def.IsZero() {
+		return p.text(`none`)
+	}
+
+	to := r.path(def)
+	if len(to) == 0 {
+		return p.text(docLabel(def))
+	}
+
+	if stridx(from, `/`) != -1 {
+		to = `../` + to
+	}
+
+	return p.link(docLabel(def), to)
+}
+
+/*
+links returns the links of all definitions within defs, delimited by
+commas.
+*/
+func (r docGen) links(p docPage, from string, defs []Definition) (l string) {
+	for i, def := range defs {
+		if i > 0 {
+			l += `, `
+		}
+		l += r.link(p, from, def)
+	}
+
+	if len(l) == 0 {
+		l = p.text(`none`)
+	}
+
+	return
+}
+
+/*
+ruleCell returns the inline description of the effective matching rule
+eff, noting whether it was inherited due to declared being zero.
+*/
+func (r docGen) ruleCell(p docPage, eff, declared MatchingRule) (c string) {
+	if eff.IsZero() {
+		return p.text(`none`)
+	}
+
+	c = p.text(docLabel(eff)) + ` (` + p.code(eff.NumericOID()) + `)`
+	if declared.IsZero() {
+		c += p.text(`, inherited`)
+	}
+
+	return
+}
+
+/*
+index produces the index page.
+*/
+func (r docGen) index() {
+	from := `index` + r.format.ext()
+	p := r.format.newPage(`Schema reference`)
+	p.heading(1, `Schema reference`)
+	p.para(p.text(`Subschema subentry:`) + ` ` + p.code(r.schema.DN()))
+
+	var byName, byOID []Definition
+	for _, defs := range []Definitions{r.schema.ObjectClasses(), r.schema.AttributeTypes()} {
+		for i := 0; i < defs.Len(); i++ {
+			byName = append(byName, definitionAt(defs, i))
+		}
+	}
+	byOID = append(byOID, byName...)
+
+	sort.SliceStable(byName, func(i, j int) bool {
+		return lc(docLabel(byName[i])) < lc(docLabel(byName[j]))
+	})
+	sort.SliceStable(byOID, func(i, j int) bool {
+		return numericOIDLess(byOID[i].NumericOID(), byOID[j].NumericOID())
+	})
+
+	row := func(def Definition) []string {
+		return []string{r.link(p, from, def), p.code(def.NumericOID()),
+			p.text(def.Type()), p.text(def.Description())}
+	}
+
+	p.heading(2, `By name`)
+	var rows [][]string
+	for _, def := range byName {
+		rows = append(rows, row(def))
+	}
+	p.table([]string{`Name`, `OID`, `Type`, `Description`}, rows)
+
+	p.heading(2, `By OID`)
+	rows = nil
+	for _, def := range byOID {
+		rows = append(rows, row(def))
+	}
+	p.table([]string{`Name`, `OID`, `Type`, `Description`}, rows)
+
+	if nfs := r.schema.NameForms(); nfs.Len() > 0 {
+		p.heading(2, `Name forms`)
+		rows = nil
+		for i := 0; i < nfs.Len(); i++ {
+			nf := nfs.Index(i)
+			rows = append(rows, []string{p.text(docLabel(nf)), p.code(nf.NumericOID()),
+				r.link(p, from, nf.OC()), r.attrLinks(p, from, nf.Must())})
+		}
+		p.table([]string{`Name`, `OID`, `Object class`, `Naming attributes`}, rows)
+	}
+
+	if dcs := r.schema.DITContentRules(); dcs.Len() > 0 {
+		p.heading(2, `DIT content rules`)
+		rows = nil
+		for i := 0; i < dcs.Len(); i++ {
+			dc := dcs.Index(i)
+			rows = append(rows, []string{p.text(docLabel(dc)), p.code(dc.NumericOID()),
+				r.link(p, from, dc.StructuralClass())})
+		}
+		p.table([]string{`Name`, `OID`, `Structural class`}, rows)
+	}
+
+	if dss := r.schema.DITStructureRules(); dss.Len() > 0 {
+		p.heading(2, `DIT structure rules`)
+		rows = nil
+		for i := 0; i < dss.Len(); i++ {
+			ds := dss.Index(i)
+			rows = append(rows, []string{p.code(ds.ID()), p.text(ds.Name()),
+				p.text(docLabel(ds.Form())), r.link(p, from, ds.Form().OC())})
+		}
+		p.table([]string{`Rule ID`, `Name`, `Name form`, `Object class`}, rows)
+	}
+
+	r.pages[from] = p.bytes()
+}
+
+/*
+attrLinks returns the links of all members of ats.
+*/
+func (r docGen) attrLinks(p docPage, from string, ats AttributeTypes) string {
+	var defs []Definition
+	for i := 0; i < ats.Len(); i++ {
+		defs = append(defs, ats.Index(i))
+	}
+
+	return r.links(p, from, defs)
+}
+
+/*
+classLinks returns the links of all members of ocs.
+*/
+func (r docGen) classLinks(p docPage, from string, ocs ObjectClasses) string {
+	var defs []Definition
+	for i := 0; i < ocs.Len(); i++ {
+		defs = append(defs, ocs.Index(i))
+	}
+
+	return r.links(p, from, defs)
+}
+
+/*
+common adds the rows shared by all definition pages to rows.
+*/
+func (r docGen) common(p docPage, def Definition) (rows [][]string) {
+	rows = append(rows, []string{p.text(`OID`), p.code(def.NumericOID())})
+	if names := def.Names(); names.Len() > 0 {
+		rows = append(rows, []string{p.text(`Names`), p.text(join(names.List(), `, `))})
+	}
+	if def.Obsolete() {
+		rows = append(rows, []string{p.text(`Obsolete`), p.text(`yes`)})
+	}
+
+	return
+}
+
+/*
+extensions adds a row for each extension of def to rows.
+*/
+func (r docGen) extensions(p docPage, def Definition, rows [][]string) [][]string {
+	exts := def.Extensions()
+	for i := 0; i < exts.Len(); i++ {
+		ext := exts.Index(i)
+		var vals []string
+		for j := 0; j < ext.Values.Len(); j++ {
+			vals = append(vals, ext.Values.Index(j))
+		}
+		rows = append(rows, []string{p.code(ext.XString), p.text(join(vals, `, `))})
+	}
+
+	return rows
+}
+
+/*
+objectClass produces the page describing oc.
+*/
+func (r docGen) objectClass(oc ObjectClass) {
+	from := r.path(oc)
+	title := `objectClass ` + docLabel(oc)
+	p := r.format.newPage(title)
+	p.heading(1, title)
+	if desc := oc.Description(); len(desc) > 0 {
+		p.para(p.text(desc))
+	}
+
+	kinds := map[uint]string{AbstractKind: `ABSTRACT`, StructuralKind: `STRUCTURAL`, AuxiliaryKind: `AUXILIARY`}
+	rows := r.common(p, oc)
+	rows = append(rows,
+		[]string{p.text(`Kind`), p.text(kinds[oc.Kind()])},
+		[]string{p.text(`Superclasses`), r.classLinks(p, from, oc.SuperClasses())},
+		[]string{p.text(`Subclasses`), r.classLinks(p, from, oc.SubClasses())})
+	p.table([]string{`Property`, `Value`}, r.extensions(p, oc, rows))
+
+	// the class itself, followed by its superclasses, from
+	// which the declaring class of each attribute is found.
+	chain := []ObjectClass{oc}
+	sups := oc.SuperChain()
+	for i := 0; i < sups.Len(); i++ {
+		chain = append(chain, sups.Index(i))
+	}
+
+	for _, clause := range []struct {
+		heading string
+		all     AttributeTypes
+		local   func(ObjectClass) AttributeTypes
+	}{
+		{`Required attributes (MUST)`, oc.AllMust(), ObjectClass.Must},
+		{`Optional attributes (MAY)`, oc.AllMay(), ObjectClass.May},
+	} {
+		p.heading(2, clause.heading)
+		rows = nil
+		for i := 0; i < clause.all.Len(); i++ {
+			at := clause.all.Index(i)
+			var decl Definition
+			for _, c := range chain {
+				if clause.local(c).Contains(at.NumericOID()) {
+					decl = c
+					break
+				}
+			}
+			rows = append(rows, []string{r.link(p, from, at), p.code(at.NumericOID()),
+				p.text(syntaxLabel(at.EffectiveSyntax())), r.link(p, from, decl)})
+		}
+		p.table([]string{`Attribute`, `OID`, `Syntax`, `Declared by`}, rows)
+	}
+
+	if dc := r.schema.DITContentRules().Get(oc.NumericOID()); !dc.IsZero() {
+		p.heading(2, `DIT content rule`)
+		rows = r.common(p, dc)
+		rows = append(rows,
+			[]string{p.text(`Auxiliary classes (AUX)`), r.classLinks(p, from, dc.Aux())},
+			[]string{p.text(`Required (MUST)`), r.attrLinks(p, from, dc.Must())},
+			[]string{p.text(`Optional (MAY)`), r.attrLinks(p, from, dc.May())},
+			[]string{p.text(`Precluded (NOT)`), r.attrLinks(p, from, dc.Not())})
+		p.table([]string{`Property`, `Value`}, r.extensions(p, dc, rows))
+	}
+
+	var forms []NameForm
+	nfs := r.schema.NameForms()
+	for i := 0; i < nfs.Len(); i++ {
+		if nf := nfs.Index(i); nf.OC().NumericOID() == oc.NumericOID() {
+			forms = append(forms, nf)
+		}
+	}
+
+	if len(forms) > 0 {
+		p.heading(2, `Name forms`)
+		rows = nil
+		for _, nf := range forms {
+			rows = append(rows, []string{p.text(docLabel(nf)), p.code(nf.NumericOID()),
+				r.attrLinks(p, from, nf.Must()), r.attrLinks(p, from, nf.May())})
+		}
+		p.table([]string{`Name form`, `OID`, `Naming attributes (MUST)`, `Optional (MAY)`}, rows)
+
+		var rules [][]string
+		dss := r.schema.DITStructureRules()
+		for i := 0; i < dss.Len(); i++ {
+			ds := dss.Index(i)
+			if ds.Form().OC().NumericOID() != oc.NumericOID() {
+				continue
+			}
+
+			var sups []Definition
+			for j := 0; j < ds.SuperRules().Len(); j++ {
+				sups = append(sups, ds.SuperRules().Index(j).Form().OC())
+			}
+			rules = append(rules, []string{p.code(ds.ID()), p.text(ds.Name()),
+				p.text(docLabel(ds.Form())), r.links(p, from, sups)})
+		}
+
+		if len(rules) > 0 {
+			p.heading(2, `DIT structure rules`)
+			p.table([]string{`Rule ID`, `Name`, `Name form`, `Superior classes`}, rules)
+		}
+	}
+
+	r.pages[from] = p.bytes()
+}
+
+/*
+attributeType produces the page describing at.
+*/
+func (r docGen) attributeType(at AttributeType) {
+	from := r.path(at)
+	title := `attributeType ` + docLabel(at)
+	p := r.format.newPage(title)
+	p.heading(1, title)
+	if desc := at.Description(); len(desc) > 0 {
+		p.para(p.text(desc))
+	}
+
+	var chain []Definition
+	sups := at.SuperChain()
+	for i := 0; i < sups.Len(); i++ {
+		chain = append(chain, sups.Index(i))
+	}
+
+	syntax := p.text(`none`)
+	if stx := at.EffectiveSyntax(); !stx.IsZero() {
+		syntax = p.text(stx.Description()) + ` (` + p.code(stx.NumericOID()) + `)`
+		if at.Syntax().IsZero() {
+			syntax += p.text(`, inherited`)
+		}
+		if mub := at.MinimumUpperBounds(); mub > 0 {
+			syntax += p.text(`, upper bound ` + uitoa(mub))
+		}
+	}
+
+	yn := func(b bool) string {
+		if b {
+			return p.text(`yes`)
+		}
+		return p.text(`no`)
+	}
+
+	usage := at.Usage()
+	if len(usage) == 0 {
+		usage = `userApplications`
+	}
+
+	rows := r.common(p, at)
+	rows = append(rows,
+		[]string{p.text(`Supertypes`), r.links(p, from, chain)},
+		[]string{p.text(`Subtypes`), r.attrLinks(p, from, at.SubTypes())},
+		[]string{p.text(`Syntax`), syntax},
+		[]string{p.text(`Equality`), r.ruleCell(p, at.EffectiveEquality(), at.Equality())},
+		[]string{p.text(`Ordering`), r.ruleCell(p, at.EffectiveOrdering(), at.Ordering())},
+		[]string{p.text(`Substring`), r.ruleCell(p, at.EffectiveSubstring(), at.Substring())},
+		[]string{p.text(`Single-valued`), yn(at.SingleValue())},
+		[]string{p.text(`Collective`), yn(at.Collective())},
+		[]string{p.text(`User-modifiable`), yn(!at.NoUserModification())},
+		[]string{p.text(`Usage`), p.text(usage)})
+	p.table([]string{`Property`, `Value`}, r.extensions(p, at, rows))
+
+	p.heading(2, `Used by`)
+	rows = nil
+	ocs := r.schema.ObjectClasses()
+	for i := 0; i < ocs.Len(); i++ {
+		oc := ocs.Index(i)
+		if oc.Must().Contains(at.NumericOID()) {
+			rows = append(rows, []string{r.link(p, from, oc), p.text(`MUST`)})
+		} else if oc.May().Contains(at.NumericOID()) {
+			rows = append(rows, []string{r.link(p, from, oc), p.text(`MAY`)})
+		}
+	}
+	p.table([]string{`Object class`, `Clause`}, rows)
+
+	r.pages[from] = p.bytes()
+}
+
+/*
+docPage is implemented by the page builders of each [DocFormat].  Methods
+which return strings produce inline content for use by para and table,
+which expect such content rather than plain text.
+*/
+type docPage interface {
+	heading(int, string)
+	para(string)
+	table([]string, [][]string)
+	link(string, string) string
+	text(string) string
+	code(string) string
+	bytes() []byte
+}
+
+type markdownPage struct {
+	buf []byte
+}
+
+func newMarkdownPage(_ string) *markdownPage {
+	return &markdownPage{}
+}
+
+func (r *markdownPage) write(s string) {
+	r.buf = append(r.buf, []byte(s+string(rune(10)))...)
+}
+
+func (r *markdownPage) heading(level int, s string) {
+	var h string
+	for i := 0; i < level; i++ {
+		h += `#`
+	}
+	r.write(h + ` ` + r.text(s) + string(rune(10)))
+}
+
+func (r *markdownPage) para(s string) {
+	r.write(s + string(rune(10)))
+}
+
+func (r *markdownPage) table(cols []string, rows [][]string) {
+	if len(rows) == 0 {
+		r.para(r.text(`None.`))
+		return
+	}
+
+	var head, rule string
+	for _, col := range cols {
+		head += `| ` + r.text(col) + ` `
+		rule += `| --- `
+	}
+	r.write(head + `|`)
+	r.write(rule + `|`)
+
+	for _, row := range rows {
+		var line string
+		for _, cell := range row {
+			line += `| ` + cell + ` `
+		}
+		r.write(line + `|`)
+	}
+	r.write(``)
+}
+
+func (r *markdownPage) link(text, href string) string {
+	return `[` + r.text(text) + `](` + href + `)`
+}
+
+func (r *markdownPage) text(s string) string {
+	var b []rune
+	for _, c := range condenseWHSP(s) {
+		switch c {
+		case '\\', '|', '*', '_', '[', ']', '<', '>', '`', '#':
+			b = append(b, '\\')
+		}
+		b = append(b, c)
+	}
+
+	return string(b)
+}
+
+func (r *markdownPage) code(s string) string {
+	return "`" + repAll(s, "`", ``) + "`"
+}
+
+func (r *markdownPage) bytes() []byte {
+	return r.buf
+}
+
+type htmlPage struct {
+	buf []byte
+}
+
+func newHTMLPage(title string) (p *htmlPage) {
+	p = &htmlPage{}
+	p.write(`<!DOCTYPE html>`)
+	p.write(`<html>`)
+	p.write(`<head><meta charset="utf-8"><title>` + p.text(title) + `</title></head>`)
+	p.write(`<body>`)
+
+	return
+}
+
+func (r *htmlPage) write(s string) {
+	r.buf = append(r.buf, []byte(s+string(rune(10)))...)
+}
+
+func (r *htmlPage) heading(level int, s string) {
+	h := itoa(level)
+	r.write(`<h` + h + `>` + r.text(s) + `</h` + h + `>`)
+}
+
+func (r *htmlPage) para(s string) {
+	r.write(`<p>` + s + `</p>`)
+}
+
+func (r *htmlPage) table(cols []string, rows [][]string) {
+	if len(rows) == 0 {
+		r.para(r.text(`None.`))
+		return
+	}
+
+	r.write(`<table>`)
+	var head string
+	for _, col := range cols {
+		head += `<th>` + r.text(col) + `</th>`
+	}
+	r.write(`<tr>` + head + `</tr>`)
+
+	for _, row := range rows {
+		var line string
+		for _, cell := range row {
+			line += `<td>` + cell + `</td>`
+		}
+		r.write(`<tr>` + line + `</tr>`)
+	}
+	r.write(`</table>`)
+}
+
+func (r *htmlPage) link(text, href string) string {
+	return `<a href="` + html.EscapeString(href) + `">` + r.text(text) + `</a>`
+}
+
+func (r *htmlPage) text(s string) string {
+	return html.EscapeString(condenseWHSP(s))
+}
+
+func (r *htmlPage) code(s string) string {
+	return `<code>` + r.text(s) + `</code>`
+}
+
+func (r *htmlPage) bytes() []byte {
+	return append(r.buf, []byte(`</body>`+string(rune(10))+`</html>`+string(rune(10)))...)
+}
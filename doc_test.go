@@ -0,0 +1,105 @@
+package schemax
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+This example demonstrates the generation of Markdown documentation for
+a [Schema], one page of which describes the "person" [ObjectClass].
+*/
+func ExampleSchema_Documentation() {
+	pages, err := mySchema.Documentation(MarkdownDocs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s", pages[`objectClasses/person.md`])
+	// Output: # objectClass person
+	//
+	// | Property | Value |
+	// | --- | --- |
+	// | OID | `2.5.6.6` |
+	// | Names | person |
+	// | Kind | STRUCTURAL |
+	// | Superclasses | [top](../objectClasses/top.md) |
+	// | Subclasses | [organizationalPerson](../objectClasses/organizationalPerson.md), [residentialPerson](../objectClasses/residentialPerson.md) |
+	// | `X-ORIGIN` | RFC4519 |
+	//
+	// ## Required attributes (MUST)
+	//
+	// | Attribute | OID | Syntax | Declared by |
+	// | --- | --- | --- | --- |
+	// | [objectClass](../attributeTypes/objectClass.md) | `2.5.4.0` | OID | [top](../objectClasses/top.md) |
+	// | [cn](../attributeTypes/cn.md) | `2.5.4.3` | Directory String | [person](../objectClasses/person.md) |
+	// | [sn](../attributeTypes/sn.md) | `2.5.4.4` | Directory String | [person](../objectClasses/person.md) |
+	//
+	// ## Optional attributes (MAY)
+	//
+	// | Attribute | OID | Syntax | Declared by |
+	// | --- | --- | --- | --- |
+	// | [description](../attributeTypes/description.md) | `2.5.4.13` | Directory String | [person](../objectClasses/person.md) |
+	// | [seeAlso](../attributeTypes/seeAlso.md) | `2.5.4.34` | DN | [person](../objectClasses/person.md) |
+	// | [telephoneNumber](../attributeTypes/telephoneNumber.md) | `2.5.4.20` | Telephone Number | [person](../objectClasses/person.md) |
+	// | [userPassword](../attributeTypes/userPassword.md) | `2.5.4.35` | Octet String | [person](../objectClasses/person.md) |
+}
+
+func TestSchema_Documentation(t *testing.T) {
+	pages, err := mySchema.Documentation(HTMLDocs)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	want := 1 + mySchema.ObjectClasses().Len() + mySchema.AttributeTypes().Len()
+	if len(pages) != want {
+		t.Errorf("%s failed: want %d pages, got %d", t.Name(), want, len(pages))
+		return
+	}
+
+	at := string(pages[`attributeTypes/cn.html`])
+	for _, frag := range []string{
+		`<title>attributeType cn</title>`,
+		`<td>Syntax</td><td>Directory String (<code>1.3.6.1.4.1.1466.115.121.1.15</code>), inherited</td>`,
+		`<a href="../attributeTypes/name.html">name</a>`,
+		`<a href="../objectClasses/person.html">person</a></td><td>MUST</td>`,
+	} {
+		if stridx(at, frag) == -1 {
+			t.Errorf("%s failed: %q not found in:\n%s", t.Name(), frag, at)
+			return
+		}
+	}
+
+	// every link must lead to a generated page
+	for path, content := range pages {
+		page := string(content)
+		for i := stridx(page, `href="`); i != -1; i = stridx(page, `href="`) {
+			page = page[i+6:]
+			href := page[:stridx(page, `"`)]
+			target := filepath.ToSlash(filepath.Join(filepath.Dir(path), href))
+			if _, found := pages[target]; !found {
+				t.Errorf("%s failed: broken link %s in %s", t.Name(), href, path)
+				return
+			}
+		}
+	}
+
+	dir := t.TempDir()
+	if err = mySchema.WriteDocumentation(dir, MarkdownDocs); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if _, err = os.Stat(filepath.Join(dir, `objectClasses`, `inetOrgPerson.md`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if _, err = mySchema.Documentation(DocFormat(9)); err == nil {
+		t.Errorf("%s failed: expected error for unknown format", t.Name())
+	} else if _, err = (Schema{}).Documentation(MarkdownDocs); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+	}
+}
@@ -78,6 +78,9 @@ func (r *dITStructureRule) replace(x DITStructureRule) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, DITStructureRule{r})()
+
 	r.ID = x.dITStructureRule.ID
 	r.Name = x.dITStructureRule.Name
 	r.Desc = x.dITStructureRule.Desc
@@ -85,9 +88,17 @@ func (r *dITStructureRule) replace(x DITStructureRule) {
 	r.Obsolete = x.dITStructureRule.Obsolete
 	r.SuperRules = x.dITStructureRule.SuperRules
 	r.Extensions = x.dITStructureRule.Extensions
-	r.stringer = x.dITStructureRule.stringer
+	r.stringer, r.rendering = copyStringer(x.dITStructureRule.stringer, x.dITStructureRule.rendering, r.prepareString)
 	r.schema = x.dITStructureRule.schema
 	r.data = x.dITStructureRule.data
+
+	if member(old.schema, DITStructureRule{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  DITStructureRule{&old},
+			New:  DITStructureRule{r},
+		})
+	}
 }
 
 /*
@@ -106,6 +117,10 @@ func (r DITStructureRule) SetData(x any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setData(x any) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -141,6 +156,10 @@ func (r DITStructureRule) SetSchema(schema Schema) DITStructureRule {
 }
 
 func (r *dITStructureRule) setSchema(schema Schema) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -448,22 +467,21 @@ func (r DITStructureRule) SetStringer(function ...Stringer) DITStructureRule {
 }
 
 func (r *dITStructureRule) setStringer(function ...Stringer) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
 	}
 
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// text/template op is deferred until first use
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 	} else {
 		r.stringer = stringer
+		r.rendering = nil
 	}
 }
 
@@ -484,6 +502,11 @@ func (r DITStructureRule) SetName(x ...string) DITStructureRule {
 }
 
 func (r *dITStructureRule) setName(x ...string) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, DITStructureRule{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -503,6 +526,10 @@ func (r DITStructureRule) SetObsolete() DITStructureRule {
 }
 
 func (r *dITStructureRule) setObsolete() {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -524,6 +551,10 @@ func (r DITStructureRule) SetDescription(desc string) DITStructureRule {
 }
 
 func (r *dITStructureRule) setDescription(desc string) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -556,6 +587,10 @@ func (r DITStructureRule) SetExtension(x string, xstrs ...string) DITStructureRu
 }
 
 func (r *dITStructureRule) setExtension(x string, xstrs ...string) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -577,6 +612,11 @@ func (r DITStructureRule) SetRuleID(id any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setRuleID(x any) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, DITStructureRule{r})()
+
 	switch tv := x.(type) {
 	case uint64:
 		r.ID = uint(tv)
@@ -610,6 +650,10 @@ func (r DITStructureRule) SetSuperRule(m ...any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setSuperRule(m ...any) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var def DITStructureRule
@@ -657,7 +701,7 @@ func (r DITStructureRule) Parse(raw string) (err error) {
 
 func (r *dITStructureRule) parse(raw string) error {
 	// parseLS wraps the antlr4512 DITStructureRule parser/lexer
-	mp, err := parseDS(raw)
+	mp, err := parseDS(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -686,6 +730,10 @@ func (r DITStructureRule) SetForm(x any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setForm(x any) {
+	if !modify(DITStructureRule{r}, r.rendering) {
+		return
+	}
+
 	var def NameForm
 	switch tv := x.(type) {
 	case string:
@@ -791,6 +839,12 @@ func (r DITStructureRules) Inventory() (inv Inventory) {
 func (r DITStructureRule) setOID(_ string) {}
 func (r DITStructureRule) macro() []string { return []string{} }
 
+func (r DITStructureRule) resetRendering() {
+	if !r.IsZero() {
+		r.dITStructureRule.rendering.reset()
+	}
+}
+
 // stackage closure func - do not exec directly (use String method)
 func (r DITStructureRules) iDsStringer(_ ...any) (present string) {
 	var _present []string
@@ -894,7 +948,7 @@ func (r DITStructureRules) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -925,16 +979,18 @@ the principal identifier of an [DITStructureRule] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index.
 */
 func (r DITStructureRules) Get(id any) DITStructureRule {
 	return r.get(id)
 }
 
 func (r DITStructureRules) get(id any) (ds DITStructureRule) {
+	// An empty receiver is not grounds for an early return,
+	// as it may be the collection of an overlay, in which
+	// case the lookup falls through to that of its base.
 	L := r.len()
-	if L == 0 {
-		return
-	}
 
 	var n uint
 	var name string
@@ -959,6 +1015,16 @@ func (r DITStructureRules) get(id any) (ds DITStructureRule) {
 		}
 	}
 
+	key := name
+	if !named {
+		key = uitoa(n)
+	}
+
+	if def, indexed := lookupDefinition(r.cast(), key); indexed {
+		ds, _ = def.(DITStructureRule)
+		return
+	}
+
 	for i := 0; i < L && ds.IsZero(); i++ {
 		_ds := r.index(i)
 		if named {
@@ -1026,23 +1092,15 @@ func (r DITStructureRule) Map() (def DefinitionMap) {
 
 func (r *dITStructureRule) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(r.Type()).
-		Funcs(funcMap(map[string]any{
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			// SuperLen refers to the integer number of
-			// superior dITStructureRule instances held
-			// by a dITStructureRule.
-			`SuperLen`: r.SuperRules.len,
-			`Obsolete`: func() bool { return r.Obsolete },
-		}))
-
-	if t, err = t.Parse(dITStructureRuleTmpl); err == nil {
+	t := newTemplate(r.Type()).Funcs(funcMap(r.tmplFuncs()))
+
+	if t, err = t.Parse(r.schema.template(`dITStructureRule`, dITStructureRuleTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *dITStructureRule
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -1051,6 +1109,22 @@ func (r *dITStructureRule) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *dITStructureRule) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		// SuperLen refers to the integer number of
+		// superior dITStructureRule instances held
+		// by a dITStructureRule.
+		`SuperLen`: r.SuperRules.len,
+		`Obsolete`: func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `OBSOLETE`, `FORM`, `SUP`)
+}
+
 /*
 IsZero returns a Boolean value indicative of a nil receiver state.
 */
@@ -25,6 +25,14 @@ var (
 	ErrNotUnique           error = errors.New("Definition is already defined")
 	ErrNotEqual            error = errors.New("Values are not equal")
 	ErrMissingNumericOID   error = errors.New("Missing or invalid numeric OID for definition")
+	ErrDefNotFound         error = errors.New("Definition not found")
+	ErrOverrideNotAllowed  error = errors.New("Definition override not allowed; see AllowOverride")
+	ErrTxClosed            error = errors.New("Transaction has already been committed or rolled back")
+	ErrTxConflict          error = errors.New("Schema was modified outside of the transaction")
+	ErrBinaryFormat        error = errors.New("Input is not a binary-encoded Schema")
+	ErrBinaryVersion       error = errors.New("Binary-encoded Schema was produced by an incompatible revision")
+	ErrDirectoryNotEmpty   error = errors.New("Directory already contains schema files")
+	ErrInformationObject   error = errors.New("Malformed ASN.1 information object notation")
 
 	ErrOrderingRuleNotFound  error = errors.New("ORDERING MatchingRule not found")
 	ErrSubstringRuleNotFound error = errors.New("SUBSTR MatchingRule not found")
@@ -94,6 +94,7 @@ func (r Extensions) Set(key string, values ...string) {
 	_key := uc(key)
 	var hi, se bool
 	if def := r.Definition(); def != nil {
+		def.resetRendering()
 		if sch := def.Schema(); !sch.IsZero() {
 			opts := sch.Options()
 			hi = opts.Positive(HangingIndents)
@@ -180,9 +181,20 @@ func (r Extensions) Keys() (keys []string) {
 	return
 }
 
+/*
+tmplFunc returns the string representation of the receiver instance per
+the [Formatting] in effect for the [Schema] of the associated [Definition].
+*/
 func (r Extensions) tmplFunc() (e string) {
 	if !r.IsZero() {
-		e = r.String()
+		var hi string
+		if def := r.Definition(); def != nil && !def.Schema().IsZero() {
+			hi = def.Schema().Formatting().hindent()
+		}
+
+		for i := 0; i < r.Len(); i++ {
+			e += r.Index(i).string(hi)
+		}
 	}
 
 	return
@@ -191,15 +203,28 @@ func (r Extensions) tmplFunc() (e string) {
 /*
 String returns the string representation of the receiver instance.
 */
-func (r Extension) String() (s string) {
+func (r Extension) String() string {
+	return r.string(``)
+}
+
+/*
+string returns the string representation of the receiver instance, with
+hi serving as the leading whitespace.  A zero hi results in the use of a
+hanging indent only if one was in effect when the receiver was created.
+*/
+func (r Extension) string(hi string) (s string) {
 	if !r.IsZero() {
+		if len(hi) == 0 {
+			hi = Formatting{MultiLine: r.hindent}.hindent()
+		}
+
 		switch r.Values.Len() {
 		case 0:
 			break
 		case 1:
-			s = hindent(r.hindent) + r.XString + ` ` + `'` + r.Values.Index(0) + `'`
+			s = hi + r.XString + ` ` + `'` + r.Values.Index(0) + `'`
 		default:
-			s = hindent(r.hindent) + r.XString + ` ` + r.Values.String()
+			s = hi + r.XString + ` ` + r.Values.String()
 		}
 	}
 
@@ -0,0 +1,245 @@
+package schemax
+
+/*
+format.go implements the formatting controls honored by the default
+rendering of definitions.
+*/
+
+/*
+Formatting contains the settings which govern the string representation
+of definitions produced by the package-default templates, as well as any
+templates registered by way of [Schema.SetTemplate] which make use of the
+relevant template functions.
+
+A zero instance of this type produces the package-default output, which
+is subject to the [HangingIndents] option.
+
+See [Schema.SetFormatting] for details.
+*/
+type Formatting struct {
+	// Indent is the number of spaces which precede each clause when
+	// MultiLine is in effect.  Values less than one imply four (4).
+	Indent int
+
+	// LineWidth is the maximum desired line width, beyond which the
+	// OID lists of clauses such as MUST and MAY are wrapped.  Zero
+	// indicates no maximum, in which case each list member occupies
+	// its own line.  This setting only applies when MultiLine is in
+	// effect.
+	LineWidth int
+
+	// ClauseOrder contains the clause keywords, e.g.: "NAME", "DESC"
+	// or "MUST", in the order in which they are to be rendered. The
+	// pseudo-keyword "KIND" refers to the kind of an ObjectClass.
+	// Clauses absent from the slice are rendered afterwards in their
+	// default order.  Extensions are always rendered last.
+	//
+	// Note that an order contrary to RFC 4512 may produce output not
+	// readable by strict parsers, including that of this package.
+	ClauseOrder []string
+
+	// EmitDefaults results in the rendering of clauses which would
+	// otherwise be omitted due to their value being the default, such
+	// as "USAGE userApplications".
+	EmitDefaults bool
+
+	// MultiLine results in each clause being rendered on its own line,
+	// indented per Indent.  This is implied by the HangingIndents
+	// Option.
+	MultiLine bool
+
+	// ParenthesizeNames results in the enclosure of a lone NAME value
+	// within parentheses, e.g.: "NAME ( 'cn' )" rather than "NAME 'cn'".
+	ParenthesizeNames bool
+
+	// MacroOIDs results in the numeric OID of a definition being
+	// rendered in macro form, e.g.: "nisSchema.1.2" rather than
+	// "1.3.6.1.1.1.1.2", if a suitable macro is registered within
+	// the Macros of the Schema.  This does not apply to instances of
+	// MatchingRuleUse or DITStructureRule.  See also the method
+	// Schema.MacroDeclarations.
+	MacroOIDs bool
+}
+
+/*
+Formatting returns the [Formatting] instance in effect for the receiver
+instance.  The Indent field is resolved to its effective value, and the
+MultiLine field shall be true if the [HangingIndents] option is set.
+*/
+func (r Schema) Formatting() (f Formatting) {
+	if !r.IsZero() {
+		if _f, ok := r.cast().Auxiliary()[`formatting`].(*Formatting); ok && _f != nil {
+			f = _f.clone()
+		}
+		f.MultiLine = f.MultiLine || r.Options().Positive(HangingIndents)
+	}
+
+	if f.Indent < 1 {
+		f.Indent = 4
+	}
+
+	return
+}
+
+/*
+SetFormatting assigns f to the receiver instance, thereby altering the
+string representation of all definitions it contains, as well as any
+added subsequently.  The cached string values of such definitions are
+reset.  Definitions bearing a user-provided [Stringer] closure are not
+affected.
+
+Formatting is preserved through [Schema.Clone] and [Schema.Overlay], but
+not through [Schema.MarshalBinary] or [Schema.MarshalJSON].  This method
+has no effect upon a frozen [Schema] (see [Schema.Snapshot]).
+
+This is a fluent method.
+*/
+func (r Schema) SetFormatting(f Formatting) Schema {
+	if !r.IsZero() && !r.IsFrozen() {
+		f = f.clone()
+		r.cast().Auxiliary()[`formatting`] = &f
+		r.resetRenderings()
+	}
+
+	return r
+}
+
+/*
+resetRenderings discards the cached string values of all definitions
+within the receiver instance.
+*/
+func (r Schema) resetRenderings() {
+	for _, defs := range r.collections() {
+		for i := 0; i < defs.Len(); i++ {
+			definitionAt(defs, i).resetRendering()
+		}
+	}
+}
+
+/*
+clone returns a copy of the receiver instance.
+*/
+func (r Formatting) clone() (f Formatting) {
+	f = r
+	if r.ClauseOrder != nil {
+		f.ClauseOrder = make([]string, len(r.ClauseOrder))
+		copy(f.ClauseOrder, r.ClauseOrder)
+	}
+
+	return
+}
+
+/*
+hindent returns the whitespace which precedes each clause.
+*/
+func (r Formatting) hindent() (x string) {
+	x = ` `
+	if r.MultiLine {
+		indent := r.Indent
+		if indent < 1 {
+			indent = 4
+		}
+		x = string(rune(10)) + pad(indent)
+	}
+
+	return
+}
+
+/*
+clauses returns the clause keywords within defaults, ordered per the
+ClauseOrder field of the receiver instance.
+*/
+func (r Formatting) clauses(defaults ...string) (c []string) {
+	seen := make(map[string]bool, len(defaults))
+	for _, k := range defaults {
+		seen[k] = false
+	}
+
+	for _, k := range r.ClauseOrder {
+		k = uc(trimS(k))
+		if done, found := seen[k]; found && !done {
+			c = append(c, k)
+			seen[k] = true
+		}
+	}
+
+	for _, k := range defaults {
+		if !seen[k] {
+			c = append(c, k)
+		}
+	}
+
+	return
+}
+
+/*
+name returns the string representation of the NAME clause value.
+*/
+func (r Formatting) name(names QuotedDescriptorList) (n string) {
+	if !names.IsZero() {
+		if n = names.String(); names.Len() == 1 && r.ParenthesizeNames {
+			n = `( ` + n + ` )`
+		}
+	}
+
+	return
+}
+
+/*
+list returns the multi-line string representation of oids, the members
+of which are aligned beneath the opening parenthesis.  lead is the length
+of the clause keyword which precedes the list.
+*/
+func (r Formatting) list(oids []string, lead int) (present string) {
+	switch len(oids) {
+	case 0:
+		return
+	case 1:
+		present = oids[0]
+		return
+	}
+
+	indent := r.Indent
+	if indent < 1 {
+		indent = 4
+	}
+
+	num := lead + indent + 1
+	present = `( ` + oids[0]
+	col := num + len(present)
+	for i := 1; i < len(oids); i++ {
+		next := ` $ ` + oids[i]
+		if r.LineWidth > 0 && col+len(next) <= r.LineWidth {
+			present += next
+			col += len(next)
+			continue
+		}
+
+		present += string(rune(10)) + pad(num) + next[1:]
+		col = num + len(next) - 1
+	}
+	present += ` )`
+
+	return
+}
+
+/*
+funcs adds the template functions common to all definition types to fm,
+returning the result.
+*/
+func (r Formatting) funcs(names QuotedDescriptorList, fm map[string]any, defaults ...string) map[string]any {
+	fm[`Clauses`] = func() []string { return r.clauses(defaults...) }
+	fm[`Name`] = func() string { return r.name(names) }
+	return fm
+}
+
+/*
+pad returns a string of n spaces.
+*/
+func pad(n int) (x string) {
+	for i := 0; i < n; i++ {
+		x += ` `
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the use of a custom [Formatting] instance to
+alter the string representation of all definitions within a [Schema].
+*/
+func ExampleSchema_SetFormatting() {
+	sch := mySchema.Clone().SetFormatting(Formatting{
+		Indent:            2,
+		LineWidth:         40,
+		ParenthesizeNames: true,
+	})
+
+	fmt.Println(sch.ObjectClasses().Get(`person`))
+	// Output: ( 2.5.6.6
+	//   NAME ( 'person' )
+	//   SUP top
+	//   STRUCTURAL
+	//   MUST ( cn $ sn )
+	//   MAY ( description $ seeAlso
+	//       $ telephoneNumber $ userPassword )
+	//   X-ORIGIN 'RFC4519' )
+}
+
+func TestSchema_SetFormatting(t *testing.T) {
+	sch := mySchema.Clone()
+	sch.Options().Unshift(HangingIndents)
+	cn := sch.AttributeTypes().Get(`cn`)
+	want := cn.String()
+
+	sch.SetFormatting(Formatting{
+		EmitDefaults: true,
+		ClauseOrder:  []string{`desc`, `NAME`, `BOGUS`},
+	})
+
+	expect := `( 2.5.4.3 DESC 'RFC4519: common name(s) for which the entity is known by' ` +
+		`NAME ( 'cn' 'commonName' ) SUP name USAGE userApplications X-ORIGIN 'RFC4519' )`
+	if got := cn.String(); got != expect {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), expect, got)
+		return
+	}
+
+	// formatting survives cloning, but does not affect the source
+	if got := sch.Clone().AttributeTypes().Get(`cn`).String(); got != expect {
+		t.Errorf("%s failed: formatting not cloned:\n%s", t.Name(), got)
+		return
+	} else if mySchema.AttributeTypes().Get(`cn`).String() == expect {
+		t.Errorf("%s failed: source schema altered", t.Name())
+		return
+	}
+
+	// multi-line output must remain parseable
+	sch.SetFormatting(Formatting{MultiLine: true, Indent: 1, LineWidth: 30})
+	inet := sch.ObjectClasses().Get(`inetOrgPerson`)
+	raw := repAll(inet.String(), inet.NumericOID(), `1.3.6.1.4.1.56521.999.1`)
+	raw = repAll(raw, `'inetOrgPerson'`, `'testPerson'`)
+	if err := sch.ParseRaw([]byte(`objectClass ` + raw)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := sch.ObjectClasses().Get(`testPerson`).May().Len(); got != inet.May().Len() {
+		t.Errorf("%s failed: want %d MAY members, got %d", t.Name(), inet.May().Len(), got)
+		return
+	}
+
+	// the zero value restores the default output
+	if sch.SetFormatting(Formatting{}); cn.String() != want {
+		t.Errorf("%s failed: default not restored:\nwant: %s\ngot:  %s", t.Name(), want, cn.String())
+		return
+	}
+
+	if f := (Schema{}).Formatting(); f.Indent != 4 || f.MultiLine {
+		t.Errorf("%s failed: unexpected zero schema formatting %#v", t.Name(), f)
+	}
+}
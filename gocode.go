@@ -0,0 +1,336 @@
+package schemax
+
+/*
+gocode.go implements the generation of Go source code from the attribute
+types and object classes of a Schema.
+*/
+
+import (
+	"go/format"
+	"strconv"
+)
+
+/*
+GoCodeConfig contains the settings which govern the Go source code
+produced by way of the [Schema.GoCode] method.  A zero instance of this
+type is valid.
+*/
+type GoCodeConfig struct {
+	// Package is the name of the package to which the source code
+	// belongs.  A zero value implies "schema".
+	Package string
+
+	// ObjectClasses contains the names or numeric OIDs of the object
+	// classes for which source code is generated, alongside those of the
+	// attribute types they allow.  If empty, source code is generated
+	// for all object classes and attribute types.
+	ObjectClasses []string
+
+	// Tag is the key of the struct tag bearing the name of the attribute
+	// type to which each struct field corresponds.  A zero value implies
+	// "ldap".
+	Tag string
+}
+
+/*
+goCodeTypes maps the numeric OIDs of RFC 4517 syntaxes to the Go type of
+a single value thereof.  Syntaxes not present are represented as strings.
+*/
+var goCodeTypes = map[string]string{
+	`1.3.6.1.4.1.1466.115.121.1.5`:  `[]byte`,    // Binary
+	`1.3.6.1.4.1.1466.115.121.1.7`:  `bool`,      // Boolean
+	`1.3.6.1.4.1.1466.115.121.1.8`:  `[]byte`,    // Certificate
+	`1.3.6.1.4.1.1466.115.121.1.9`:  `[]byte`,    // Certificate List
+	`1.3.6.1.4.1.1466.115.121.1.10`: `[]byte`,    // Certificate Pair
+	`1.3.6.1.4.1.1466.115.121.1.24`: `time.Time`, // Generalized Time
+	`1.3.6.1.4.1.1466.115.121.1.27`: `int64`,     // Integer
+	`1.3.6.1.4.1.1466.115.121.1.28`: `[]byte`,    // JPEG
+	`1.3.6.1.4.1.1466.115.121.1.40`: `[]byte`,    // Octet String
+	`1.3.6.1.4.1.1466.115.121.1.53`: `time.Time`, // UTC Time
+}
+
+/*
+GoCode returns gofmt-formatted Go source code describing the attribute
+types and object classes of the receiver instance, alongside an error.
+The source code contains:
+
+  - the string types AttributeType, ObjectClass and OID
+  - an AttributeType and OID constant per [AttributeType], such as
+    AttrCn ("cn") and AttrCnOID ("2.5.4.3")
+  - an ObjectClass and OID constant per [ObjectClass], such as
+    ClassPerson ("person") and ClassPersonOID ("2.5.6.6")
+  - a struct per [ObjectClass], such as Person, bearing a DN field as
+    well as a field per [AttributeType] allowed by the class, including
+    those inherited from its super classes (see [ObjectClass.AllMust]
+    and [ObjectClass.AllMay])
+
+Go identifiers are derived from the principal name of each definition,
+e.g.: "inetOrgPerson" becomes InetOrgPerson, or from its numeric OID if
+unnamed.  Struct fields are typed per the effective syntax of their type
+(see [AttributeType.EffectiveSyntax]) as one of string, int64, bool,
+time.Time or []byte, and are slices thereof unless the type is single-
+valued (see [AttributeType.SingleValue]).  Each field bears a struct tag
+keyed per [GoCodeConfig.Tag] whose value is the name of its type, e.g.:
+`ldap:"cn"`.
+
+The source code is marked as generated, and is thus suitable for use with
+"go generate".  An error is returned if the receiver is zero, if any class
+named within cfg is not found, or if any Go identifier would be declared
+more than once.
+*/
+func (r Schema) GoCode(cfg GoCodeConfig) (src []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	if len(cfg.Package) == 0 {
+		cfg.Package = `schema`
+	}
+	if len(cfg.Tag) == 0 {
+		cfg.Tag = `ldap`
+	}
+
+	var ocs, ats []Definition
+	if ocs, ats, err = r.goCodeDefinitions(cfg.ObjectClasses); err != nil {
+		return
+	}
+
+	g := goCodeGen{
+		tag:  cfg.Tag,
+		used: map[string]string{`AttributeType`: `a generated type`, `ObjectClass`: `a generated type`, `OID`: `a generated type`},
+	}
+
+	g.line(`// Code generated by schemax; DO NOT EDIT.`)
+	g.line(``)
+	g.line(`package ` + cfg.Package)
+	g.line(`@IMPORTS@`)
+	g.line(`// AttributeType is the name of an LDAP attribute type.`)
+	g.line(`type AttributeType string`)
+	g.line(``)
+	g.line(`// ObjectClass is the name of an LDAP object class.`)
+	g.line(`type ObjectClass string`)
+	g.line(``)
+	g.line(`// OID is a numeric object identifier.`)
+	g.line(`type OID string`)
+
+	if err = g.constants(`Attr`, `AttributeType`, ats); err == nil {
+		if err = g.constants(`Class`, `ObjectClass`, ocs); err == nil {
+			for i := 0; i < len(ocs) && err == nil; i++ {
+				err = g.structure(ocs[i].(ObjectClass))
+			}
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	var imports string
+	if g.time {
+		imports = "\nimport \"time\"\n"
+	}
+
+	return format.Source([]byte(repAll(g.src, `@IMPORTS@`, imports)))
+}
+
+/*
+goCodeDefinitions returns the object classes identified by ids -- or all
+object classes if ids is empty -- as well as the attribute types allowed
+by them, alongside an error.
+*/
+func (r Schema) goCodeDefinitions(ids []string) (ocs, ats []Definition, err error) {
+	all := r.ObjectClasses()
+	if len(ids) == 0 {
+		ocs = diagramClasses(all)
+		for i := 0; i < r.AttributeTypes().Len(); i++ {
+			ats = append(ats, r.AttributeTypes().Index(i))
+		}
+		return
+	}
+
+	allowed := NewAttributeTypeOIDList()
+	for _, id := range ids {
+		oc := all.Get(id)
+		if oc.IsZero() {
+			err = mkerr(ErrObjectClassNotFound.Error() + `: ` + id)
+			return
+		}
+		ocs = append(ocs, oc)
+		pushAttributeTypes(allowed, oc.AllMust())
+		pushAttributeTypes(allowed, oc.AllMay())
+	}
+
+	// Retain the order of the attribute types
+	// within the receiver.
+	for i := 0; i < r.AttributeTypes().Len(); i++ {
+		if at := r.AttributeTypes().Index(i); allowed.Contains(at.NumericOID()) {
+			ats = append(ats, at)
+		}
+	}
+
+	return
+}
+
+/*
+goCodeGen accumulates the source code produced by [Schema.GoCode].
+*/
+type goCodeGen struct {
+	src  string
+	tag  string
+	time bool
+	used map[string]string // Go identifier -> definition name
+}
+
+/*
+line appends s, followed by a line feed, to the source code.
+*/
+func (r *goCodeGen) line(s string) {
+	r.src += s + string(rune(10))
+}
+
+/*
+structTag returns the struct tag bearing the attribute type name.
+*/
+func (r *goCodeGen) structTag(name string) string {
+	return "`" + r.tag + `:` + strconv.Quote(name) + "`"
+}
+
+/*
+declare returns an error if ident was previously declared.
+*/
+func (r *goCodeGen) declare(ident, name string) (err error) {
+	if prev, found := r.used[ident]; found {
+		err = mkerr(ErrNotUnique.Error() + `: Go identifier ` + ident +
+			` of ` + name + ` conflicts with ` + prev)
+		return
+	}
+	r.used[ident] = name
+
+	return
+}
+
+/*
+constants appends the name and OID constants of defs, each identifier of
+which begins with prefix, to the source code.
+*/
+func (r *goCodeGen) constants(prefix, typ string, defs []Definition) (err error) {
+	if len(defs) == 0 {
+		return
+	}
+
+	r.line(``)
+	r.line(`// ` + typ + ` names and numeric OIDs.`)
+	r.line(`const (`)
+	for _, def := range defs {
+		label := docLabel(def)
+		ident := prefix + goIdent(def)
+		if err = r.declare(ident, label); err == nil {
+			err = r.declare(ident+`OID`, label)
+		}
+
+		if err != nil {
+			break
+		}
+
+		r.line(ident + ` ` + typ + ` = ` + strconv.Quote(label))
+		r.line(ident + `OID OID = ` + strconv.Quote(def.NumericOID()))
+	}
+	r.line(`)`)
+
+	return
+}
+
+/*
+structure appends the struct type describing entries of oc to the source
+code.
+*/
+func (r *goCodeGen) structure(oc ObjectClass) (err error) {
+	ident := goIdent(oc)
+	if err = r.declare(ident, docLabel(oc)); err != nil {
+		return
+	}
+
+	kinds := map[uint]string{AbstractKind: `abstract`, StructuralKind: `structural`, AuxiliaryKind: `auxiliary`}
+
+	r.line(``)
+	r.line(`// ` + ident + ` describes entries of the ` + docLabel(oc) + ` ` +
+		kinds[oc.Kind()] + ` object class (` + oc.NumericOID() + `).`)
+	r.line(`type ` + ident + ` struct {`)
+	r.line(`DN string ` + r.structTag(`dn`))
+
+	must := oc.AllMust()
+	fields := map[string]bool{`DN`: true}
+	clause := func(ats AttributeTypes, kw string) {
+		for i := 0; i < ats.Len() && err == nil; i++ {
+			at := ats.Index(i)
+			field := goIdent(at)
+			if fields[field] {
+				if kw == `MUST` || !must.Contains(at.NumericOID()) {
+					err = mkerr(ErrNotUnique.Error() + `: Go identifier ` + field +
+						` of ` + docLabel(at) + ` conflicts within ` + ident)
+				}
+				continue
+			}
+			fields[field] = true
+
+			r.line(``)
+			r.line(`// ` + field + ` holds the value(s) of ` + docLabel(at) +
+				` (` + at.NumericOID() + `); ` + kw + `.`)
+			r.line(field + ` ` + r.fieldType(at) + ` ` + r.structTag(docLabel(at)))
+		}
+	}
+
+	clause(must, `MUST`)
+	clause(oc.AllMay(), `MAY`)
+	r.line(`}`)
+
+	return
+}
+
+/*
+fieldType returns the Go type of the struct field describing at.
+*/
+func (r *goCodeGen) fieldType(at AttributeType) (typ string) {
+	typ = `string`
+	if t, found := goCodeTypes[at.EffectiveSyntax().NumericOID()]; found {
+		typ = t
+	}
+
+	if typ == `time.Time` {
+		r.time = true
+	}
+
+	if !at.SingleValue() {
+		typ = `[]` + typ
+	}
+
+	return
+}
+
+/*
+goIdent returns the exported Go identifier derived from the principal name
+of def, e.g.: "InetOrgPerson" for "inetOrgPerson", or from its numeric OID
+if unnamed, e.g.: "OID2_5_4_3".
+*/
+func goIdent(def Definition) (ident string) {
+	name := def.Name()
+	if len(name) == 0 {
+		return `OID` + repAll(def.NumericOID(), `.`, `_`)
+	}
+
+	upper := true
+	for _, c := range name {
+		switch {
+		case c == '-':
+			upper = true
+		case upper:
+			ident += uc(string(c))
+			upper = false
+		default:
+			ident += string(c)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,153 @@
+package schemax
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+/*
+This example demonstrates the generation of Go source code describing
+the "pkiUser" [ObjectClass] and the [AttributeType] instances it allows.
+*/
+func ExampleSchema_GoCode() {
+	src, err := mySchema.GoCode(GoCodeConfig{
+		Package:       `directory`,
+		ObjectClasses: []string{`pkiUser`},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s", src)
+	// Output: // Code generated by schemax; DO NOT EDIT.
+	//
+	// package directory
+	//
+	// // AttributeType is the name of an LDAP attribute type.
+	// type AttributeType string
+	//
+	// // ObjectClass is the name of an LDAP object class.
+	// type ObjectClass string
+	//
+	// // OID is a numeric object identifier.
+	// type OID string
+	//
+	// // AttributeType names and numeric OIDs.
+	// const (
+	// 	AttrObjectClass        AttributeType = "objectClass"
+	// 	AttrObjectClassOID     OID           = "2.5.4.0"
+	// 	AttrUserCertificate    AttributeType = "userCertificate"
+	// 	AttrUserCertificateOID OID           = "2.5.4.36"
+	// )
+	//
+	// // ObjectClass names and numeric OIDs.
+	// const (
+	// 	ClassPkiUser    ObjectClass = "pkiUser"
+	// 	ClassPkiUserOID OID         = "2.5.6.21"
+	// )
+	//
+	// // PkiUser describes entries of the pkiUser auxiliary object class (2.5.6.21).
+	// type PkiUser struct {
+	// 	DN string `ldap:"dn"`
+	//
+	// 	// ObjectClass holds the value(s) of objectClass (2.5.4.0); MUST.
+	// 	ObjectClass []string `ldap:"objectClass"`
+	//
+	// 	// UserCertificate holds the value(s) of userCertificate (2.5.4.36); MAY.
+	// 	UserCertificate [][]byte `ldap:"userCertificate"`
+	// }
+}
+
+func TestSchema_GoCode(t *testing.T) {
+	s := mySchema.Clone()
+	if err := s.ParseRaw([]byte(`
+attributetype ( 1.3.6.1.4.1.56521.999.88.1 NAME 'lastSeen'
+	EQUALITY generalizedTimeMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.24 SINGLE-VALUE )
+attributetype ( 1.3.6.1.4.1.56521.999.88.2 NAME 'is-active'
+	EQUALITY booleanMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.7 SINGLE-VALUE )
+attributetype ( 1.3.6.1.4.1.56521.999.88.3 NAME 'badgeNumber'
+	EQUALITY integerMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 )
+objectclass ( 1.3.6.1.4.1.56521.999.88.4 NAME 'badgeHolder'
+	SUP inetOrgPerson STRUCTURAL
+	MUST badgeNumber MAY ( lastSeen $ is-active ) )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	src, err := s.GoCode(GoCodeConfig{Tag: `attr`})
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// The source code of the entire schema must type-check.
+	fset := token.NewFileSet()
+	var file *ast.File
+	if file, err = parser.ParseFile(fset, `gen.go`, src, 0); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, `source`, nil)}
+	var pkg *types.Package
+	if pkg, err = conf.Check(`schema`, fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	holder, ok := pkg.Scope().Lookup(`BadgeHolder`).Type().Underlying().(*types.Struct)
+	if !ok {
+		t.Errorf("%s failed: BadgeHolder struct not found", t.Name())
+		return
+	}
+
+	want := map[string][2]string{
+		`DN`:          {`string`, `attr:"dn"`},
+		`BadgeNumber`: {`[]int64`, `attr:"badgeNumber"`},
+		`LastSeen`:    {`time.Time`, `attr:"lastSeen"`},
+		`IsActive`:    {`bool`, `attr:"is-active"`},
+		`GivenName`:   {`[]string`, `attr:"givenName"`},
+		`JpegPhoto`:   {`[][]byte`, `attr:"jpegPhoto"`},
+	}
+
+	for i := 0; i < holder.NumFields(); i++ {
+		f := holder.Field(i)
+		if w, found := want[f.Name()]; found {
+			if got := [2]string{f.Type().String(), holder.Tag(i)}; got != w {
+				t.Errorf("%s failed [%s]: want %v, got %v", t.Name(), f.Name(), w, got)
+				return
+			}
+			delete(want, f.Name())
+		}
+	}
+
+	if len(want) > 0 {
+		t.Errorf("%s failed: fields not generated: %v", t.Name(), want)
+		return
+	}
+
+	if _, err = s.GoCode(GoCodeConfig{ObjectClasses: []string{`bogusClass`}}); err == nil {
+		t.Errorf("%s failed: expected error for unknown class", t.Name())
+		return
+	}
+
+	// isActive would be declared twice.
+	if err = s.ParseRaw([]byte(`attributetype ( 1.3.6.1.4.1.56521.999.88.5 NAME 'isActive'
+	EQUALITY booleanMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.7 )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if _, err = s.GoCode(GoCodeConfig{}); err == nil {
+		t.Errorf("%s failed: expected error for identifier conflict", t.Name())
+	} else if _, err = (Schema{}).GoCode(GoCodeConfig{}); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+	}
+}
@@ -35,6 +35,7 @@ var (
 	join   func([]string, string) string       = strings.Join
 	hasPfx func(string, string) bool           = strings.HasPrefix
 	hasSfx func(string, string) bool           = strings.HasSuffix
+	stridx func(string, string) int            = strings.Index
 	lc     func(string) string                 = strings.ToLower
 	uc     func(string) string                 = strings.ToUpper
 	trim   func(string, string) string         = strings.Trim
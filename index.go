@@ -0,0 +1,332 @@
+package schemax
+
+/*
+index.go implements constant-time lookups of definitions within schema
+collections.
+*/
+
+import (
+	"sync/atomic"
+
+	"github.com/JesseCoretta/go-stackage"
+)
+
+/*
+lookup contains the index of a schema collection, alongside the [Macros]
+instance used to resolve identifiers in macro form (e.g.: "nisSchema.1.0").
+
+Where the collection resides within an overlay (see [Schema.Overlay]), base
+is the corresponding collection of the underlying [Schema], and is searched
+when no match is found locally.
+*/
+type lookup struct {
+	current atomic.Pointer[lookupTable]
+	macros  Macros
+	base    stackage.Stack
+}
+
+/*
+lookupTable maps the lowercased identifiers of the first size members of
+a collection to the respective definitions.
+
+A lookupTable is never altered once published, as readers may consult it
+at any time.  Members pushed since the keys map was built are indexed by
+way of the (smaller) extra map, which is copied upon each change and merged
+into a new keys map once it grows beyond maxExtraKeys.
+*/
+type lookupTable struct {
+	size  int
+	keys  map[string]any
+	extra map[string]any
+}
+
+/*
+maxExtraKeys is the number of keys held within the extra map of a
+lookupTable beyond which a new keys map is built.
+*/
+const maxExtraKeys = 64
+
+func newLookup() *lookup {
+	return &lookup{}
+}
+
+/*
+collectionLookup returns the *lookup instance associated with stk, or nil
+if stk is not an indexed collection.
+*/
+func collectionLookup(stk stackage.Stack) (l *lookup) {
+	if aux := stk.Auxiliary(); aux != nil {
+		l, _ = aux[`lookup`].(*lookup)
+	}
+
+	return
+}
+
+/*
+lookupDefinition returns the definition identified by id within stk, or
+within the base collection of stk if not found therein. The Boolean return
+value indicates whether stk is indexed; if false, callers must fall back to
+a linear search.
+*/
+func lookupDefinition(stk stackage.Stack, id string) (def any, indexed bool) {
+	var l *lookup
+	if l = collectionLookup(stk); l == nil {
+		return
+	}
+
+	indexed = true
+	t := l.table(stk)
+	if def = t.get(lc(id)); def == nil {
+		if noid, ok := l.resolveMacro(id); ok {
+			def = t.get(lc(noid))
+		}
+	}
+
+	if def == nil && !l.base.IsZero() {
+		def, _ = lookupDefinition(l.base, id)
+	}
+
+	return
+}
+
+/*
+table returns the current lookupTable of the receiver, bringing it up to
+date with stk beforehand if necessary.
+
+Members pushed since the last call are indexed incrementally, and the table
+is rebuilt in full if members were removed.  In either case a new table is
+published in place of the current one, which remains intact for the benefit
+of concurrent readers.
+
+Changes to the identifying values of indexed members are handled by way of
+rekey.
+*/
+func (r *lookup) table(stk stackage.Stack) (t *lookupTable) {
+	L := stk.Len()
+
+	curr := r.current.Load()
+	if curr != nil && curr.size == L {
+		return curr
+	}
+
+	if curr == nil || curr.size > L {
+		t = &lookupTable{keys: make(map[string]any, L*2)}
+	} else {
+		t = curr.grow()
+	}
+
+	for ; t.size < L; t.size++ {
+		slice, _ := stk.Index(t.size)
+		for _, key := range lookupKeys(slice) {
+			t.add(key, slice)
+		}
+	}
+
+	if len(t.extra) > maxExtraKeys {
+		t = t.merge()
+	}
+	r.current.CompareAndSwap(curr, t)
+
+	return
+}
+
+/*
+grow returns a copy of the receiver instance, suitable for the indexing
+of additional members.  The keys map is shared.
+*/
+func (r *lookupTable) grow() *lookupTable {
+	extra := make(map[string]any, len(r.extra)+8)
+	for k, v := range r.extra {
+		extra[k] = v
+	}
+
+	return &lookupTable{size: r.size, keys: r.keys, extra: extra}
+}
+
+/*
+merge returns a copy of the receiver instance in which the contents of the
+extra map have been merged into a new keys map.
+*/
+func (r *lookupTable) merge() *lookupTable {
+	keys := make(map[string]any, len(r.keys)+len(r.extra))
+	for k, v := range r.keys {
+		keys[k] = v
+	}
+	for k, v := range r.extra {
+		keys[k] = v
+	}
+
+	return &lookupTable{size: r.size, keys: keys}
+}
+
+/*
+add indexes def by key, unless key is already present.  This must only be
+called upon a table not yet published.
+*/
+func (r *lookupTable) add(key string, def any) {
+	// first match prevails, as with a
+	// linear search.
+	if r.get(key) != nil {
+		return
+	}
+
+	if r.extra == nil {
+		r.keys[key] = def
+	} else {
+		r.extra[key] = def
+	}
+}
+
+/*
+get returns the definition indexed by key, or nil if not found.
+*/
+func (r *lookupTable) get(key string) (def any) {
+	if def = r.keys[key]; def == nil {
+		def = r.extra[key]
+	}
+
+	return
+}
+
+/*
+invalidate discards the current lookupTable of the receiver.
+*/
+func (r *lookup) invalidate() {
+	if r != nil {
+		r.current.Store(nil)
+	}
+}
+
+/*
+rekey prepares for a change to the identifying values of x -- its numeric
+OID, names, rule ID and so on.  Should x be indexed within the appropriate
+collection of s, the returned closure discards that index, which must be
+rebuilt upon the next lookup.  Otherwise the returned closure does nothing.
+
+The closure is meant to be deferred by the method responsible for the
+change, e.g.:
+
+	defer rekey(r.schema, AttributeType{r})()
+*/
+func rekey(s Schema, x Definition) func() {
+	var l *lookup
+	if defs := s.collection(x.Type()); defs != nil {
+		l = collectionLookup(defs.cast())
+	}
+
+	if l != nil && l.current.Load().holds(x) {
+		return l.invalidate
+	}
+
+	return func() {}
+}
+
+/*
+member returns a Boolean value indicative of x residing within the
+appropriate collection of s.
+*/
+func member(s Schema, x Definition) (is bool) {
+	if defs := s.collection(x.Type()); defs != nil {
+		if l := collectionLookup(defs.cast()); l != nil {
+			is = l.table(defs.cast()).holds(x)
+		}
+	}
+
+	return
+}
+
+/*
+holds returns a Boolean value indicative of x being indexed within the
+receiver instance.
+*/
+func (r *lookupTable) holds(x Definition) bool {
+	if r != nil {
+		ptr := definitionPointer(x)
+		for _, key := range lookupKeys(x) {
+			if def, _ := r.get(key).(Definition); def != nil && definitionPointer(def) == ptr {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/*
+resolveMacro returns the numeric OID form of id should id bear a macro
+prefix known to the receiver's [Macros] instance, e.g.: "nisSchema.1.0"
+or "nisSchema:1.0".
+*/
+func (r *lookup) resolveMacro(id string) (noid string, ok bool) {
+	if r.macros.macros == nil {
+		return
+	}
+
+	for i, c := range id {
+		if c == '.' || c == ':' {
+			if i > 0 && isAlpha(rune(id[0])) {
+				var pfx string
+				if pfx, ok = r.macros.Resolve(id[:i]); ok {
+					noid = pfx + `.` + id[i+1:]
+				}
+			}
+			break
+		}
+	}
+
+	return
+}
+
+/*
+lookupKeys returns the lowercased identifying values of x.
+*/
+func lookupKeys(x any) (keys []string) {
+	if def, ok := x.(Definition); !ok || def.IsZero() {
+		return
+	}
+
+	var names QuotedDescriptorList
+	switch tv := x.(type) {
+	case LDAPSyntax:
+		keys = append(keys, tv.NumericOID())
+		if desc := repAll(tv.lDAPSyntax.Desc, ` `, ``); len(desc) > 0 {
+			keys = append(keys, desc)
+		}
+	case DITStructureRule:
+		keys = append(keys, uitoa(tv.RuleID()))
+		names = tv.dITStructureRule.Name
+	case Definition:
+		keys = append(keys, tv.NumericOID())
+		names = tv.Names()
+	}
+
+	for i := 0; i < names.len(); i++ {
+		keys = append(keys, names.index(i))
+	}
+
+	for i := 0; i < len(keys); i++ {
+		keys[i] = lc(keys[i])
+	}
+
+	return
+}
+
+/*
+setLookupMacros associates the input [Macros] instance with the index of
+stk, if indexed.
+*/
+func setLookupMacros(stk stackage.Stack, m Macros) {
+	if l := collectionLookup(stk); l != nil {
+		l.macros = m
+	}
+}
+
+/*
+setLookupBase associates the input base collection with the index of stk,
+if indexed.
+*/
+func setLookupBase(stk, base stackage.Stack) {
+	if l := collectionLookup(stk); l != nil {
+		l.base = base
+	}
+}
@@ -0,0 +1,162 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the retrieval of an [AttributeType] using its
+numeric OID in macro form.
+*/
+func ExampleAttributeTypes_Get_byMacro() {
+	sch := NewSchema()
+	fmt.Println(sch.AttributeTypes().Get(`nisSchema.1.0`).Name())
+	// Output: uidNumber
+}
+
+func TestSchema_lookup(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+
+	for id, want := range map[string]string{
+		`CN`:                  `cn`,
+		`2.5.4.3`:             `cn`,
+		`commonName`:          `cn`,
+		`nisSchema.1.0`:       `uidNumber`,
+		`nisschema:1.1`:       `gidNumber`,
+		`bogusMacro.1.0`:      ``,
+		`1.3.6.1.4.1.56521.0`: ``,
+	} {
+		if got := sch.AttributeTypes().Get(id).Name(); got != want {
+			t.Errorf("%s failed: %s: want '%s', got '%s'", t.Name(), id, want, got)
+			return
+		}
+	}
+
+	if ls := sch.LDAPSyntaxes().Get(`directorystring`); ls.NumericOID() != `1.3.6.1.4.1.1466.115.121.1.15` {
+		t.Errorf("%s failed: syntax lookup by description", t.Name())
+		return
+	}
+
+	// names assigned following a push are honored
+	cn := sch.AttributeTypes().Get(`cn`)
+	cn.SetName(`lookupAlias`)
+	if !sch.AttributeTypes().Contains(`lookupAlias`) {
+		t.Errorf("%s failed: late name assignment not indexed", t.Name())
+		return
+	}
+
+	// only the owning collection is re-keyed, and only for members
+	ocs := collectionLookup(sch.ObjectClasses().cast())
+	ocs.table(sch.ObjectClasses().cast())
+	ats := collectionLookup(sch.AttributeTypes().cast())
+	ats.table(sch.AttributeTypes().cast())
+	sch.NewAttributeType().SetNumericOID(`1.3.6.1.4.1.56521.999.55.2`).SetName(`notPushed`)
+	cn.SetName(`yetAnotherAlias`)
+	if ocs.current.Load() == nil {
+		t.Errorf("%s failed: unrelated index discarded", t.Name())
+		return
+	} else if !sch.AttributeTypes().Contains(`yetAnotherAlias`) {
+		t.Errorf("%s failed: owning index not re-keyed", t.Name())
+		return
+	}
+	ats.table(sch.AttributeTypes().cast())
+	before := ats.current.Load()
+	sch.NewAttributeType().SetNumericOID(`1.3.6.1.4.1.56521.999.55.3`).SetName(`stillNotPushed`)
+	if ats.current.Load() != before {
+		t.Errorf("%s failed: index discarded for non-member", t.Name())
+		return
+	}
+
+	// replacements are honored
+	gon := sch.ObjectClasses().Get(`groupOfNames`)
+	sch.Replace(sch.NewObjectClass().
+		SetNumericOID(gon.NumericOID()).
+		SetName(`groupOfMembers`).
+		SetKind(gon.Kind()).
+		SetSuperClass(`top`).
+		SetMust(`cn`).
+		SetMay(`member`).
+		SetStringer())
+	if sch.ObjectClasses().Contains(`groupOfNames`) || !sch.ObjectClasses().Contains(`groupOfMembers`) {
+		t.Errorf("%s failed: replacement not indexed", t.Name())
+		return
+	}
+
+	// removals followed by pushes are honored
+	tx := sch.Begin()
+	tx.Remove(tx.Schema().ObjectClasses().Get(`dcObject`))
+	tx.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.55.1
+		NAME 'lookupClass'
+		SUP top AUXILIARY
+		MAY cn )`)
+	if stage := tx.Schema(); stage.ObjectClasses().Contains(`dcObject`) ||
+		!stage.ObjectClasses().Contains(`lookupClass`) {
+		t.Errorf("%s failed: staged index not maintained", t.Name())
+		return
+	}
+	tx.Rollback()
+
+	// structure rules are identified by rule ID or name
+	ds := mySchema.DITStructureRules().Index(0)
+	for _, id := range []any{int(ds.RuleID()), ds.RuleID(), ds.ID(), uc(ds.Name())} {
+		if got := mySchema.DITStructureRules().Get(id); got.dITStructureRule != ds.dITStructureRule {
+			t.Errorf("%s failed: %v: structure rule not found", t.Name(), id)
+			return
+		}
+	}
+
+	// snapshots retain their index
+	snap := sch.Snapshot()
+	cn.SetName(`anotherAlias`)
+	if !snap.AttributeTypes().Contains(`lookupAlias`) || snap.AttributeTypes().Contains(`anotherAlias`) {
+		t.Errorf("%s failed: unexpected snapshot lookup results", t.Name())
+	}
+}
+
+/*
+lookupBenchSchema returns a Schema bearing over 2000 [AttributeType]
+instances, alongside a non-indexed [AttributeTypes] instance bearing
+the same definitions.
+*/
+func lookupBenchSchema(b *testing.B) (sch Schema, list AttributeTypes) {
+	sch = NewSchema()
+	for i := 0; sch.AttributeTypes().Len() < 2000; i++ {
+		if err := sch.ParseAttributeType(fmt.Sprintf(`( 1.3.6.1.4.1.56521.999.44.%d
+			NAME 'benchAttr%d'
+			SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`, i, i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	list = NewAttributeTypeOIDList()
+	for i := 0; i < sch.AttributeTypes().Len(); i++ {
+		list.cast().Push(sch.AttributeTypes().Index(i))
+	}
+
+	return
+}
+
+func BenchmarkAttributeTypes_Get(b *testing.B) {
+	sch, _ := lookupBenchSchema(b)
+	last := sch.AttributeTypes().Index(sch.AttributeTypes().Len() - 1).Name()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if sch.AttributeTypes().Get(last).IsZero() {
+			b.Fatal("lookup failed")
+		}
+	}
+}
+
+func BenchmarkAttributeTypes_Get_linear(b *testing.B) {
+	_, list := lookupBenchSchema(b)
+	last := list.Index(list.Len() - 1).Name()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if list.Get(last).IsZero() {
+			b.Fatal("lookup failed")
+		}
+	}
+}
@@ -0,0 +1,1231 @@
+package schemax
+
+/*
+json.go implements the JSON document format for definitions and Schema
+instances.
+*/
+
+import (
+	"encoding/json"
+
+	"github.com/JesseCoretta/go-antlr4512"
+)
+
+/*
+jsonDefinition is the JSON document form of any [Definition].  Only those
+fields applicable to the type of [Definition] at hand are populated; empty
+fields are omitted.
+
+References to other definitions are expressed using their principal
+identifiers (see the various OID methods), with the exception of syntaxes,
+which are always referenced by numeric OID, and [DITStructureRule] super
+rules, which are referenced by rule ID.
+*/
+type jsonDefinition struct {
+	OID                string          `json:"oid,omitempty"`
+	RuleID             *uint           `json:"ruleID,omitempty"`
+	Names              []string        `json:"names,omitempty"`
+	Description        string          `json:"description,omitempty"`
+	Obsolete           bool            `json:"obsolete,omitempty"`
+	SuperType          string          `json:"superType,omitempty"`
+	Equality           string          `json:"equality,omitempty"`
+	Ordering           string          `json:"ordering,omitempty"`
+	Substring          string          `json:"substring,omitempty"`
+	Syntax             string          `json:"syntax,omitempty"`
+	SyntaxLength       uint            `json:"syntaxLength,omitempty"`
+	SingleValue        bool            `json:"singleValue,omitempty"`
+	Collective         bool            `json:"collective,omitempty"`
+	NoUserModification bool            `json:"noUserModification,omitempty"`
+	Usage              string          `json:"usage,omitempty"`
+	SuperClasses       []string        `json:"superClasses,omitempty"`
+	Kind               string          `json:"kind,omitempty"`
+	Aux                []string        `json:"aux,omitempty"`
+	Must               []string        `json:"must,omitempty"`
+	May                []string        `json:"may,omitempty"`
+	Not                []string        `json:"not,omitempty"`
+	Applies            []string        `json:"applies,omitempty"`
+	StructuralClass    string          `json:"structuralClass,omitempty"`
+	Form               string          `json:"form,omitempty"`
+	SuperRules         []uint          `json:"superRules,omitempty"`
+	Extensions         []jsonExtension `json:"extensions,omitempty"`
+}
+
+/*
+jsonExtension is the JSON document form of an [Extension].  Extensions are
+expressed as an ordered array of such documents.
+*/
+type jsonExtension struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+/*
+jsonSchema is the JSON document form of a [Schema].  Each collection is
+keyed by the string returned by its Type method.
+*/
+type jsonSchema struct {
+	DN                string            `json:"dn,omitempty"`
+	Options           []string          `json:"options,omitempty"`
+	Macros            map[string]string `json:"macros,omitempty"`
+	LDAPSyntaxes      []jsonDefinition  `json:"ldapSyntaxes,omitempty"`
+	MatchingRules     []jsonDefinition  `json:"matchingRules,omitempty"`
+	AttributeTypes    []jsonDefinition  `json:"attributeTypes,omitempty"`
+	MatchingRuleUses  []jsonDefinition  `json:"matchingRuleUses,omitempty"`
+	ObjectClasses     []jsonDefinition  `json:"objectClasses,omitempty"`
+	DITContentRules   []jsonDefinition  `json:"dITContentRules,omitempty"`
+	NameForms         []jsonDefinition  `json:"nameForms,omitempty"`
+	DITStructureRules []jsonDefinition  `json:"dITStructureRules,omitempty"`
+}
+
+/*
+jsonOptions contains the names by which [Option] constants are expressed
+within JSON documents.
+*/
+var jsonOptions map[string]Option = map[string]Option{
+	`HangingIndents`: HangingIndents,
+	`SortExtensions`: SortExtensions,
+	`SortLists`:      SortLists,
+	`AllowOverride`:  AllowOverride,
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the following members, each of which is
+omitted if empty:
+
+  - "dn", a string containing the DN of the receiver
+  - "options", an array of [Option] names, e.g.: "HangingIndents"
+  - "macros", an object mapping each macro name to its numeric OID
+  - "ldapSyntaxes", "matchingRules", "attributeTypes", "matchingRuleUses",
+    "objectClasses", "dITContentRules", "nameForms" and "dITStructureRules",
+    each an array of documents produced by the MarshalJSON method of the
+    respective [Definition] type
+
+If the receiver is an overlay (see [Schema.Overlay]), the definitions of
+its base are not included.
+
+See [Schema.UnmarshalJSON] for the inverse operation.
+*/
+func (r Schema) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return []byte(`null`), nil
+	}
+
+	doc := jsonSchema{
+		DN:                r.DN(),
+		Macros:            r.Macros().macros,
+		LDAPSyntaxes:      r.LDAPSyntaxes().json(),
+		MatchingRules:     r.MatchingRules().json(),
+		AttributeTypes:    r.AttributeTypes().json(),
+		MatchingRuleUses:  r.MatchingRuleUses().json(),
+		ObjectClasses:     r.ObjectClasses().json(),
+		DITContentRules:   r.DITContentRules().json(),
+		NameForms:         r.NameForms().json(),
+		DITStructureRules: r.DITStructureRules().json(),
+	}
+
+	for _, name := range []string{`HangingIndents`, `SortExtensions`, `SortLists`, `AllowOverride`} {
+		if r.Options().Positive(jsonOptions[name]) {
+			doc.Options = append(doc.Options, name)
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[Schema.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+Upon success, the receiver is replaced with a new instance of [Schema].
+Definitions are marshaled and linked just as they would be if parsed, and
+are subject to the same compliancy checks.  As such, any [Definition]
+must appear after those upon which it depends, and duplicate definitions
+are disregarded.
+
+If the receiver is an overlay (see [Schema.Overlay]) at the time this
+method is called, the new instance is an overlay of the same base.
+*/
+func (r *Schema) UnmarshalJSON(data []byte) (err error) {
+	if r == nil {
+		err = ErrNilReceiver
+		return
+	}
+
+	var doc jsonSchema
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return
+	}
+
+	opts := newOpts()
+	for _, name := range doc.Options {
+		opt, found := jsonOptions[name]
+		if !found {
+			err = mkerr(ErrInvalidInput.Error() + `: unknown option ` + name)
+			return
+		}
+		opts.Shift(opt)
+	}
+
+	var s Schema
+	if base := r.Base(); !base.IsZero() {
+		s = base.Overlay()
+	} else {
+		s = initSchema()
+	}
+
+	s.cast().Auxiliary()[`options`] = opts
+	s.cast().SetID(doc.DN)
+	for k, v := range doc.Macros {
+		s.Macros().macros[k] = v
+	}
+
+	for _, funk := range []func() error{
+		func() error { return s.LDAPSyntaxes().unmarshalJSON(s, doc.LDAPSyntaxes) },
+		func() error { return s.MatchingRules().unmarshalJSON(s, doc.MatchingRules) },
+		func() error { return s.AttributeTypes().unmarshalJSON(s, doc.AttributeTypes) },
+		func() error { return s.MatchingRuleUses().unmarshalJSON(s, doc.MatchingRuleUses) },
+		func() error { return s.ObjectClasses().unmarshalJSON(s, doc.ObjectClasses) },
+		func() error { return s.DITContentRules().unmarshalJSON(s, doc.DITContentRules) },
+		func() error { return s.NameForms().unmarshalJSON(s, doc.NameForms) },
+		func() error { return s.DITStructureRules().unmarshalJSON(s, doc.DITStructureRules) },
+	} {
+		if err = funk(); err != nil {
+			return
+		}
+	}
+
+	*r = s
+
+	return
+}
+
+/*
+jsonExtensions returns the JSON document form of x.
+*/
+func jsonExtensions(x Extensions) (e []jsonExtension) {
+	for i := 0; i < x.len(); i++ {
+		ext := x.index(i)
+		e = append(e, jsonExtension{
+			Name:   ext.XString,
+			Values: ext.Values.List(),
+		})
+	}
+
+	return
+}
+
+/*
+setJSONExtensions assigns the extensions described by e to x, in order.
+*/
+func setJSONExtensions(x Extensions, e []jsonExtension) {
+	for i := 0; i < len(e); i++ {
+		x.Set(e[i].Name, e[i].Values...)
+	}
+}
+
+/*
+jsonPrepare returns the decoded JSON document data alongside an error
+following an attempt to verify that def may be populated by way of its
+UnmarshalJSON method.
+*/
+func jsonPrepare(def Definition, data []byte) (doc jsonDefinition, err error) {
+	if def.IsZero() {
+		err = ErrNilReceiver
+	} else if def.Schema().IsZero() {
+		err = ErrNilSchemaRef
+	} else {
+		err = json.Unmarshal(data, &doc)
+	}
+
+	return
+}
+
+/*
+jsonPrepareCollection returns the [Schema] of defs and the decoded JSON
+document data alongside an error following an attempt to verify that defs
+may be populated by way of its UnmarshalJSON method.
+*/
+func jsonPrepareCollection(defs Definitions, data []byte) (sch Schema, docs []jsonDefinition, err error) {
+	if defs.IsZero() {
+		err = ErrNilReceiver
+	} else if sch = collectionSchema(defs.cast()); sch.IsZero() {
+		err = ErrNilSchemaRef
+	} else if sch.IsFrozen() {
+		err = mkerr(ErrInvalidInput.Error() + `: Schema is frozen`)
+	} else {
+		err = json.Unmarshal(data, &docs)
+	}
+
+	return
+}
+
+/*
+jsonMarshal returns the JSON document form of the input value, or a null
+JSON value if zero is true.
+*/
+func jsonMarshal(v any, zero bool) ([]byte, error) {
+	if zero {
+		return []byte(`null`), nil
+	}
+
+	return json.Marshal(v)
+}
+
+/*
+jsonNotUnique returns an error indicating that the [Definition] described
+by id is already present within the relevant [Schema].
+*/
+func jsonNotUnique(typ, id string) error {
+	return mkerr(ErrNotUnique.Error() + ": " + typ + `, ` + id)
+}
+
+func (r jsonDefinition) lDAPSyntax() antlr4512.LDAPSyntax {
+	return antlr4512.LDAPSyntax{
+		OID:  r.OID,
+		Desc: r.Description,
+	}
+}
+
+func (r jsonDefinition) matchingRule() antlr4512.MatchingRule {
+	return antlr4512.MatchingRule{
+		OID:      r.OID,
+		Name:     r.Names,
+		Desc:     r.Description,
+		Obsolete: r.Obsolete,
+		Syntax:   r.Syntax,
+	}
+}
+
+func (r jsonDefinition) attributeType() antlr4512.AttributeType {
+	return antlr4512.AttributeType{
+		OID:        r.OID,
+		Name:       r.Names,
+		Desc:       r.Description,
+		Obsolete:   r.Obsolete,
+		SuperType:  r.SuperType,
+		Equality:   r.Equality,
+		Ordering:   r.Ordering,
+		Substring:  r.Substring,
+		Syntax:     r.Syntax,
+		MUB:        r.SyntaxLength,
+		Single:     r.SingleValue,
+		Collective: r.Collective,
+		Immutable:  r.NoUserModification,
+		Usage:      r.Usage,
+	}
+}
+
+func (r jsonDefinition) matchingRuleUse() antlr4512.MatchingRuleUse {
+	return antlr4512.MatchingRuleUse{
+		OID:      r.OID,
+		Name:     r.Names,
+		Desc:     r.Description,
+		Obsolete: r.Obsolete,
+		Applies:  r.Applies,
+	}
+}
+
+func (r jsonDefinition) objectClass() antlr4512.ObjectClass {
+	return antlr4512.ObjectClass{
+		OID:          r.OID,
+		Name:         r.Names,
+		Desc:         r.Description,
+		Obsolete:     r.Obsolete,
+		SuperClasses: r.SuperClasses,
+		Kind:         r.Kind,
+		Must:         r.Must,
+		May:          r.May,
+	}
+}
+
+func (r jsonDefinition) dITContentRule() antlr4512.DITContentRule {
+	return antlr4512.DITContentRule{
+		OID:      r.OID,
+		Name:     r.Names,
+		Desc:     r.Description,
+		Obsolete: r.Obsolete,
+		Aux:      r.Aux,
+		Must:     r.Must,
+		May:      r.May,
+		Not:      r.Not,
+	}
+}
+
+func (r jsonDefinition) nameForm() antlr4512.NameForm {
+	return antlr4512.NameForm{
+		OID:      r.OID,
+		Name:     r.Names,
+		Desc:     r.Description,
+		Obsolete: r.Obsolete,
+		OC:       r.StructuralClass,
+		Must:     r.Must,
+		May:      r.May,
+	}
+}
+
+func (r jsonDefinition) dITStructureRule() (ds antlr4512.DITStructureRule) {
+	ds = antlr4512.DITStructureRule{
+		Name:     r.Names,
+		Desc:     r.Description,
+		Obsolete: r.Obsolete,
+		Form:     r.Form,
+	}
+
+	if r.RuleID != nil {
+		ds.ID = uitoa(*r.RuleID)
+	}
+
+	for i := 0; i < len(r.SuperRules); i++ {
+		ds.SuperRules = append(ds.SuperRules, uitoa(r.SuperRules[i]))
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "description" and "extensions"
+members.  Extensions are expressed as an ordered array of objects, each
+bearing a "name" string and a "values" array of strings.
+*/
+func (r LDAPSyntax) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r LDAPSyntax) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:         r.NumericOID(),
+			Description: r.Description(),
+			Extensions:  jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[LDAPSyntax.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+As with [LDAPSyntax.Parse], the receiver MUST possess a [Schema] reference,
+and is NOT automatically pushed into any [LDAPSyntaxes] stack.
+*/
+func (r LDAPSyntax) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def LDAPSyntax
+		if def, err = r.lDAPSyntax.schema.marshalLS(doc.lDAPSyntax()); err == nil {
+			if def.IsZero() {
+				return jsonNotUnique(r.Type(), doc.OID)
+			}
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.lDAPSyntax.OID = def.NumericOID()
+			r.lDAPSyntax.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "syntax" and "extensions" members.
+*/
+func (r MatchingRule) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r MatchingRule) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:         r.NumericOID(),
+			Names:       r.Names().List(),
+			Description: r.Description(),
+			Obsolete:    r.Obsolete(),
+			Syntax:      r.Syntax().NumericOID(),
+			Extensions:  jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[MatchingRule.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+As with [MatchingRule.Parse], the receiver MUST possess a [Schema] reference,
+and is NOT automatically pushed into any [MatchingRules] stack.
+*/
+func (r MatchingRule) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def MatchingRule
+		if def, err = r.matchingRule.schema.marshalMR(doc.matchingRule()); err == nil {
+			if def.IsZero() {
+				return jsonNotUnique(r.Type(), doc.OID)
+			}
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.matchingRule.OID = def.NumericOID()
+			r.matchingRule.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "superType", "equality", "ordering", "substring", "syntax",
+"syntaxLength", "singleValue", "collective", "noUserModification", "usage"
+and "extensions" members.
+*/
+func (r AttributeType) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r AttributeType) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:                r.NumericOID(),
+			Names:              r.Names().List(),
+			Description:        r.Description(),
+			Obsolete:           r.Obsolete(),
+			SuperType:          r.SuperType().OID(),
+			Equality:           r.Equality().OID(),
+			Ordering:           r.Ordering().OID(),
+			Substring:          r.Substring().OID(),
+			Syntax:             r.Syntax().NumericOID(),
+			SyntaxLength:       r.attributeType.MUB,
+			SingleValue:        r.SingleValue(),
+			Collective:         r.Collective(),
+			NoUserModification: r.NoUserModification(),
+			Usage:              r.Usage(),
+			Extensions:         jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[AttributeType.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+As with [AttributeType.Parse], the receiver MUST possess a [Schema] reference,
+and is NOT automatically pushed into any [AttributeTypes] stack.
+*/
+func (r AttributeType) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def AttributeType
+		if def, err = r.attributeType.schema.marshalAT(doc.attributeType()); err == nil {
+			if def.IsZero() {
+				return jsonNotUnique(r.Type(), doc.OID)
+			}
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.attributeType.OID = def.NumericOID()
+			r.attributeType.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "applies" and "extensions" members, wherein "oid" is the
+numeric OID of the relevant [MatchingRule].
+*/
+func (r MatchingRuleUse) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r MatchingRuleUse) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:         r.NumericOID(),
+			Names:       r.Names().List(),
+			Description: r.Description(),
+			Obsolete:    r.Obsolete(),
+			Applies:     jsonAttributeTypes(r.Applies()),
+			Extensions:  jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[MatchingRuleUse.MarshalJSON] method, thereby implementing the
+[json.Unmarshaler] interface.
+
+The receiver MUST possess a [Schema] reference, and is NOT automatically
+pushed into any [MatchingRuleUses] stack.
+*/
+func (r MatchingRuleUse) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def MatchingRuleUse
+		if def, err = r.matchingRuleUse.schema.marshalMU(doc.matchingRuleUse()); err == nil {
+			if def.IsZero() {
+				return mkerr(ErrMatchingRuleNotFound.Error() + `: ` + doc.OID)
+			}
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.matchingRuleUse.OID = def.matchingRuleUse.OID
+			r.matchingRuleUse.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "superClasses", "kind", "must", "may" and "extensions" members.
+The "kind" member is one of "STRUCTURAL", "AUXILIARY" or "ABSTRACT".
+*/
+func (r ObjectClass) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r ObjectClass) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:          r.NumericOID(),
+			Names:        r.Names().List(),
+			Description:  r.Description(),
+			Obsolete:     r.Obsolete(),
+			SuperClasses: jsonObjectClasses(r.SuperClasses()),
+			Kind:         `STRUCTURAL`,
+			Must:         jsonAttributeTypes(r.Must()),
+			May:          jsonAttributeTypes(r.May()),
+			Extensions:   jsonExtensions(r.Extensions()),
+		}
+
+		switch r.Kind() {
+		case AbstractKind:
+			doc.Kind = `ABSTRACT`
+		case AuxiliaryKind:
+			doc.Kind = `AUXILIARY`
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[ObjectClass.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+As with [ObjectClass.Parse], the receiver MUST possess a [Schema] reference,
+and is NOT automatically pushed into any [ObjectClasses] stack.
+*/
+func (r ObjectClass) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def ObjectClass
+		if def, err = r.objectClass.schema.marshalOC(doc.objectClass()); err == nil {
+			if def.IsZero() {
+				return jsonNotUnique(r.Type(), doc.OID)
+			}
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.objectClass.OID = def.NumericOID()
+			r.objectClass.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "aux", "must", "may", "not" and "extensions" members, wherein
+"oid" is the numeric OID of the relevant STRUCTURAL [ObjectClass].
+*/
+func (r DITContentRule) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r DITContentRule) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:         r.NumericOID(),
+			Names:       r.Names().List(),
+			Description: r.Description(),
+			Obsolete:    r.Obsolete(),
+			Aux:         jsonObjectClasses(r.Aux()),
+			Must:        jsonAttributeTypes(r.Must()),
+			May:         jsonAttributeTypes(r.May()),
+			Not:         jsonAttributeTypes(r.Not()),
+			Extensions:  jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[DITContentRule.MarshalJSON] method, thereby implementing the
+[json.Unmarshaler] interface.
+
+As with [DITContentRule.Parse], the receiver MUST possess a [Schema]
+reference, and is NOT automatically pushed into any [DITContentRules]
+stack.
+*/
+func (r DITContentRule) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def DITContentRule
+		if def, err = r.dITContentRule.schema.marshalDC(doc.dITContentRule()); err == nil {
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.dITContentRule.OID = def.dITContentRule.OID
+			r.dITContentRule.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "oid", "names", "description",
+"obsolete", "structuralClass", "must", "may" and "extensions" members.
+*/
+func (r NameForm) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r NameForm) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		doc = jsonDefinition{
+			OID:             r.NumericOID(),
+			Names:           r.Names().List(),
+			Description:     r.Description(),
+			Obsolete:        r.Obsolete(),
+			StructuralClass: r.OC().OID(),
+			Must:            jsonAttributeTypes(r.Must()),
+			May:             jsonAttributeTypes(r.May()),
+			Extensions:      jsonExtensions(r.Extensions()),
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[NameForm.MarshalJSON] method, thereby implementing the [json.Unmarshaler]
+interface.
+
+As with [NameForm.Parse], the receiver MUST possess a [Schema] reference,
+and is NOT automatically pushed into any [NameForms] stack.
+*/
+func (r NameForm) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def NameForm
+		if def, err = r.nameForm.schema.marshalNF(doc.nameForm()); err == nil {
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.nameForm.OID = def.nameForm.OID
+			r.nameForm.replace(def)
+		}
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON document alongside an
+error, thereby implementing the [json.Marshaler] interface.
+
+The document is an object bearing the "ruleID", "names", "description",
+"obsolete", "form", "superRules" and "extensions" members.  The "ruleID"
+member, as well as each of the "superRules" values, is a non-negative
+integer.
+*/
+func (r DITStructureRule) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r DITStructureRule) json() (doc jsonDefinition) {
+	if !r.IsZero() {
+		id := r.RuleID()
+		doc = jsonDefinition{
+			RuleID:      &id,
+			Names:       r.Names().List(),
+			Description: r.Description(),
+			Obsolete:    r.Obsolete(),
+			Form:        r.Form().OID(),
+			Extensions:  jsonExtensions(r.Extensions()),
+		}
+
+		for i := 0; i < r.SuperRules().Len(); i++ {
+			doc.SuperRules = append(doc.SuperRules, r.SuperRules().Index(i).RuleID())
+		}
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to populate the
+receiver instance using data, a JSON document as produced by the
+[DITStructureRule.MarshalJSON] method, thereby implementing the
+[json.Unmarshaler] interface.
+
+As with [DITStructureRule.Parse], the receiver MUST possess a [Schema]
+reference, and is NOT automatically pushed into any [DITStructureRules]
+stack.
+*/
+func (r DITStructureRule) UnmarshalJSON(data []byte) error {
+	doc, err := jsonPrepare(r, data)
+	if err == nil {
+		var def DITStructureRule
+		if def, err = r.dITStructureRule.schema.marshalDS(doc.dITStructureRule()); err == nil {
+			setJSONExtensions(def.Extensions(), doc.Extensions)
+			r.dITStructureRule.ID = def.RuleID()
+			r.dITStructureRule.replace(def)
+		}
+	}
+
+	return err
+}
+
+func jsonAttributeTypes(x AttributeTypes) (ids []string) {
+	for i := 0; i < x.Len(); i++ {
+		ids = append(ids, x.Index(i).OID())
+	}
+
+	return
+}
+
+func jsonObjectClasses(x ObjectClasses) (ids []string) {
+	for i := 0; i < x.Len(); i++ {
+		ids = append(ids, x.Index(i).OID())
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [LDAPSyntax.MarshalJSON] method, alongside an error.
+*/
+func (r LDAPSyntaxes) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r LDAPSyntaxes) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [LDAPSyntaxes.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.LDAPSyntaxes].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r LDAPSyntaxes) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r LDAPSyntaxes) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def LDAPSyntax
+		if def, err = sch.marshalLS(docs[i].lDAPSyntax()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [MatchingRule.MarshalJSON] method, alongside an error.
+*/
+func (r MatchingRules) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r MatchingRules) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [MatchingRules.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.MatchingRules].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r MatchingRules) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r MatchingRules) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def MatchingRule
+		if def, err = sch.marshalMR(docs[i].matchingRule()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [AttributeType.MarshalJSON] method, alongside an error.
+*/
+func (r AttributeTypes) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r AttributeTypes) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [AttributeTypes.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.AttributeTypes].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r AttributeTypes) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r AttributeTypes) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def AttributeType
+		if def, err = sch.marshalAT(docs[i].attributeType()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [MatchingRuleUse.MarshalJSON] method, alongside an error.
+*/
+func (r MatchingRuleUses) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r MatchingRuleUses) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [MatchingRuleUses.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.MatchingRuleUses].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r MatchingRuleUses) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r MatchingRuleUses) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def MatchingRuleUse
+		if def, err = sch.marshalMU(docs[i].matchingRuleUse()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [ObjectClass.MarshalJSON] method, alongside an error.
+*/
+func (r ObjectClasses) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r ObjectClasses) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [ObjectClasses.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.ObjectClasses].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r ObjectClasses) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r ObjectClasses) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def ObjectClass
+		if def, err = sch.marshalOC(docs[i].objectClass()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [DITContentRule.MarshalJSON] method, alongside an error.
+*/
+func (r DITContentRules) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r DITContentRules) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [DITContentRules.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.DITContentRules].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r DITContentRules) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r DITContentRules) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def DITContentRule
+		if def, err = sch.marshalDC(docs[i].dITContentRule()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [NameForm.MarshalJSON] method, alongside an error.
+*/
+func (r NameForms) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r NameForms) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [NameForms.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.NameForms].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r NameForms) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r NameForms) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def NameForm
+		if def, err = sch.marshalNF(docs[i].nameForm()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the receiver instance as a JSON array of documents
+produced by the [DITStructureRule.MarshalJSON] method, alongside an error.
+*/
+func (r DITStructureRules) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(r.json(), r.IsZero())
+}
+
+func (r DITStructureRules) json() (docs []jsonDefinition) {
+	for i := 0; i < r.Len(); i++ {
+		docs = append(docs, r.Index(i).json())
+	}
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to push the definitions
+described by data, a JSON array as produced by [DITStructureRules.MarshalJSON],
+into the receiver instance, thereby implementing the [json.Unmarshaler]
+interface.
+
+The receiver MUST be a collection of a [Schema], such as that returned
+by [Schema.DITStructureRules].  As with [Schema.UnmarshalJSON], definitions
+are subject to the usual compliancy checks, and duplicate definitions
+are disregarded.
+*/
+func (r DITStructureRules) UnmarshalJSON(data []byte) error {
+	sch, docs, err := jsonPrepareCollection(r, data)
+	if err == nil {
+		err = r.unmarshalJSON(sch, docs)
+	}
+
+	return err
+}
+
+func (r DITStructureRules) unmarshalJSON(sch Schema, docs []jsonDefinition) (err error) {
+	for i := 0; i < len(docs) && err == nil; i++ {
+		var def DITStructureRule
+		if def, err = sch.marshalDS(docs[i].dITStructureRule()); err == nil && !def.IsZero() {
+			setJSONExtensions(def.Extensions(), docs[i].Extensions)
+			err = r.push(def)
+		}
+	}
+
+	return
+}
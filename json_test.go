@@ -0,0 +1,148 @@
+package schemax
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/*
+This example demonstrates the JSON document form of an [AttributeType].
+*/
+func ExampleAttributeType_MarshalJSON() {
+	cn := mySchema.AttributeTypes().Get(`cn`)
+	data, err := json.Marshal(cn)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(string(data))
+	// Output: {"oid":"2.5.4.3","names":["cn","commonName"],"description":"RFC4519: common name(s) for which the entity is known by","superType":"name","extensions":[{"name":"X-ORIGIN","values":["RFC4519"]}]}
+}
+
+/*
+This example demonstrates saving a [Schema] as a JSON document, and
+restoring it thereafter.
+*/
+func ExampleSchema_UnmarshalJSON() {
+	data, err := json.Marshal(NewSchema())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var sch Schema
+	if err = json.Unmarshal(data, &sch); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	cn := sch.AttributeTypes().Get(`cn`)
+	fmt.Println(cn.SuperType().Name(), cn.SuperType().Schema() == sch)
+	// Output: name true
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	orig := mySchema.Clone()
+	orig.SetDN(`cn=subschema`)
+	orig.Macros().Set(`jsonMacro`, `1.3.6.1.4.1.56521.999.98`)
+
+	// SortLists would reorder lists which were not
+	// sorted at the time they were populated
+	orig.Options().Unshift(SortLists)
+
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	var restored Schema
+	if err = json.Unmarshal(data, &restored); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if restored.Counters() != orig.Counters() {
+		t.Errorf("%s failed: counters mismatch:\nwant: %#v\ngot:  %#v",
+			t.Name(), orig.Counters(), restored.Counters())
+		return
+	} else if restored.DN() != orig.DN() || restored.Options().binary() != orig.Options().binary() {
+		t.Errorf("%s failed: DN or options mismatch", t.Name())
+		return
+	} else if _, found := restored.Macros().Resolve(`jsonMacro`); !found {
+		t.Errorf("%s failed: macros not restored", t.Name())
+		return
+	}
+
+	for i, defs := range orig.collections() {
+		if want, got := defs.String(), restored.collections()[i].String(); want != got {
+			t.Errorf("%s failed: %s mismatch", t.Name(), defs.Type())
+			return
+		}
+	}
+
+	// unresolvable references are rejected
+	bogus := []byte(`{"attributeTypes":[{"oid":"1.3.6.1.4.1.56521.999.98.1","superType":"bogus"}]}`)
+	if err = json.Unmarshal(bogus, &restored); err == nil {
+		t.Errorf("%s failed: unresolvable reference accepted", t.Name())
+		return
+	} else if err = json.Unmarshal([]byte(`{"options":["bogus"]}`), &restored); err == nil {
+		t.Errorf("%s failed: unknown option accepted", t.Name())
+	}
+}
+
+func TestDefinition_UnmarshalJSON(t *testing.T) {
+	sch := NewSchema()
+	data, _ := json.Marshal(sch.ObjectClasses().Get(`groupOfNames`))
+
+	// duplicates are rejected
+	dup := sch.NewObjectClass()
+	if err := json.Unmarshal(data, &dup); err == nil {
+		t.Errorf("%s failed: duplicate accepted", t.Name())
+		return
+	}
+
+	data = []byte(strings.NewReplacer(`2.5.6.9`, `1.3.6.1.4.1.56521.999.98.2`,
+		`groupOfNames`, `groupOfJSONNames`).Replace(string(data)))
+	oc := sch.NewObjectClass()
+	if err := json.Unmarshal(data, &oc); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if oc.Name() != `groupOfJSONNames` || oc.Must().Len() != 2 || oc.SuperClasses().Index(0).Name() != `top` {
+		t.Errorf("%s failed: unexpected definition:\n%s", t.Name(), oc)
+		return
+	} else if sch.ObjectClasses().Contains(`groupOfJSONNames`) {
+		t.Errorf("%s failed: definition pushed unexpectedly", t.Name())
+		return
+	}
+
+	var ds DITStructureRule
+	if err := ds.UnmarshalJSON([]byte(`{"ruleID":0}`)); err != ErrNilReceiver {
+		t.Errorf("%s failed: want '%v', got '%v'", t.Name(), ErrNilReceiver, err)
+	}
+}
+
+func TestDefinitions_UnmarshalJSON(t *testing.T) {
+	sch := NewSchema()
+	data, _ := json.Marshal(sch.ObjectClasses().Get(`groupOfNames`))
+	data = []byte(`[` + string(data) + `,` + strings.NewReplacer(`2.5.6.9`, `1.3.6.1.4.1.56521.999.98.3`,
+		`groupOfNames`, `groupOfJSONNames`).Replace(string(data)) + `]`)
+
+	ocs := sch.ObjectClasses()
+	before := ocs.Len()
+	if err := json.Unmarshal(data, &ocs); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if ocs.Len() != before+1 || !sch.ObjectClasses().Get(`groupOfJSONNames`).Must().Contains(`member`) {
+		t.Errorf("%s failed: definitions not pushed", t.Name())
+		return
+	}
+
+	for _, defs := range []Definitions{AttributeTypes{}, NewAttributeTypes(), sch.Snapshot().AttributeTypes()} {
+		if err := defs.(json.Unmarshaler).UnmarshalJSON([]byte(`[]`)); err == nil {
+			t.Errorf("%s failed: %T accepted", t.Name(), defs)
+			return
+		}
+	}
+}
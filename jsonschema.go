@@ -0,0 +1,219 @@
+package schemax
+
+/*
+jsonschema.go implements the export of ObjectClass instances as JSON
+Schema (draft 2020-12) documents.
+*/
+
+import (
+	"encoding/json"
+)
+
+/*
+jsonSchemaDraft is the meta-schema URI of documents produced by way of
+[ObjectClass.JSONSchema].
+*/
+const jsonSchemaDraft = `https://json-schema.org/draft/2020-12/schema`
+
+/*
+jsonSchemaDocument is the JSON Schema form of an [ObjectClass].
+*/
+type jsonSchemaDocument struct {
+	Schema               string                        `json:"$schema"`
+	Title                string                        `json:"title"`
+	Description          string                        `json:"description,omitempty"`
+	Comment              string                        `json:"$comment,omitempty"`
+	Type                 string                        `json:"type"`
+	Properties           map[string]jsonSchemaProperty `json:"properties"`
+	Required             []string                      `json:"required,omitempty"`
+	AdditionalProperties bool                          `json:"additionalProperties"`
+}
+
+/*
+jsonSchemaProperty is the JSON Schema form of an [AttributeType], or of a
+single value thereof.
+*/
+type jsonSchemaProperty struct {
+	Description     string              `json:"description,omitempty"`
+	Type            string              `json:"type"`
+	Format          string              `json:"format,omitempty"`
+	Pattern         string              `json:"pattern,omitempty"`
+	ContentEncoding string              `json:"contentEncoding,omitempty"`
+	Const           string              `json:"const,omitempty"`
+	MinLength       uint                `json:"minLength,omitempty"`
+	MaxLength       uint                `json:"maxLength,omitempty"`
+	Items           *jsonSchemaProperty `json:"items,omitempty"`
+	Contains        *jsonSchemaProperty `json:"contains,omitempty"`
+	MinItems        uint                `json:"minItems,omitempty"`
+	UniqueItems     bool                `json:"uniqueItems,omitempty"`
+	ReadOnly        bool                `json:"readOnly,omitempty"`
+	Deprecated      bool                `json:"deprecated,omitempty"`
+}
+
+/*
+jsonSchemaSyntaxes maps the numeric OIDs of RFC 4517 syntaxes to the JSON
+Schema form of a single value thereof.  Syntaxes not present are treated
+as unconstrained strings.
+*/
+var jsonSchemaSyntaxes = map[string]jsonSchemaProperty{
+	`1.3.6.1.4.1.1466.115.121.1.5`:  {Type: `string`, ContentEncoding: `base64`},                              // Binary
+	`1.3.6.1.4.1.1466.115.121.1.6`:  {Type: `string`, Pattern: `^'[01]*'B$`},                                  // Bit String
+	`1.3.6.1.4.1.1466.115.121.1.7`:  {Type: `boolean`},                                                        // Boolean
+	`1.3.6.1.4.1.1466.115.121.1.8`:  {Type: `string`, ContentEncoding: `base64`},                              // Certificate
+	`1.3.6.1.4.1.1466.115.121.1.9`:  {Type: `string`, ContentEncoding: `base64`},                              // Certificate List
+	`1.3.6.1.4.1.1466.115.121.1.10`: {Type: `string`, ContentEncoding: `base64`},                              // Certificate Pair
+	`1.3.6.1.4.1.1466.115.121.1.11`: {Type: `string`, Pattern: `^[A-Za-z]{2}$`},                               // Country String
+	`1.3.6.1.4.1.1466.115.121.1.15`: {Type: `string`, MinLength: 1},                                           // Directory String
+	`1.3.6.1.4.1.1466.115.121.1.22`: {Type: `string`, Pattern: `^[ '()+,\-./0-9:=?A-Za-z]+(\$[a-zA-Z]+)*$`},   // Facsimile Telephone Number
+	`1.3.6.1.4.1.1466.115.121.1.24`: {Type: `string`, Format: `date-time`},                                    // Generalized Time
+	`1.3.6.1.4.1.1466.115.121.1.26`: {Type: `string`, Pattern: `^[\x00-\x7F]*$`},                              // IA5 String
+	`1.3.6.1.4.1.1466.115.121.1.27`: {Type: `integer`},                                                        // Integer
+	`1.3.6.1.4.1.1466.115.121.1.28`: {Type: `string`, ContentEncoding: `base64`},                              // JPEG
+	`1.3.6.1.4.1.1466.115.121.1.36`: {Type: `string`, Pattern: `^[0-9 ]+$`},                                   // Numeric String
+	`1.3.6.1.4.1.1466.115.121.1.38`: {Type: `string`, Pattern: `^([0-9]+(\.[0-9]+)+|[A-Za-z][A-Za-z0-9-]*)$`}, // OID
+	`1.3.6.1.4.1.1466.115.121.1.40`: {Type: `string`, ContentEncoding: `base64`},                              // Octet String
+	`1.3.6.1.4.1.1466.115.121.1.44`: {Type: `string`, Pattern: `^[ '()+,\-./0-9:=?A-Za-z]+$`},                 // Printable String
+	`1.3.6.1.4.1.1466.115.121.1.50`: {Type: `string`, Pattern: `^[ '()+,\-./0-9:=?A-Za-z]+$`},                 // Telephone Number
+	`1.3.6.1.4.1.1466.115.121.1.53`: {Type: `string`, Format: `date-time`},                                    // UTC Time
+}
+
+/*
+JSONSchema returns a JSON Schema (draft 2020-12) document describing the
+JSON form of entries of the receiver instance, alongside an error.  The
+document is an object bearing one property per [AttributeType] allowed
+by the receiver -- including those inherited from its super classes (see
+[ObjectClass.AllMust] and [ObjectClass.AllMay]) -- keyed by the principal
+name (or numeric OID) of each type.  All MUST types are required, while
+no other properties are permitted.
+
+Single-valued types (see [AttributeType.SingleValue]) are expressed as
+scalars, while all others are expressed as non-empty arrays of unique
+values.  The JSON type and format of each value is derived from the
+effective syntax of the type (see [AttributeType.EffectiveSyntax]):
+Integer values are expressed as integers, Boolean values as booleans,
+Generalized Time and UTC Time values as "date-time" strings, binary
+values as base64 strings, and IA5, Printable, Numeric and similar string
+values as strings constrained by a pattern.  Types bearing a minimum upper
+bound (see [AttributeType.MinimumUpperBounds]) are limited in length.
+
+If a [DITContentRule] is provided, the properties of the document are
+further adjusted per its auxiliary classes (see [DITContentRule.Aux]) as
+well as its MUST, MAY and NOT clauses.  An error is returned if the rule
+does not govern the receiver, or if the receiver is zero.
+*/
+func (r ObjectClass) JSONSchema(dcr ...DITContentRule) (doc []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	var rule DITContentRule
+	if len(dcr) > 0 && !dcr[0].IsZero() {
+		if rule = dcr[0]; rule.NumericOID() != r.NumericOID() {
+			err = mkerr(ErrInvalidInput.Error() + `: DITContentRule ` +
+				docLabel(rule) + ` does not govern ObjectClass ` + docLabel(r))
+			return
+		}
+	}
+
+	must := r.AllMust()
+	may := r.AllMay()
+	if !rule.IsZero() {
+		aux := rule.Aux()
+		for i := 0; i < aux.Len(); i++ {
+			pushAttributeTypes(must, aux.Index(i).AllMust())
+			pushAttributeTypes(may, aux.Index(i).AllMay())
+		}
+		pushAttributeTypes(must, rule.Must())
+		pushAttributeTypes(may, rule.May())
+	}
+
+	js := jsonSchemaDocument{
+		Schema:      jsonSchemaDraft,
+		Title:       docLabel(r),
+		Description: r.Description(),
+		Comment:     `objectClass ` + r.NumericOID(),
+		Type:        `object`,
+		Properties:  make(map[string]jsonSchemaProperty),
+	}
+
+	for i := 0; i < must.Len(); i++ {
+		at := must.Index(i)
+		key := docLabel(at)
+		if _, found := js.Properties[key]; !found {
+			js.Properties[key] = r.jsonSchemaProperty(at)
+			js.Required = append(js.Required, key)
+		}
+	}
+
+	for i := 0; i < may.Len(); i++ {
+		at := may.Index(i)
+		key := docLabel(at)
+		_, found := js.Properties[key]
+		if !found && (rule.IsZero() || !rule.Not().Contains(at.NumericOID())) {
+			js.Properties[key] = r.jsonSchemaProperty(at)
+		}
+	}
+
+	doc, err = json.MarshalIndent(js, ``, `  `)
+
+	return
+}
+
+/*
+pushAttributeTypes pushes the members of src into dest, skipping any
+which are already present.
+*/
+func pushAttributeTypes(dest, src AttributeTypes) {
+	for i := 0; i < src.Len(); i++ {
+		if at := src.Index(i); !dest.Contains(at.NumericOID()) {
+			dest.Push(at)
+		}
+	}
+}
+
+/*
+jsonSchemaProperty returns the JSON Schema property describing at within
+entries of the receiver instance.
+*/
+func (r ObjectClass) jsonSchemaProperty(at AttributeType) (prop jsonSchemaProperty) {
+	value := jsonSchemaProperty{Type: `string`}
+	if v, found := jsonSchemaSyntaxes[at.EffectiveSyntax().NumericOID()]; found {
+		value = v
+	}
+
+	// The syntax length, if any, may be inherited
+	// from a super type.  Lengths of base64 values
+	// are scaled to that of their encoded form.
+	for sup := at; !sup.IsZero() && value.Type == `string`; sup = sup.SuperType() {
+		if mub := sup.MinimumUpperBounds(); mub > 0 {
+			if value.MaxLength = mub; value.ContentEncoding == `base64` {
+				value.MaxLength = 4 * ((mub + 2) / 3)
+			}
+			break
+		}
+	}
+
+	if at.SingleValue() {
+		prop = value
+	} else {
+		prop = jsonSchemaProperty{
+			Type:        `array`,
+			Items:       &value,
+			MinItems:    1,
+			UniqueItems: true,
+		}
+
+		// Entries must bear the receiver among
+		// their object classes.
+		if at.NumericOID() == `2.5.4.0` {
+			prop.Contains = &jsonSchemaProperty{Type: `string`, Const: docLabel(r)}
+		}
+	}
+
+	prop.Description = at.Description()
+	prop.ReadOnly = at.NoUserModification()
+	prop.Deprecated = at.Obsolete()
+
+	return
+}
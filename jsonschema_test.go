@@ -0,0 +1,121 @@
+package schemax
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the export of the "inetOrgPerson" [ObjectClass]
+as a JSON Schema document, portions of which are shown here.
+*/
+func ExampleObjectClass_JSONSchema() {
+	inet := mySchema.ObjectClasses().Get(`inetOrgPerson`)
+	doc, err := inet.JSONSchema()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var js struct {
+		Required   []string
+		Properties map[string]struct {
+			Type  string
+			Items *struct{ Type string }
+		}
+	}
+	if err = json.Unmarshal(doc, &js); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(js.Required)
+	fmt.Println(js.Properties[`cn`].Type, js.Properties[`cn`].Items.Type)
+	fmt.Println(js.Properties[`displayName`].Type)
+	// Output: [objectClass cn sn]
+	// array string
+	// string
+}
+
+func TestObjectClass_JSONSchema(t *testing.T) {
+	arc := mySchema.ObjectClasses().Get(`arc`)
+	dcr := mySchema.DITContentRules().Get(`arcContent`)
+
+	doc, err := arc.JSONSchema(dcr)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	var js map[string]any
+	if err = json.Unmarshal(doc, &js); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if js[`$schema`] != jsonSchemaDraft || js[`additionalProperties`] != false {
+		t.Errorf("%s failed: unexpected document header:\n%s", t.Name(), doc)
+		return
+	}
+
+	props := js[`properties`].(map[string]any)
+
+	// n is a SINGLE-VALUE Integer type required by the content rule
+	if n, _ := props[`n`].(map[string]any); n == nil || n[`type`] != `integer` {
+		t.Errorf("%s failed: bad property for n: %v", t.Name(), props[`n`])
+		return
+	}
+
+	var required bool
+	for _, name := range js[`required`].([]any) {
+		required = required || name == `n`
+	}
+	if !required {
+		t.Errorf("%s failed: n not required", t.Name())
+		return
+	}
+
+	// dotNotation is precluded by the NOT clause of the content rule
+	if _, found := props[`dotNotation`]; found {
+		t.Errorf("%s failed: NOT clause not honored", t.Name())
+		return
+	}
+
+	// mail is a multi-valued IA5 String type
+	if doc, err = mySchema.ObjectClasses().Get(`inetOrgPerson`).JSONSchema(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = json.Unmarshal(doc, &js); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	at, _ := js[`properties`].(map[string]any)[`mail`].(map[string]any)
+	if items, _ := at[`items`].(map[string]any); items[`pattern`] != `^[\x00-\x7F]*$` || at[`type`] != `array` {
+		t.Errorf("%s failed: bad property for mail: %v", t.Name(), at)
+		return
+	}
+
+	// lengths of base64 values are scaled to their encoded form
+	sch := NewSchema()
+	for _, raw := range []string{
+		`( 1.3.6.1.4.1.56521.999.98.1 NAME 'jsonOctets' SYNTAX 1.3.6.1.4.1.1466.115.121.1.40{32} SINGLE-VALUE )`,
+		`( 1.3.6.1.4.1.56521.999.98.2 NAME 'jsonString' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} SINGLE-VALUE )`,
+	} {
+		if err = sch.ParseAttributeType(raw); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+			return
+		}
+	}
+	for name, want := range map[string]uint{`jsonOctets`: 44, `jsonString`: 32} {
+		if got := arc.jsonSchemaProperty(sch.AttributeTypes().Get(name)).MaxLength; got != want {
+			t.Errorf("%s failed: %s: want maxLength %d, got %d", t.Name(), name, want, got)
+			return
+		}
+	}
+
+	if _, err = mySchema.ObjectClasses().Get(`person`).JSONSchema(dcr); err == nil {
+		t.Errorf("%s failed: expected error for ungoverned class", t.Name())
+	} else if _, err = (ObjectClass{}).JSONSchema(); err == nil {
+		t.Errorf("%s failed: expected error for zero class", t.Name())
+	}
+}
@@ -81,6 +81,10 @@ func (r LDAPSyntax) SetData(x any) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setData(x any) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -214,6 +218,10 @@ func (r LDAPSyntax) SetStringer(function ...Stringer) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setStringer(function ...Stringer) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
@@ -221,20 +229,16 @@ func (r *lDAPSyntax) setStringer(function ...Stringer) {
 
 	if stringer == nil {
 		// no user provided closure means we
-		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// defer to a general use stringer, the
+		// text/template op of which is deferred
+		// until first use.
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 		return
 	}
 
 	// assign user-provided closure
 	r.stringer = stringer
+	r.rendering = nil
 }
 
 /*
@@ -356,6 +360,12 @@ func (r LDAPSyntax) setOID(x string) {
 	}
 }
 
+func (r LDAPSyntax) resetRendering() {
+	if !r.IsZero() {
+		r.lDAPSyntax.rendering.reset()
+	}
+}
+
 func (r LDAPSyntaxes) canPush(x ...any) (err error) {
 	if len(x) == 0 {
 		return
@@ -391,6 +401,10 @@ func (r LDAPSyntax) SetNumericOID(id string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setNumericOID(id string) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -429,6 +443,10 @@ func (r LDAPSyntax) SetExtension(x string, xstrs ...string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setExtension(x string, xstrs ...string) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -511,6 +529,11 @@ func (r LDAPSyntax) SetDescription(desc string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setDescription(desc string) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, LDAPSyntax{r})()
+
 	if len(desc) < 3 {
 		return
 	}
@@ -548,12 +571,21 @@ either of the following:
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r LDAPSyntaxes) Get(id string) LDAPSyntax {
 	return r.get(id)
 }
 
 func (r LDAPSyntaxes) get(id string) (ls LDAPSyntax) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		ls, _ = def.(LDAPSyntax)
+		return
+	}
+
 	for i := 0; i < r.len() && ls.IsZero(); i++ {
 		if _ls := r.index(i); !_ls.IsZero() {
 			if eq(_ls.lDAPSyntax.OID, id) {
@@ -616,7 +648,7 @@ func (r LDAPSyntaxes) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -655,7 +687,7 @@ func (r LDAPSyntax) Parse(raw string) (err error) {
 
 func (r *lDAPSyntax) parse(raw string) error {
 	// parseLS wraps the antlr4512 LDAPSyntax parser/lexer
-	mp, err := parseLS(raw)
+	mp, err := parseLS(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -757,14 +789,25 @@ func (r *lDAPSyntax) replace(x LDAPSyntax) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, LDAPSyntax{r})()
+
 	r.OID = x.lDAPSyntax.OID
 	r.Desc = x.lDAPSyntax.Desc
 	r.Extensions = x.lDAPSyntax.Extensions
 	r.data = x.lDAPSyntax.data
 	r.schema = x.lDAPSyntax.schema
-	r.stringer = x.lDAPSyntax.stringer
+	r.stringer, r.rendering = copyStringer(x.lDAPSyntax.stringer, x.lDAPSyntax.rendering, r.prepareString)
 	r.synQual = x.lDAPSyntax.synQual
 	r.data = x.lDAPSyntax.data
+
+	if member(old.schema, LDAPSyntax{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  LDAPSyntax{&old},
+			New:  LDAPSyntax{r},
+		})
+	}
 }
 
 /*
@@ -773,18 +816,15 @@ to represent the receiver instance as a string using [text/template].
 */
 func (r *lDAPSyntax) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(`ldapSyntax`).
-		Funcs(funcMap(map[string]any{
-			`ExtensionSet`: r.Extensions.tmplFunc,
-		}))
+	t := newTemplate(`ldapSyntax`).Funcs(funcMap(r.tmplFuncs()))
 
-	if t, err = t.Parse(lDAPSyntaxTmpl); err == nil {
+	if t, err = t.Parse(r.schema.template(`ldapSyntax`, lDAPSyntaxTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *lDAPSyntax
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -793,6 +833,18 @@ func (r *lDAPSyntax) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *lDAPSyntax) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(QuotedDescriptorList{}, map[string]any{
+		`OID`:          func() string { return r.schema.macroOID(f, r.OID) },
+		`ExtensionSet`: r.Extensions.tmplFunc,
+	}, `DESC`)
+}
+
 /*
 LDAPSyntaxes returns the [LDAPSyntaxes] instance from within the
 receiver instance.
@@ -821,6 +873,10 @@ func (r LDAPSyntax) SetSyntaxQualifier(function SyntaxQualifier) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setSyntaxQualifier(function SyntaxQualifier) {
+	if !modify(LDAPSyntax{r}, r.rendering) {
+		return
+	}
+
 	r.synQual = function
 }
 
@@ -1,8 +1,14 @@
 package schemax
 
+import (
+	"sort"
+
+	"github.com/JesseCoretta/go-antlr4512"
+)
+
 func newMacros() Macros {
 	return Macros{
-		make(macros, 0),
+		macros: make(macros, 0),
 	}
 }
 
@@ -55,10 +61,33 @@ func (r Macros) ReverseResolve(y string) (x string, found bool) {
 /*
 Set assigns value y (macro name) to key x (numeric OID).
 
+If the receiver is associated with a [Schema] instance, a [MacroEvent]
+is delivered to any registered [Observer] instances.  Should the MacroOIDs
+field of the [Formatting] of that [Schema] be true, the cached string values
+of its definitions are also reset.
+
+This method has no effect if the receiver belongs to a frozen [Schema]
+(see [Schema.Snapshot]).
+
 This is a fluent method.
 */
 func (r Macros) Set(x, y string) Macros {
+	if r.ro {
+		return r
+	}
+
+	old := r.macros[x]
 	r.macros[x] = y
+	if r.schema.Formatting().MacroOIDs {
+		r.schema.resetRenderings()
+	}
+	r.obs.notify(Event{
+		Kind:     MacroEvent,
+		Macro:    x,
+		OldValue: old,
+		NewValue: y,
+	})
+
 	return r
 }
 
@@ -73,3 +102,133 @@ func (r Macros) Keys() []string {
 
 	return s
 }
+
+/*
+macroOID returns oid in macro form, e.g.: "nisSchema.1.2", alongside a
+Boolean value indicative of success.  The macro whose numeric OID forms
+the longest prefix of oid is used.  A macro whose numeric OID is equal
+to oid cannot be used, as the macro form requires a suffix.
+*/
+func (r Macros) macroOID(oid string) (m string, found bool) {
+	var name, mc string
+	for k, v := range r.macros {
+		if !hasPfx(oid, v+`.`) {
+			continue
+		} else if len(v) > len(mc) || (len(v) == len(mc) && k < name) {
+			name, mc = k, v
+		}
+	}
+
+	if found = len(mc) > 0; found {
+		m = name + `.` + oid[len(mc)+1:]
+	}
+
+	return
+}
+
+/*
+macroOID returns oid in macro form if the MacroOIDs field of f is true
+and a suitable macro is registered within the receiver instance, else
+oid is returned as-is.
+*/
+func (r Schema) macroOID(f Formatting, oid string) string {
+	if f.MacroOIDs && !r.IsZero() {
+		if m, found := r.Macros().macroOID(oid); found {
+			return m
+		}
+	}
+
+	return oid
+}
+
+/*
+macroEligible returns a Boolean value indicative of whether the numeric
+OID of def may be expressed in macro form.
+*/
+func macroEligible(def Definition) bool {
+	switch def.Type() {
+	case `matchingRuleUse`, `dITStructureRule`:
+		return false
+	}
+
+	return true
+}
+
+/*
+MacroDeclarations returns the OpenLDAP "objectidentifier" declarations
+of those macros needed to express the numeric OIDs of defs in macro form,
+as produced when the MacroOIDs field of [Formatting] is true.  If no defs
+are provided, all definitions within the receiver instance are examined.
+
+Declarations are sorted by macro name, one per line, e.g.:
+
+	objectidentifier nisSchema 1.3.6.1.1.1
+
+Such declarations must precede any use of the macros they declare when
+parsed by way of [Schema.ParseFile], [Schema.ParseDirectory] or
+[Schema.ParseRaw].  A zero string is returned if no macros are needed.
+*/
+func (r Schema) MacroDeclarations(defs ...Definition) (decl string) {
+	if r.IsZero() {
+		return
+	}
+
+	if len(defs) == 0 {
+		for _, c := range r.collections() {
+			for i := 0; i < c.Len(); i++ {
+				defs = append(defs, definitionAt(c, i))
+			}
+		}
+	}
+
+	needed := make(map[string]string, 0)
+	for _, def := range defs {
+		if def == nil || def.IsZero() || !macroEligible(def) {
+			continue
+		}
+
+		if m, found := r.Macros().macroOID(def.NumericOID()); found {
+			name := m[:stridx(m, `.`)]
+			needed[name], _ = r.Macros().Resolve(name)
+		}
+	}
+
+	var names []string
+	for name := range needed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		decl += `objectidentifier ` + name + ` ` + needed[name] + string(rune(10))
+	}
+
+	return
+}
+
+/*
+seedMacros adds the macros registered within the receiver instance to om
+such that they may be referenced by definitions undergoing parsing.  Any
+macro already present within om is left as-is.
+*/
+func (r Schema) seedMacros(om antlr4512.Macros) {
+	for k, v := range r.Macros().macros {
+		if _, found := om[k]; !found {
+			om[k] = v
+		}
+	}
+}
+
+/*
+adoptMacros registers the macros within om, such as those declared by way
+of "objectidentifier" statements within parsed input, with the receiver
+instance.  This allows the numeric OIDs of parsed definitions to be
+rendered back into macro form.  See the MacroOIDs field of [Formatting].
+*/
+func (r Schema) adoptMacros(om antlr4512.Macros) {
+	for k, v := range om {
+		if cur, found := r.Macros().Resolve(k); !found || cur != v {
+			r.Macros().Set(k, v)
+		}
+	}
+}
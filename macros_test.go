@@ -1,6 +1,7 @@
 package schemax
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -13,3 +14,88 @@ func TestMacros_codecov(t *testing.T) {
 	m.ReverseResolve(`jesse`)
 	m.Keys()
 }
+
+/*
+This example demonstrates the rendering of numeric OIDs in macro form,
+alongside the declarations of the macros needed to parse such output.
+*/
+func ExampleSchema_MacroDeclarations() {
+	sch := mySchema.Clone().SetFormatting(Formatting{MacroOIDs: true})
+	sch.Options().Unshift(HangingIndents)
+
+	uidNumber := sch.AttributeTypes().Get(`uidNumber`)
+	fmt.Print(sch.MacroDeclarations(uidNumber))
+	fmt.Println(uidNumber)
+	// Output: objectidentifier nisSchema 1.3.6.1.1.1
+	// ( nisSchema.1.0 NAME 'uidNumber' DESC 'An integer uniquely identifying a user in an administrative domain' EQUALITY integerMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE X-ORIGIN 'RFC2307' )
+}
+
+func TestSchema_MacroOIDs(t *testing.T) {
+	sch := mySchema.Clone()
+	raw := `objectidentifier myOID 1.3.6.1.4.1.56521.999
+objectidentifier myAttrs myOID:1
+attributeType ( myAttrs.7 NAME 'xTest' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )
+objectClass ( myOID.2.1 NAME 'xOC' SUP top AUXILIARY MAY xTest )`
+
+	if err := sch.ParseRaw([]byte(raw)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// declared macros are retained, and resolved with a separating period
+	at := sch.AttributeTypes().Get(`xTest`)
+	if got := at.NumericOID(); got != `1.3.6.1.4.1.56521.999.1.7` {
+		t.Errorf("%s failed: unexpected OID %s", t.Name(), got)
+		return
+	} else if _, found := sch.Macros().Resolve(`myAttrs`); !found {
+		t.Errorf("%s failed: declared macro not retained", t.Name())
+		return
+	}
+
+	// numeric output remains the default
+	if !hasPfx(at.String(), `( 1.3.6.1.4.1.56521.999.1.7`) {
+		t.Errorf("%s failed: unexpected default output: %s", t.Name(), at)
+		return
+	}
+
+	sch.SetFormatting(Formatting{MacroOIDs: true})
+	if !hasPfx(at.String(), `( myAttrs.7`) {
+		t.Errorf("%s failed: unexpected macro output: %s", t.Name(), at)
+		return
+	}
+
+	// macro changes are reflected by cached renderings
+	sch.Macros().Set(`myAttrs`, `1.3.6.1.4.1.56521.999.9`)
+	if !hasPfx(at.String(), `( myOID.1.7`) {
+		t.Errorf("%s failed: unexpected output following macro change: %s", t.Name(), at)
+		return
+	}
+	sch.Macros().Set(`myAttrs`, `1.3.6.1.4.1.56521.999.1`)
+
+	oc := sch.ObjectClasses().Get(`xOC`)
+	want := "objectidentifier myAttrs 1.3.6.1.4.1.56521.999.1\n" +
+		"objectidentifier myOID 1.3.6.1.4.1.56521.999\n"
+	if got := sch.MacroDeclarations(at, oc); got != want {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+		return
+	}
+
+	// files written in macro form must parse back identically
+	dir := t.TempDir()
+	if err := sch.WriteDirectory(dir, LayoutByOrigin); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	dup := NewEmptySchema()
+	if err := dup.ParseDirectory(dir); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := dup.ObjectClasses().Get(`xOC`).NumericOID(); got != oc.NumericOID() {
+		t.Errorf("%s failed: want %s, got %s", t.Name(), oc.NumericOID(), got)
+		return
+	} else if dup.AttributeTypes().Len() != sch.AttributeTypes().Len() {
+		t.Errorf("%s failed: want %d attributeTypes, got %d", t.Name(),
+			sch.AttributeTypes().Len(), dup.AttributeTypes().Len())
+	}
+}
@@ -116,6 +116,38 @@ func mapTransferExtensions(src Definition, dest DefinitionMap) DefinitionMap {
 	return dest
 }
 
+/*
+qdEscapes returns raw with the "\27" and "\5C" escapes of § 4.1 of
+RFC 4512 replaced by the "\'" and "\\" forms accepted by the parser.
+Escapes already in the latter form are left as-is, such that an escaped
+backslash followed by "27" or "5C" is not mistaken for an escape.
+*/
+func qdEscapes(raw string) string {
+	if stridx(raw, `\`) == -1 {
+		return raw
+	}
+
+	buf := newBuf()
+	for i := 0; i < len(raw); i++ {
+		if c := raw[i]; c != '\\' || i+1 == len(raw) {
+			buf.WriteByte(c)
+		} else if n := raw[i+1]; n == '\\' || n == '\'' {
+			buf.WriteString(raw[i : i+2])
+			i++
+		} else if i+2 < len(raw) && raw[i+1:i+3] == `27` {
+			buf.WriteString(`\'`)
+			i += 2
+		} else if i+2 < len(raw) && eq(raw[i+1:i+3], `5C`) {
+			buf.WriteString(`\\`)
+			i += 2
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+
+	return buf.String()
+}
+
 /*
 condenseWHSP returns input string b with all contiguous
 WHSP characters condensed into single space characters.
@@ -31,3 +31,56 @@ dolor sit amet,
 
 incididunt ut labore et dolore magna aliqua.`)
 }
+
+func TestQDEscapes(t *testing.T) {
+	for idx, tc := range []struct {
+		raw, want string
+	}{
+		{`'plain'`, `'plain'`},
+		{`'it\'s'`, `'it\'s'`},
+		{`'a \\ b'`, `'a \\ b'`},
+		{`'a \\27 b'`, `'a \\27 b'`},
+		{`'a \\5C b'`, `'a \\5C b'`},
+		{`'it\27s'`, `'it\'s'`},
+		{`'a \5C b \5c'`, `'a \\ b \\'`},
+		{`'a \2 b \'`, `'a \2 b \'`},
+	} {
+		if got := qdEscapes(tc.raw); got != tc.want {
+			t.Errorf("%s[%d] failed: want %s, got %s", t.Name(), idx, tc.want, got)
+		}
+	}
+}
+
+/*
+TestQDEscapes_parse verifies that quoted strings bearing escapes in the
+form accepted prior to the support of "\27" and "\5C" are parsed as-is.
+*/
+func TestQDEscapes_parse(t *testing.T) {
+	for idx, tc := range []struct {
+		desc, want string
+	}{
+		{`it\'s`, `it\'s`},
+		{`a \\ b`, `a \\ b`},
+		{`a \\27 b`, `a \\27 b`},
+		{`a \\5c b`, `a \\5c b`},
+		{`it\27s a \5C`, `it\'s a \\`},
+	} {
+		sch := NewSchema()
+		raw := `( 1.3.6.1.4.1.56521.999.94.1 NAME 'escapedDesc' DESC '` + tc.desc +
+			`' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`
+		if err := sch.ParseAttributeType(raw); err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		} else if got := sch.AttributeTypes().Get(`escapedDesc`).Description(); got != tc.want {
+			t.Errorf("%s[%d] failed: want %s, got %s", t.Name(), idx, tc.want, got)
+			continue
+		}
+
+		dup := NewSchema()
+		if err := dup.ParseRaw([]byte(`attributetype ` + raw)); err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got := dup.AttributeTypes().Get(`escapedDesc`).Description(); got != tc.want {
+			t.Errorf("%s[%d] failed: want %s, got %s", t.Name(), idx, tc.want, got)
+		}
+	}
+}
@@ -98,6 +98,9 @@ func (r *matchingRule) replace(x MatchingRule) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, MatchingRule{r})()
+
 	r.OID = x.matchingRule.OID
 	r.Macro = x.matchingRule.Macro
 	r.Name = x.matchingRule.Name
@@ -107,9 +110,17 @@ func (r *matchingRule) replace(x MatchingRule) {
 	r.Extensions = x.matchingRule.Extensions
 	r.data = x.matchingRule.data
 	r.schema = x.matchingRule.schema
-	r.stringer = x.matchingRule.stringer
+	r.stringer, r.rendering = copyStringer(x.matchingRule.stringer, x.matchingRule.rendering, r.prepareString)
 	r.data = x.matchingRule.data
 	r.assMatch = x.matchingRule.assMatch
+
+	if member(old.schema, MatchingRule{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  MatchingRule{&old},
+			New:  MatchingRule{r},
+		})
+	}
 }
 
 /*
@@ -143,7 +154,7 @@ func (r MatchingRule) Parse(raw string) (err error) {
 
 func (r *matchingRule) parse(raw string) error {
 	// parseMR wraps the antlr4512 MatchingRule parser/lexer
-	mp, err := parseMR(raw)
+	mp, err := parseMR(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -186,6 +197,10 @@ func (r MatchingRule) SetData(x any) MatchingRule {
 }
 
 func (r *matchingRule) setData(x any) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -219,6 +234,10 @@ func (r MatchingRule) SetNumericOID(id string) MatchingRule {
 }
 
 func (r *matchingRule) setNumericOID(id string) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -315,10 +334,18 @@ func (r MatchingRule) SetAssertionMatcher(function AssertionMatcher) MatchingRul
 }
 
 func (r *matchingRule) setAssertionMatcher(function AssertionMatcher) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	r.assMatch = function
 }
 
 func (r *matchingRule) setSyntax(x any) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	var def LDAPSyntax
 	switch tv := x.(type) {
 	case string:
@@ -354,6 +381,10 @@ func (r MatchingRule) SetSchema(schema Schema) MatchingRule {
 }
 
 func (r *matchingRule) setSchema(schema Schema) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -392,6 +423,10 @@ func (r MatchingRule) SetDescription(desc string) MatchingRule {
 }
 
 func (r *matchingRule) setDescription(desc string) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -477,6 +512,7 @@ func (r MatchingRule) Map() (def DefinitionMap) {
 	def[`DESC`] = []string{r.Description()}
 	def[`OBSOLETE`] = []string{bool2str(r.Obsolete())}
 	def[`SYNTAX`] = []string{r.Syntax().NumericOID()}
+	def[`TYPE`] = []string{r.Type()}
 	def[`RAW`] = []string{r.String()}
 
 	// copy our extensions from receiver r
@@ -514,6 +550,10 @@ func (r MatchingRule) SetObsolete() MatchingRule {
 }
 
 func (r *matchingRule) setObsolete() {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -536,6 +576,11 @@ func (r MatchingRule) SetName(x ...string) MatchingRule {
 }
 
 func (r *matchingRule) setName(x ...string) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, MatchingRule{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -579,6 +624,10 @@ func (r MatchingRule) SetExtension(x string, xstrs ...string) MatchingRule {
 }
 
 func (r *matchingRule) setExtension(x string, xstrs ...string) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -608,6 +657,12 @@ func (r MatchingRule) setOID(x string) {
 	}
 }
 
+func (r MatchingRule) resetRendering() {
+	if !r.IsZero() {
+		r.matchingRule.rendering.reset()
+	}
+}
+
 /*
 LoadMatchingRules returns an error following to attempt to load all
 built-in [MatchingRule] definitions into the receiver instance.
@@ -747,19 +802,14 @@ to represent the receiver instance as a string using [text/template].
 */
 func (r *matchingRule) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(r.Type()).
-		Funcs(funcMap(map[string]any{
-			`Syntax`:       func() string { return r.Syntax.NumericOID() },
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			`Obsolete`:     func() bool { return r.Obsolete },
-		}))
-	if t, err = t.Parse(matchingRuleTmpl); err == nil {
+	t := newTemplate(r.Type()).Funcs(funcMap(r.tmplFuncs()))
+	if t, err = t.Parse(r.schema.template(`matchingRule`, matchingRuleTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *matchingRule
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -768,6 +818,20 @@ func (r *matchingRule) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *matchingRule) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`OID`:          func() string { return r.schema.macroOID(f, r.OID) },
+		`Syntax`:       func() string { return r.Syntax.NumericOID() },
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		`Obsolete`:     func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `OBSOLETE`, `SYNTAX`)
+}
+
 // stackage closure func - do not exec directly.
 func (r MatchingRules) canPush(x ...any) (err error) {
 	if len(x) == 0 {
@@ -883,6 +947,10 @@ func (r MatchingRule) SetStringer(function ...Stringer) MatchingRule {
 }
 
 func (r *matchingRule) setStringer(function ...Stringer) {
+	if !modify(MatchingRule{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
@@ -890,20 +958,16 @@ func (r *matchingRule) setStringer(function ...Stringer) {
 
 	if stringer == nil {
 		// no user provided closure means we
-		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// defer to a general use stringer, the
+		// text/template op of which is deferred
+		// until first use.
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 		return
 	}
 
 	// assign user-provided closure
 	r.stringer = stringer
+	r.rendering = nil
 }
 
 /*
@@ -964,7 +1028,7 @@ func (r MatchingRules) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1031,12 +1095,21 @@ the principal identifier of an [MatchingRule] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r MatchingRules) Get(id string) MatchingRule {
 	return r.get(id)
 }
 
 func (r MatchingRules) get(id string) (mr MatchingRule) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		mr, _ = def.(MatchingRule)
+		return
+	}
+
 	for i := 0; i < r.len() && mr.IsZero(); i++ {
 		if _mr := r.index(i); !_mr.IsZero() {
 			if _mr.IsIdentifiedAs(id) {
@@ -111,6 +111,9 @@ func (r *matchingRuleUse) replace(x MatchingRuleUse) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, MatchingRuleUse{r})()
+
 	r.OID = x.matchingRuleUse.OID
 	r.Name = x.matchingRuleUse.Name
 	r.Desc = x.matchingRuleUse.Desc
@@ -119,8 +122,16 @@ func (r *matchingRuleUse) replace(x MatchingRuleUse) {
 	r.Extensions = x.matchingRuleUse.Extensions
 	r.data = x.matchingRuleUse.data
 	r.schema = x.matchingRuleUse.schema
-	r.stringer = x.matchingRuleUse.stringer
+	r.stringer, r.rendering = copyStringer(x.matchingRuleUse.stringer, x.matchingRuleUse.rendering, r.prepareString)
 	r.data = x.matchingRuleUse.data
+
+	if member(old.schema, MatchingRuleUse{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  MatchingRuleUse{&old},
+			New:  MatchingRuleUse{r},
+		})
+	}
 }
 
 /*
@@ -184,6 +195,10 @@ func (r MatchingRuleUse) SetData(x any) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setData(x any) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -245,6 +260,10 @@ func (r MatchingRuleUse) SetStringer(function ...Stringer) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setStringer(function ...Stringer) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
@@ -252,20 +271,16 @@ func (r *matchingRuleUse) setStringer(function ...Stringer) {
 
 	if stringer == nil {
 		// no user provided closure means we
-		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// defer to a general use stringer, the
+		// text/template op of which is deferred
+		// until first use.
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 		return
 	}
 
 	// assign user-provided closure
 	r.stringer = stringer
+	r.rendering = nil
 }
 
 /*
@@ -311,6 +326,10 @@ func (r MatchingRuleUse) SetObsolete() MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setObsolete() {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -343,6 +362,10 @@ func (r MatchingRuleUse) SetApplies(m ...any) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setApplies(m ...any) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -381,6 +404,10 @@ func (r MatchingRuleUse) SetSchema(schema Schema) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setSchema(schema Schema) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -432,20 +459,14 @@ to represent the receiver instance as a string using [text/template].
 */
 func (r *matchingRuleUse) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(r.Type()).
-		Funcs(funcMap(map[string]any{
-			`MatchingRuleOID`: r.OID.NumericOID,
-			`ExtensionSet`:    r.Extensions.tmplFunc,
-			`Applied`:         r.Applies.String,
-			`Obsolete`:        func() bool { return r.Obsolete },
-		}))
-	if t, err = t.Parse(matchingRuleUseTmpl); err == nil {
+	t := newTemplate(r.Type()).Funcs(funcMap(r.tmplFuncs()))
+	if t, err = t.Parse(r.schema.template(`matchingRuleUse`, matchingRuleUseTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *matchingRuleUse
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -454,6 +475,20 @@ func (r *matchingRuleUse) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *matchingRuleUse) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`MatchingRuleOID`: r.OID.NumericOID,
+		`ExtensionSet`:    r.Extensions.tmplFunc,
+		`Applied`:         r.Applies.String,
+		`Obsolete`:        func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `APPLIES`)
+}
+
 /*
 Maps returns slices of [DefinitionMap] instances.
 */
@@ -487,6 +522,7 @@ func (r MatchingRuleUse) Map() (def DefinitionMap) {
 	def[`DESC`] = []string{r.Description()}
 	def[`OBSOLETE`] = []string{bool2str(r.Obsolete())}
 	def[`APPLIES`] = applies
+	def[`TYPE`] = []string{r.Type()}
 	def[`RAW`] = []string{r.String()}
 
 	// copy our extensions from receiver r
@@ -538,13 +574,8 @@ func (r MatchingRuleUses) Type() string {
 func (r MatchingRuleUses) prepareStrings() (err error) {
 	for i := 0; i < r.Len() && err == nil; i++ {
 		mu := r.index(i)
-		var str string
-		str, err = mu.matchingRuleUse.prepareString()
-		if err == nil {
-			mu.matchingRuleUse.stringer = func() string {
-				return str
-			}
-		}
+		mu.matchingRuleUse.stringer, mu.matchingRuleUse.rendering =
+			defaultStringer(mu.matchingRuleUse.prepareString)
 	}
 
 	return
@@ -593,6 +624,10 @@ func (r MatchingRuleUse) SetExtension(x string, xstrs ...string) MatchingRuleUse
 }
 
 func (r *matchingRuleUse) setExtension(x string, xstrs ...string) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -627,6 +662,10 @@ func (r MatchingRuleUse) SetNumericOID(id string) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setNumericOID(id string) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	mr := r.schema.MatchingRules().Get(id)
 	// only set an OID when the receiver
 	// lacks one (iow: no modifications)
@@ -678,6 +717,11 @@ func (r MatchingRuleUse) SetName(x ...string) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setName(x ...string) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, MatchingRuleUse{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -708,6 +752,10 @@ func (r MatchingRuleUse) SetDescription(desc string) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setDescription(desc string) {
+	if !modify(MatchingRuleUse{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -810,7 +858,7 @@ func (r MatchingRuleUses) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -841,12 +889,21 @@ the principal identifier of an [MatchingRuleUse] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r MatchingRuleUses) Get(id string) MatchingRuleUse {
 	return r.get(id)
 }
 
 func (r MatchingRuleUses) get(id string) (mu MatchingRuleUse) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		mu, _ = def.(MatchingRuleUse)
+		return
+	}
+
 	for i := 0; i < r.len() && mu.IsZero(); i++ {
 		if _mu := r.index(i); !_mu.IsZero() {
 			if _mu.IsIdentifiedAs(id) {
@@ -919,7 +976,7 @@ func (r Schema) updateMatchingRuleUses(ats AttributeTypes) (err error) {
 // part of this process fail.
 func (r Schema) updateEqualityUses(at AttributeType) (err error) {
 	if eqty := at.Equality(); !eqty.IsZero() {
-		mu := r.MatchingRuleUses().get(eqty.NumericOID())
+		mu := r.ownMatchingRuleUse(r.MatchingRuleUses().get(eqty.NumericOID()))
 
 		// If the MatchingRuleUse instance does not exist,
 		// create it now.
@@ -950,7 +1007,7 @@ func (r Schema) updateEqualityUses(at AttributeType) (err error) {
 // part of this process fail.
 func (r Schema) updateSubstringUses(at AttributeType) (err error) {
 	if substr := at.Substring(); !substr.IsZero() {
-		mu := r.MatchingRuleUses().get(substr.NumericOID())
+		mu := r.ownMatchingRuleUse(r.MatchingRuleUses().get(substr.NumericOID()))
 
 		// If the MatchingRuleUse instance does not exist,
 		// create it now.
@@ -981,7 +1038,7 @@ func (r Schema) updateSubstringUses(at AttributeType) (err error) {
 // part of this process fail.
 func (r Schema) updateOrderingUses(at AttributeType) (err error) {
 	if order := at.Ordering(); !order.IsZero() {
-		mu := r.MatchingRuleUses().get(order.NumericOID())
+		mu := r.ownMatchingRuleUse(r.MatchingRuleUses().get(order.NumericOID()))
 
 		// If the MatchingRuleUse instance does not exist,
 		// create it now.
@@ -1007,3 +1064,9 @@ func (r Schema) updateOrderingUses(at AttributeType) (err error) {
 
 func (r MatchingRuleUse) setOID(_ string) {}
 func (r MatchingRuleUse) macro() []string { return []string{} }
+
+func (r MatchingRuleUse) resetRendering() {
+	if !r.IsZero() {
+		r.matchingRuleUse.rendering.reset()
+	}
+}
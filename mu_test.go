@@ -406,8 +406,17 @@ func ExampleMatchingRuleUse_Description() {
 	// Output:
 }
 
+/*
+This example demonstrates the assignment of a description to an instance
+of [MatchingRuleUse].
+
+Note that a new instance is used here rather than one within the shared
+"mySchema" var, as changes made by way of the Set methods of a definition
+are reflected by its String method, and would thus alter the output of
+other examples.
+*/
 func ExampleMatchingRuleUse_SetDescription() {
-	cim := mySchema.MatchingRuleUses().Get(`caseIgnoreMatch`)
+	cim := NewMatchingRuleUse()
 	cim.SetDescription("Caseless string match")
 	fmt.Println(cim.Description())
 	// Output: Caseless string match
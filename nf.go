@@ -174,6 +174,9 @@ func (r *nameForm) replace(x NameForm) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, NameForm{r})()
+
 	r.OID = x.nameForm.OID
 	r.Macro = x.nameForm.Macro
 	r.Name = x.nameForm.Name
@@ -185,8 +188,16 @@ func (r *nameForm) replace(x NameForm) {
 	r.Extensions = x.nameForm.Extensions
 	r.data = x.nameForm.data
 	r.schema = x.nameForm.schema
-	r.stringer = x.nameForm.stringer
+	r.stringer, r.rendering = copyStringer(x.nameForm.stringer, x.nameForm.rendering, r.prepareString)
 	r.data = x.nameForm.data
+
+	if member(old.schema, NameForm{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  NameForm{&old},
+			New:  NameForm{r},
+		})
+	}
 }
 
 /*
@@ -274,6 +285,10 @@ func (r NameForm) SetData(x any) NameForm {
 }
 
 func (r *nameForm) setData(x any) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -309,6 +324,10 @@ func (r NameForm) SetSchema(schema Schema) NameForm {
 }
 
 func (r *nameForm) setSchema(schema Schema) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -347,6 +366,11 @@ func (r NameForm) SetName(name ...string) NameForm {
 }
 
 func (r *nameForm) setName(x ...string) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, NameForm{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -370,6 +394,10 @@ func (r NameForm) SetNumericOID(id string) NameForm {
 }
 
 func (r *nameForm) setNumericOID(id string) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -414,6 +442,10 @@ func (r NameForm) SetObsolete() NameForm {
 }
 
 func (r *nameForm) setObsolete() {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -476,6 +508,10 @@ func (r NameForm) SetMay(m ...any) NameForm {
 }
 
 func (r *nameForm) setMay(m ...any) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -509,6 +545,10 @@ func (r NameForm) SetExtension(x string, xstrs ...string) NameForm {
 }
 
 func (r *nameForm) setExtension(x string, xstrs ...string) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -543,6 +583,10 @@ func (r NameForm) SetMust(m ...any) NameForm {
 }
 
 func (r *nameForm) setMust(m ...any) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -642,22 +686,21 @@ func (r NameForm) SetStringer(function ...Stringer) NameForm {
 }
 
 func (r *nameForm) setStringer(function ...Stringer) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
 	}
 
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// text/template op is deferred until first use
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 	} else {
 		r.stringer = stringer
+		r.rendering = nil
 	}
 }
 
@@ -689,26 +732,27 @@ func (r NameForm) setOID(x string) {
 	}
 }
 
+func (r NameForm) resetRendering() {
+	if !r.IsZero() {
+		r.nameForm.rendering.reset()
+	}
+}
+
 /*
 prepareString returns a string an an error indicative of an attempt
 to represent the receiver instance as a string using [text/template].
 */
 func (r *nameForm) prepareString() (str string, err error) {
 	buf := newBuf()
-	t := newTemplate(`nameForm`).
-		Funcs(funcMap(map[string]any{
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			`MayLen`:       r.May.len,
-			`Obsolete`:     func() bool { return r.Obsolete },
-		}))
-
-	if t, err = t.Parse(nameFormTmpl); err == nil {
+	t := newTemplate(`nameForm`).Funcs(funcMap(r.tmplFuncs()))
+
+	if t, err = t.Parse(r.schema.template(`nameForm`, nameFormTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *nameForm
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -717,6 +761,20 @@ func (r *nameForm) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *nameForm) tmplFuncs() map[string]any {
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`OID`:          func() string { return r.schema.macroOID(f, r.OID) },
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		`MayLen`:       r.May.len,
+		`Obsolete`:     func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `OBSOLETE`, `OC`, `MUST`, `MAY`)
+}
+
 /*
 Description returns the underlying (optional) descriptive text
 assigned to the receiver instance.
@@ -743,6 +801,10 @@ func (r NameForm) SetDescription(desc string) NameForm {
 }
 
 func (r *nameForm) setDescription(desc string) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -791,7 +853,7 @@ func (r NameForm) Parse(raw string) (err error) {
 
 func (r *nameForm) parse(raw string) error {
 	// parseLS wraps the antlr4512 NameForm parser/lexer
-	mp, err := parseNF(raw)
+	mp, err := parseNF(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -916,7 +978,7 @@ func (r NameForms) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -947,12 +1009,21 @@ match of the principal identifier of a [NameForm] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r NameForms) Get(id string) NameForm {
 	return r.get(id)
 }
 
 func (r NameForms) get(id string) (nf NameForm) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		nf, _ = def.(NameForm)
+		return
+	}
+
 	for i := 0; i < r.len() && nf.IsZero(); i++ {
 		if _nf := r.index(i); !_nf.IsZero() {
 			if _nf.nameForm.OID == id {
@@ -989,6 +1060,10 @@ func (r NameForm) SetOC(x any) NameForm {
 }
 
 func (r *nameForm) setOC(x any) {
+	if !modify(NameForm{r}, r.rendering) {
+		return
+	}
+
 	var oc ObjectClass
 	switch tv := x.(type) {
 	case string:
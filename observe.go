@@ -0,0 +1,283 @@
+package schemax
+
+/*
+observe.go implements change notification for Schema instances.
+*/
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/JesseCoretta/go-stackage"
+)
+
+/*
+observers contains the [Observer] instances registered with a [Schema].
+A single instance is shared between the [Schema], its collections and
+its [Macros] instance.
+
+The number of changes observed is also tracked, allowing stale snapshots
+to be detected (see [Schema.Snapshot]).
+
+While holding, events are queued rather than delivered; see [Tx.Commit].
+*/
+type observers struct {
+	mutex    *sync.Mutex
+	list     []Observer
+	held     []Event
+	holding  bool
+	modified func(Definition) // see modify
+	changes  atomic.Uint64
+}
+
+func newObservers() *observers {
+	return &observers{mutex: &sync.Mutex{}}
+}
+
+func (r *observers) add(o Observer) {
+	if r != nil && o != nil {
+		r.mutex.Lock()
+		r.list = append(r.list, o)
+		r.mutex.Unlock()
+	}
+}
+
+func (r *observers) reset() {
+	if r != nil {
+		r.mutex.Lock()
+		r.list = nil
+		r.mutex.Unlock()
+	}
+}
+
+/*
+notify records a change and delivers ev to all registered [Observer]
+instances in order of registration.
+*/
+func (r *observers) notify(ev Event) {
+	if r == nil {
+		return
+	}
+
+	r.changes.Add(1)
+
+	r.mutex.Lock()
+	if r.holding {
+		r.held = append(r.held, ev)
+		r.mutex.Unlock()
+		return
+	}
+	r.mutex.Unlock()
+
+	r.deliver(ev)
+}
+
+/*
+modify records a change made to x by way of one of its Set methods, and
+passes x to the modified closure, if set (see [Schema.Begin]).  No event
+is delivered.
+*/
+func (r *observers) modify(x Definition) {
+	if r == nil {
+		return
+	}
+
+	r.changes.Add(1)
+
+	r.mutex.Lock()
+	fn := r.modified
+	r.mutex.Unlock()
+
+	if fn != nil {
+		fn(x)
+	}
+}
+
+/*
+hold causes all subsequent events to be queued until release is called.
+*/
+func (r *observers) hold() {
+	if r != nil {
+		r.mutex.Lock()
+		r.holding = true
+		r.mutex.Unlock()
+	}
+}
+
+/*
+release ends a hold, returning the events queued in the interim.
+*/
+func (r *observers) release() (held []Event) {
+	if r != nil {
+		r.mutex.Lock()
+		held, r.held = r.held, nil
+		r.holding = false
+		r.mutex.Unlock()
+	}
+
+	return
+}
+
+/*
+version returns the number of changes recorded by the receiver.
+*/
+func (r *observers) version() (v uint64) {
+	if r != nil {
+		v = r.changes.Load()
+	}
+
+	return
+}
+
+/*
+deliver delivers ev to all registered [Observer] instances in order of
+registration without recording a change.
+*/
+func (r *observers) deliver(ev Event) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	list := r.list
+	r.mutex.Unlock()
+
+	for i := 0; i < len(list); i++ {
+		list[i](ev)
+	}
+}
+
+/*
+String returns the string representation of the receiver instance.
+*/
+func (r EventKind) String() (s string) {
+	switch r {
+	case PushEvent:
+		s = `push`
+	case ReplaceEvent:
+		s = `replace`
+	case RemoveEvent:
+		s = `remove`
+	case MacroEvent:
+		s = `macro`
+	}
+
+	return
+}
+
+/*
+AddObserver registers the input [Observer] with the receiver instance.
+The [Observer] shall receive an [Event] following each of the following
+changes:
+
+  - A [Definition] is pushed into any collection of the receiver
+  - A [Definition] within the receiver is replaced
+  - A [Definition] is removed from the receiver (see [Tx.Remove])
+  - A macro is set within the receiver's [Macros] instance
+
+Changes staged within a [Tx] are delivered only upon a successful commit.
+
+[Observer] instances are executed synchronously, in order of registration,
+by the goroutine responsible for the change.
+
+Note that [Observer] instances are not carried over by [Schema.Clone].
+
+This is a fluent method.
+*/
+func (r Schema) AddObserver(o Observer) Schema {
+	r.observers().add(o)
+	return r
+}
+
+/*
+ResetObservers unregisters all [Observer] instances from the receiver
+instance.
+
+This is a fluent method.
+*/
+func (r Schema) ResetObservers() Schema {
+	r.observers().reset()
+	return r
+}
+
+func (r Schema) observers() (o *observers) {
+	if !r.IsZero() {
+		o, _ = r.cast().Auxiliary()[`observers`].(*observers)
+	}
+
+	return
+}
+
+func (r Schema) notify(ev Event) {
+	r.observers().notify(ev)
+}
+
+/*
+setCollection writes the input collection into the receiver instance at
+the specified index, associating it with the receiver's observers and
+[Macros] instance.
+*/
+func (r Schema) setCollection(defs Definitions, idx int) {
+	setObservers(defs.cast(), r.observers())
+	setLookupMacros(defs.cast(), r.Macros())
+	setCollectionSchema(defs.cast(), r)
+	if base := r.Base(); !base.IsZero() {
+		setLookupBase(defs.cast(), base.collections()[idx].cast())
+	}
+	r.cast().Replace(defs, idx)
+}
+
+/*
+setMacros writes the input [Macros] instance into the receiver instance,
+associating it with the receiver's observers and collections.
+*/
+func (r Schema) setMacros(m Macros) {
+	m.obs = r.observers()
+	m.schema = r
+	r.cast().Auxiliary()[`macros`] = m
+	for _, defs := range r.collections() {
+		setLookupMacros(defs.cast(), m)
+	}
+}
+
+func setObservers(stk stackage.Stack, o *observers) {
+	if stk.Auxiliary() == nil {
+		stk.SetAuxiliary()
+	}
+
+	stk.Auxiliary()[`observers`] = o
+}
+
+/*
+setCollectionSchema associates stk, a collection of s, with s.
+*/
+func setCollectionSchema(stk stackage.Stack, s Schema) {
+	if stk.Auxiliary() == nil {
+		stk.SetAuxiliary()
+	}
+
+	stk.Auxiliary()[`schema`] = s
+}
+
+/*
+collectionSchema returns the [Schema] of which stk is a collection, or a
+zero instance if stk is not associated with a [Schema].
+*/
+func collectionSchema(stk stackage.Stack) (s Schema) {
+	if aux := stk.Auxiliary(); aux != nil {
+		s, _ = aux[`schema`].(Schema)
+	}
+
+	return
+}
+
+/*
+pushAndNotify pushes def into stk and, if successful, delivers a
+[PushEvent] to any observers associated with stk.
+*/
+func pushAndNotify(stk stackage.Stack, def Definition) {
+	if L := stk.Len(); stk.Push(def).Len() > L {
+		o, _ := stk.Auxiliary()[`observers`].(*observers)
+		o.notify(Event{Kind: PushEvent, New: def})
+	}
+}
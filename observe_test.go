@@ -0,0 +1,125 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the registration of an [Observer] which is
+notified of each change made to the [Schema].
+*/
+func ExampleSchema_AddObserver() {
+	sch := NewSchema()
+	sch.AddObserver(func(ev Event) {
+		fmt.Printf("%s: %s\n", ev.Kind, ev.New.Name())
+	})
+
+	sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.77.1
+		NAME 'observedAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	// Output: push: observedAttr
+}
+
+func TestSchema_AddObserver(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+
+	var events []Event
+	sch.AddObserver(func(ev Event) {
+		events = append(events, ev)
+	})
+
+	// push
+	if err := sch.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.77.2
+		NAME 'observedClass'
+		SUP top AUXILIARY
+		MAY cn )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// duplicate pushes are rejected silently, and must not notify
+	sch.ObjectClasses().Push(sch.ObjectClasses().Get(`observedClass`))
+
+	// replace
+	oc := sch.NewObjectClass().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.77.2`).
+		SetName(`observedClass`).
+		SetKind(AuxiliaryKind).
+		SetSuperClass(`top`).
+		SetMay(`cn`, `sn`).
+		SetStringer()
+	sch.Replace(oc)
+
+	// macro
+	sch.Macros().Set(`observedMacro`, `1.3.6.1.4.1.56521.999.77`)
+
+	want := []EventKind{PushEvent, ReplaceEvent, MacroEvent}
+	if len(events) != len(want) {
+		t.Errorf("%s failed: want %d events, got %d", t.Name(), len(want), len(events))
+		return
+	}
+
+	for i, kind := range want {
+		if events[i].Kind != kind {
+			t.Errorf("%s failed: event %d: want %s, got %s",
+				t.Name(), i, kind, events[i].Kind)
+			return
+		}
+	}
+
+	if old := events[1].Old.(ObjectClass); old.May().Len() != 1 {
+		t.Errorf("%s failed: old definition not preserved", t.Name())
+		return
+	} else if nw := events[1].New.(ObjectClass); nw.May().Len() != 2 {
+		t.Errorf("%s failed: new definition not delivered", t.Name())
+		return
+	} else if events[2].Macro != `observedMacro` || events[2].OldValue != `` {
+		t.Errorf("%s failed: unexpected macro event %#v", t.Name(), events[2])
+		return
+	}
+
+	// staged changes are delivered only upon commit
+	events = nil
+	tx := sch.Begin()
+	tx.Remove(tx.Schema().ObjectClasses().Get(`observedClass`))
+	tx.Schema().Macros().Set(`observedMacro`, `1.3.6.1.4.1.56521.999.78`)
+	if len(events) != 0 {
+		t.Errorf("%s failed: staged events delivered prematurely", t.Name())
+		return
+	} else if err := tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if len(events) != 2 || events[0].Kind != RemoveEvent || events[1].OldValue == `` {
+		t.Errorf("%s failed: unexpected staged events %v", t.Name(), events)
+		return
+	}
+
+	// observers survive the commit
+	events = nil
+	sch.Macros().Set(`anotherMacro`, `1.3.6.1.4.1.56521.999.79`)
+	if len(events) != 1 {
+		t.Errorf("%s failed: observer lost following commit", t.Name())
+		return
+	}
+
+	// rolled back changes are never delivered
+	events = nil
+	tx = sch.Begin()
+	tx.Remove(tx.Schema().AttributeTypes().Get(`cn`))
+	tx.Rollback()
+
+	sch.ResetObservers()
+	sch.Macros().Set(`finalMacro`, `1.3.6.1.4.1.56521.999.80`)
+	if len(events) != 0 {
+		t.Errorf("%s failed: unexpected events %v", t.Name(), events)
+		return
+	}
+
+	for _, kind := range []EventKind{EventKind(0), PushEvent, ReplaceEvent, RemoveEvent, MacroEvent} {
+		_ = kind.String()
+	}
+
+	var zero Schema
+	zero.AddObserver(func(Event) {}).ResetObservers()
+}
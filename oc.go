@@ -118,7 +118,7 @@ func (r ObjectClass) Parse(raw string) (err error) {
 
 func (r *objectClass) parse(raw string) error {
 	// parseMR wraps the antlr4512 ObjectClass parser/lexer
-	mp, err := parseOC(raw)
+	mp, err := parseOC(qdEscapes(raw))
 	if err == nil {
 		// We received the parsed data from ANTLR (mp).
 		// Now we need to marshal it into the receiver.
@@ -139,6 +139,12 @@ func (r ObjectClass) setOID(x string) {
 	}
 }
 
+func (r ObjectClass) resetRendering() {
+	if !r.IsZero() {
+		r.objectClass.rendering.reset()
+	}
+}
+
 func (r ObjectClass) macro() (m []string) {
 	if !r.IsZero() {
 		m = r.objectClass.Macro
@@ -164,6 +170,11 @@ func (r ObjectClass) SetName(x ...string) ObjectClass {
 }
 
 func (r *objectClass) setName(x ...string) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+	defer rekey(r.schema, ObjectClass{r})()
+
 	for i := 0; i < len(x); i++ {
 		r.Name.Push(x[i])
 	}
@@ -185,6 +196,10 @@ func (r ObjectClass) SetData(x any) ObjectClass {
 }
 
 func (r *objectClass) setData(x any) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	r.data = x
 }
 
@@ -215,6 +230,10 @@ func (r ObjectClass) SetExtension(x string, xstrs ...string) ObjectClass {
 }
 
 func (r *objectClass) setExtension(x string, xstrs ...string) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -239,6 +258,10 @@ func (r ObjectClass) SetKind(k any) ObjectClass {
 }
 
 func (r *objectClass) setKind(k any) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	switch tv := k.(type) {
 	case string:
 		switch lc(tv) {
@@ -278,6 +301,10 @@ func (r ObjectClass) SetMust(m ...any) ObjectClass {
 }
 
 func (r *objectClass) setMust(m ...any) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -312,6 +339,10 @@ func (r ObjectClass) SetMay(m ...any) ObjectClass {
 }
 
 func (r *objectClass) setMay(m ...any) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -347,6 +378,10 @@ func (r ObjectClass) SetSuperClass(x ...any) ObjectClass {
 }
 
 func (r *objectClass) setSuperClass(x ...any) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	var err error
 	for i := 0; i < len(x) && err == nil; i++ {
 		var sup ObjectClass
@@ -399,6 +434,9 @@ func (r *objectClass) replace(x ObjectClass) {
 		return
 	}
 
+	old := *r
+	defer rekey(r.schema, ObjectClass{r})()
+
 	r.OID = x.objectClass.OID
 	r.Macro = x.objectClass.Macro
 	r.Name = x.objectClass.Name
@@ -411,8 +449,16 @@ func (r *objectClass) replace(x ObjectClass) {
 	r.Extensions = x.objectClass.Extensions
 	r.data = x.objectClass.data
 	r.schema = x.objectClass.schema
-	r.stringer = x.objectClass.stringer
+	r.stringer, r.rendering = copyStringer(x.objectClass.stringer, x.objectClass.rendering, r.prepareString)
 	r.data = x.objectClass.data
+
+	if member(old.schema, ObjectClass{r}) {
+		old.schema.notify(Event{
+			Kind: ReplaceEvent,
+			Old:  ObjectClass{&old},
+			New:  ObjectClass{r},
+		})
+	}
 }
 
 /*
@@ -605,6 +651,10 @@ func (r ObjectClass) SetObsolete() ObjectClass {
 }
 
 func (r *objectClass) setObsolete() {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -759,6 +809,10 @@ func (r ObjectClass) SetSchema(schema Schema) ObjectClass {
 }
 
 func (r *objectClass) setSchema(schema Schema) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -795,7 +849,7 @@ func (r ObjectClass) AllMust() (must AttributeTypes) {
 			sm := sup.index(i)
 			if sc := sm.AllMust(); !sc.IsZero() {
 				for j := 0; j < sc.len(); j++ {
-					must.push(sc.index(i))
+					must.push(sc.index(j))
 				}
 			}
 		}
@@ -836,7 +890,7 @@ func (r ObjectClass) AllMay() (may AttributeTypes) {
 			sm := sup.index(i)
 			if sc := sm.AllMay(); !sc.IsZero() {
 				for j := 0; j < sc.len(); j++ {
-					may.push(sc.index(i))
+					may.push(sc.index(j))
 				}
 			}
 		}
@@ -998,6 +1052,10 @@ func (r ObjectClass) SetNumericOID(id string) ObjectClass {
 }
 
 func (r *objectClass) setNumericOID(id string) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	if isNumericOID(id) {
 		if len(r.OID) == 0 {
 			r.OID = id
@@ -1063,22 +1121,21 @@ func (r ObjectClass) SetStringer(function ...Stringer) ObjectClass {
 }
 
 func (r *objectClass) setStringer(function ...Stringer) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	var stringer Stringer
 	if len(function) > 0 {
 		stringer = function[0]
 	}
 
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		}
+		// text/template op is deferred until first use
+		r.stringer, r.rendering = defaultStringer(r.prepareString)
 	} else {
 		r.stringer = stringer
+		r.rendering = nil
 	}
 }
 
@@ -1114,31 +1171,15 @@ to represent the receiver instance as a string using [text/template].
 */
 func (r *objectClass) prepareString() (str string, err error) {
 	buf := newBuf()
-	var kind string = `STRUCTURAL`
-	switch r.Kind {
-	case AbstractKind:
-		kind = `ABSTRACT`
-	case AuxiliaryKind:
-		kind = `AUXILIARY`
-	}
-
-	t := newTemplate(`objectClass`).
-		Funcs(funcMap(map[string]any{
-			`Kind`:         func() string { return kind },
-			`ExtensionSet`: r.Extensions.tmplFunc,
-			`MustLen`:      r.Must.len,
-			`MayLen`:       r.May.len,
-			`SuperLen`:     r.SuperClasses.len,
-			`Obsolete`:     func() bool { return r.Obsolete },
-		}))
+	t := newTemplate(`objectClass`).Funcs(funcMap(r.tmplFuncs()))
 
-	if t, err = t.Parse(objectClassTmpl); err == nil {
+	if t, err = t.Parse(r.schema.template(`objectClass`, objectClassTmpl)); err == nil {
 		if err = t.Execute(buf, struct {
 			Definition *objectClass
 			HIndent    string
 		}{
 			Definition: r,
-			HIndent:    hindent(r.schema.Options().Positive(HangingIndents)),
+			HIndent:    r.schema.Formatting().hindent(),
 		}); err == nil {
 			str = buf.String()
 		}
@@ -1147,6 +1188,31 @@ func (r *objectClass) prepareString() (str string, err error) {
 	return
 }
 
+/*
+tmplFuncs returns the template functions available to the text/template
+source used to render the receiver instance.  See [Schema.SetTemplate].
+*/
+func (r *objectClass) tmplFuncs() map[string]any {
+	var kind string = `STRUCTURAL`
+	switch r.Kind {
+	case AbstractKind:
+		kind = `ABSTRACT`
+	case AuxiliaryKind:
+		kind = `AUXILIARY`
+	}
+
+	f := r.schema.Formatting()
+	return f.funcs(r.Name, map[string]any{
+		`OID`:          func() string { return r.schema.macroOID(f, r.OID) },
+		`Kind`:         func() string { return kind },
+		`ExtensionSet`: r.Extensions.tmplFunc,
+		`MustLen`:      r.Must.len,
+		`MayLen`:       r.May.len,
+		`SuperLen`:     r.SuperClasses.len,
+		`Obsolete`:     func() bool { return r.Obsolete },
+	}, `NAME`, `DESC`, `OBSOLETE`, `SUP`, `KIND`, `MUST`, `MAY`)
+}
+
 /*
 Description returns the underlying (optional) descriptive text
 assigned to the receiver instance.
@@ -1173,6 +1239,10 @@ func (r ObjectClass) SetDescription(desc string) ObjectClass {
 }
 
 func (r *objectClass) setDescription(desc string) {
+	if !modify(ObjectClass{r}, r.rendering) {
+		return
+	}
+
 	if len(desc) < 3 {
 		return
 	}
@@ -1202,9 +1272,9 @@ toggle-based oid list stringer handler - do not exec directly.
 */
 func (r ObjectClasses) oIDsStringer(_ ...any) (present string) {
 	slice := r.index(0)
-	hd := slice.Schema().Options().Positive(HangingIndents)
+	f := slice.Schema().Formatting()
 	id := r.cast().ID()
-	if hd && id != `oc_oidlist` {
+	if f.MultiLine && id != `oc_oidlist` {
 		return r.oIDsStringerPretty(len(id))
 	}
 
@@ -1229,39 +1299,21 @@ This has no effect if the stack has only one member, producing something
 like:
 
 	top
-*/
-func (r ObjectClasses) oIDsStringerPretty(lead int) (present string) {
-	L := r.Len()
-	switch L {
-	case 0:
-		return
-	case 1:
-		present = r.Index(0).OID()
-		return
-	}
-
-	num := lead + 5
-	for idx := 0; idx < L; idx++ {
-		sl := r.Index(idx).OID()
-		if idx == 0 {
-			present += `( ` + sl + string(rune(10))
-			continue
-		}
 
-		for i := 0; i < num; i++ {
-			present += ` `
-		}
-
-		present += `$ ` + sl
-		if idx == L-1 {
-			present += ` )`
-			break // no newline on last line
+Should the LineWidth field of the relevant [Formatting] be set, members
+are instead packed onto as few lines as that width allows.
+*/
+func (r ObjectClasses) oIDsStringerPretty(lead int) string {
+	var f Formatting
+	var oids []string
+	for i := 0; i < r.len(); i++ {
+		if i == 0 {
+			f = r.index(i).Schema().Formatting()
 		}
-
-		present += string(rune(10))
+		oids = append(oids, r.index(i).OID())
 	}
 
-	return
+	return f.list(oids, lead)
 }
 
 /*
@@ -1374,7 +1426,7 @@ func (r ObjectClasses) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		pushAndNotify(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1405,12 +1457,21 @@ the principal identifier of an [ObjectClass] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Within the collections of a [Schema], lookups are satisfied by an index,
+and an id in macro form (e.g.: "nisSchema.1.0") is resolved using the
+[Macros] instance of the [Schema].
 */
 func (r ObjectClasses) Get(id string) ObjectClass {
 	return r.get(id)
 }
 
 func (r ObjectClasses) get(id string) (oc ObjectClass) {
+	if def, indexed := lookupDefinition(r.cast(), id); indexed {
+		oc, _ = def.(ObjectClass)
+		return
+	}
+
 	for i := 0; i < r.len() && oc.IsZero(); i++ {
 		if _oc := r.index(i); !_oc.IsZero() {
 			if _oc.objectClass.OID == id {
@@ -559,3 +559,41 @@ func TestObjectClass_codecov(t *testing.T) {
 	_ = def2.Replace(def) // will fail
 
 }
+
+/*
+TestObjectClass_AllMustAllMay verifies that all types inherited by way of
+a super class bearing more than one inherited type are honored.
+*/
+func TestObjectClass_AllMustAllMay(t *testing.T) {
+	sch := NewSchema()
+	for _, raw := range []string{
+		`( 1.3.6.1.4.1.56521.999.95.1 NAME 'inheritBase' SUP top ABSTRACT MUST ( cn $ sn ) MAY ( description $ seeAlso ) )`,
+		`( 1.3.6.1.4.1.56521.999.95.2 NAME 'inheritMiddle' SUP inheritBase ABSTRACT MUST uid MAY ou )`,
+		`( 1.3.6.1.4.1.56521.999.95.3 NAME 'inheritLeaf' SUP inheritMiddle STRUCTURAL MUST o MAY l )`,
+	} {
+		if err := sch.ParseObjectClass(raw); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+			return
+		}
+	}
+
+	oc := sch.ObjectClasses().Get(`inheritLeaf`)
+	for _, tc := range []struct {
+		label string
+		got   AttributeTypes
+		want  []string
+	}{
+		{`MUST`, oc.AllMust(), []string{`objectClass`, `cn`, `sn`, `uid`, `o`}},
+		{`MAY`, oc.AllMay(), []string{`description`, `seeAlso`, `ou`, `l`}},
+	} {
+		var names []string
+		for i := 0; i < tc.got.Len(); i++ {
+			names = append(names, tc.got.Index(i).Name())
+		}
+
+		if want, got := join(tc.want, ` `), join(names, ` `); want != got {
+			t.Errorf("%s failed [%s]:\nwant: %s\ngot:  %s", t.Name(), tc.label, want, got)
+			return
+		}
+	}
+}
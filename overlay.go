@@ -0,0 +1,150 @@
+package schemax
+
+/*
+overlay.go implements the layering of a writable Schema over a frozen base.
+*/
+
+import (
+	"github.com/JesseCoretta/go-stackage"
+)
+
+/*
+Overlay returns a new instance of [Schema] layered over a frozen snapshot
+(see [Schema.Snapshot]) of the receiver instance, which serves as the base
+of the return instance.  The base is never altered by way of the overlay.
+
+Overlays behave as follows:
+
+  - Lookups (e.g.: [AttributeTypes.Get]) consult the overlay first, and
+    fall through to the base if no match is found therein
+  - Pushes and parsed definitions land in the overlay, and may reference
+    definitions within the base.  A definition whose numeric OID (or rule
+    ID) is already present within the base is disregarded as a duplicate
+  - [Schema.Replace] shadows a base definition by adding the replacement
+    to the overlay; the base definition is left untouched.  Note that other
+    base definitions continue to reference the shadowed definition
+  - A [MatchingRuleUse] of the base that must be updated on account of a
+    new [AttributeType] (see [Schema.UpdateMatchingRuleUses]) is first
+    copied into the overlay
+  - Iteration (e.g.: [AttributeTypes.Len], [AttributeTypes.Index] and the
+    various Maps and String methods) concerns only the definitions within
+    the overlay, thus the overlay may be exported on its own
+
+The overlay bears a copy of the [Macros] instance, [Options] bit settings
+and DN of the receiver.  Any [Option] instances input in variadic form are
+set in addition to those copied.
+
+Many overlays may share a single base.  Should the receiver change after
+this method is called, the overlay will not reflect such changes.
+
+A zero instance of [Schema] is returned if the receiver is zero.
+*/
+func (r Schema) Overlay(o ...Option) (s Schema) {
+	if r.IsZero() {
+		return
+	}
+
+	base := r.Snapshot()
+	s = base.cloneEmpty()
+	for i := 0; i < len(o); i++ {
+		s.Options().Shift(o[i])
+	}
+	s.setBase(base)
+
+	return
+}
+
+/*
+Base returns the frozen base [Schema] instance of the receiver, or a zero
+instance if the receiver is not an overlay.  See [Schema.Overlay] for
+details.
+*/
+func (r Schema) Base() (base Schema) {
+	if !r.IsZero() {
+		base, _ = r.cast().Auxiliary()[`base`].(Schema)
+	}
+
+	return
+}
+
+/*
+IsOverlay returns a Boolean value indicative of the receiver instance being
+layered over a base [Schema].  See [Schema.Overlay] for details.
+*/
+func (r Schema) IsOverlay() bool {
+	return !r.Base().IsZero()
+}
+
+/*
+setBase associates the input base [Schema] with the receiver instance and
+its collections.
+*/
+func (r Schema) setBase(base Schema) {
+	r.cast().Auxiliary()[`base`] = base
+
+	bcols := base.collections()
+	for i, defs := range r.collections() {
+		setLookupBase(defs.cast(), bcols[i].cast())
+	}
+}
+
+/*
+shadow returns a Boolean value indicative of whether x was added to the
+receiver instance in order to shadow a [Definition] bearing the same
+identifier within the base.
+*/
+func (r Schema) shadow(x Definition) (shadowed bool) {
+	base := r.Base()
+	if base.IsZero() || r.index(x) != -1 {
+		return
+	}
+
+	idx := base.index(x)
+	if shadowed = idx != -1; !shadowed || !x.Compliant() {
+		return
+	}
+
+	old, _ := base.collection(x.Type()).cast().Index(idx)
+	setSchema(x, r)
+	pushLocal(r.collection(x.Type()).cast(), x)
+
+	r.notify(Event{
+		Kind: ReplaceEvent,
+		Old:  old.(Definition),
+		New:  x,
+	})
+
+	return
+}
+
+/*
+ownMatchingRuleUse returns mu if mu resides within the receiver instance.
+Should mu reside only within the base of the receiver, a copy of mu is
+pushed into the receiver and returned in its place, thus sparing the base
+from modification.
+*/
+func (r Schema) ownMatchingRuleUse(mu MatchingRuleUse) MatchingRuleUse {
+	if mu.IsZero() || !r.IsOverlay() || r.index(mu) != -1 {
+		return mu
+	}
+
+	cl := newCloner(r)
+	cl.root = mu.matchingRuleUse
+	mu = cl.matchingRuleUse(mu)
+	pushLocal(r.MatchingRuleUses().cast(), mu)
+
+	return mu
+}
+
+/*
+pushLocal pushes x into stk, an overlay collection, without regard for the
+presence of a [Definition] bearing the same identifier within the base of
+stk, which would otherwise cause the push policy of stk to reject x.
+*/
+func pushLocal(stk stackage.Stack, x any) {
+	l := collectionLookup(stk)
+	base := l.base
+	l.base = stackage.Stack{}
+	stk.Push(x)
+	l.base = base
+}
@@ -0,0 +1,160 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the layering of private definitions over a
+shared base [Schema].
+*/
+func ExampleSchema_Overlay() {
+	base := NewSchema()
+	overlay := base.Overlay()
+
+	if err := overlay.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.1
+		NAME 'privateAttr'
+		SUP name )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(overlay.AttributeTypes().Get(`privateAttr`).SuperType().Name())
+	fmt.Println(base.AttributeTypes().Contains(`privateAttr`))
+	fmt.Println(overlay.AttributeTypes().Len())
+	// Output:
+	// name
+	// false
+	// 1
+}
+
+func TestSchema_Overlay(t *testing.T) {
+	base := NewSchema()
+	overlay := base.Overlay(AllowOverride)
+
+	if !overlay.IsOverlay() || base.IsOverlay() || !overlay.Base().IsFrozen() {
+		t.Errorf("%s failed: unexpected overlay states", t.Name())
+		return
+	} else if overlay.Counters() != (Counters{}) {
+		t.Errorf("%s failed: overlay not empty", t.Name())
+		return
+	} else if _, found := overlay.Macros().Resolve(`nisSchema`); !found {
+		t.Errorf("%s failed: macros not copied", t.Name())
+		return
+	}
+
+	// lookups fall through to the base
+	for _, id := range []string{`cn`, `2.5.4.3`, `nisSchema.1.0`} {
+		if !overlay.AttributeTypes().Contains(id) {
+			t.Errorf("%s failed: %s not found in base", t.Name(), id)
+			return
+		}
+	}
+
+	// lookups fall through to the base, even if the
+	// relevant overlay collection is empty
+	dsBase := mySchema.Clone()
+	dsOverlay := dsBase.Overlay()
+	ds := dsBase.DITStructureRules().Index(0)
+	if dsOverlay.DITStructureRules().Len() != 0 {
+		t.Errorf("%s failed: overlay not empty", t.Name())
+		return
+	}
+	for _, id := range []any{ds.RuleID(), ds.ID(), ds.Name()} {
+		if got := dsOverlay.DITStructureRules().Get(id); got.IsZero() || got.RuleID() != ds.RuleID() {
+			t.Errorf("%s failed: structure rule %v not found in base", t.Name(), id)
+			return
+		}
+	}
+
+	// duplicates of base definitions are disregarded
+	overlay.ParseAttributeType(`( 2.5.4.3
+		NAME 'cn'
+		SUP name )`)
+	if overlay.AttributeTypes().Len() != 0 {
+		t.Errorf("%s failed: duplicate of base definition accepted", t.Name())
+		return
+	}
+
+	// pushes land in the overlay, and matching rule
+	// uses of the base are copied rather than altered
+	baseMU := overlay.Base().MatchingRuleUses().Get(`caseIgnoreMatch`)
+	applies := baseMU.Applies().Len()
+	if err := overlay.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.2
+		NAME 'overlayAttr'
+		EQUALITY caseIgnoreMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = overlay.UpdateMatchingRuleUses(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if baseMU.Applies().Len() != applies {
+		t.Errorf("%s failed: base matchingRuleUse altered", t.Name())
+		return
+	} else if mu := overlay.MatchingRuleUses().Get(`caseIgnoreMatch`); mu.Applies().Len() != applies+1 {
+		t.Errorf("%s failed: overlay matchingRuleUse not updated", t.Name())
+		return
+	} else if overlay.MatchingRuleUses().Len() != 1 || base.AttributeTypes().Contains(`overlayAttr`) {
+		t.Errorf("%s failed: unexpected overlay contents", t.Name())
+		return
+	}
+
+	// replacements shadow base definitions
+	gon := overlay.ObjectClasses().Get(`groupOfNames`)
+	overlay.Replace(overlay.NewObjectClass().
+		SetNumericOID(gon.NumericOID()).
+		SetName(`groupOfMembers`).
+		SetKind(gon.Kind()).
+		SetSuperClass(`top`).
+		SetMust(`cn`).
+		SetMay(`member`, `overlayAttr`).
+		SetStringer())
+
+	if shadow := overlay.ObjectClasses().Get(gon.NumericOID()); shadow.Name() != `groupOfMembers` {
+		t.Errorf("%s failed: base definition not shadowed", t.Name())
+		return
+	} else if gon.Name() != `groupOfNames` || !overlay.Base().ObjectClasses().Contains(`groupOfNames`) {
+		t.Errorf("%s failed: base definition altered", t.Name())
+		return
+	} else if overlay.ObjectClasses().Len() != 1 {
+		t.Errorf("%s failed: shadow not added to overlay", t.Name())
+		return
+	}
+
+	// clones, snapshots and transactions retain the base
+	cl := overlay.Clone()
+	if cl.Base() != overlay.Base() || cl.Counters() != overlay.Counters() {
+		t.Errorf("%s failed: clone did not retain base", t.Name())
+		return
+	} else if at := cl.AttributeTypes().Get(`overlayAttr`); at.Equality() != overlay.Base().MatchingRules().Get(`caseIgnoreMatch`) {
+		t.Errorf("%s failed: base definition copied by clone", t.Name())
+		return
+	}
+
+	if snap := overlay.Snapshot(); !snap.IsOverlay() || !snap.AttributeTypes().Contains(`cn`) {
+		t.Errorf("%s failed: snapshot did not retain base", t.Name())
+		return
+	}
+
+	tx := overlay.Begin()
+	tx.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.88.3
+		NAME 'overlayClass'
+		SUP top AUXILIARY
+		MAY overlayAttr )`)
+	if err := tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if !overlay.ObjectClasses().Contains(`overlayClass`) || !overlay.ObjectClasses().Contains(`person`) {
+		t.Errorf("%s failed: unexpected committed overlay contents", t.Name())
+		return
+	}
+
+	var zero Schema
+	if !zero.Overlay().IsZero() || zero.IsOverlay() {
+		t.Errorf("%s failed: expected zero instance", t.Name())
+	}
+}
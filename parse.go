@@ -23,7 +23,7 @@ ParseLDAPSyntax returns an error following an attempt to parse raw into an
 instance of [LDAPSyntax] and append it to the [Schema.LDAPSyntaxes] stack.
 */
 func (r Schema) ParseLDAPSyntax(raw string) error {
-	def, err := parseLS(raw)
+	def, err := parseLS(qdEscapes(raw))
 	if err == nil {
 		var _def LDAPSyntax
 		if _def, err = r.marshalLS(def); err == nil {
@@ -39,7 +39,7 @@ ParseMatchingRule returns an error following an attempt to parse raw into an
 instance of [MatchingRule] and append it to the [Schema.MatchingRules] stack.
 */
 func (r Schema) ParseMatchingRule(raw string) error {
-	def, err := parseMR(raw)
+	def, err := parseMR(qdEscapes(raw))
 	if err == nil {
 		var _def MatchingRule
 		if _def, err = r.marshalMR(def); err == nil {
@@ -55,7 +55,7 @@ ParseMatchingRuleUse returns an error following an attempt to parse raw into an
 instance of [MatchingRuleUse] and append it to the [Schema.MatchingRuleUses] stack.
 */
 func (r Schema) ParseMatchingRuleUse(raw string) error {
-	def, err := parseMU(raw)
+	def, err := parseMU(qdEscapes(raw))
 	if err == nil {
 		var _def MatchingRuleUse
 		if _def, err = r.marshalMU(def); err == nil {
@@ -71,7 +71,7 @@ ParseAttributeType returns an error following an attempt to parse raw into an
 instance of [AttributeType] and append it to the [Schema.AttributeTypes] stack.
 */
 func (r Schema) ParseAttributeType(raw string) error {
-	def, err := parseAT(raw)
+	def, err := parseAT(qdEscapes(raw))
 	if err == nil {
 		var _def AttributeType
 		if _def, err = r.marshalAT(def); err == nil {
@@ -87,7 +87,7 @@ ParseObjectClass returns an error following an attempt to parse raw into an
 instance of [ObjectClass] and append it to the [Schema.ObjectClasses] stack.
 */
 func (r Schema) ParseObjectClass(raw string) error {
-	def, err := parseOC(raw)
+	def, err := parseOC(qdEscapes(raw))
 	if err == nil {
 		var _def ObjectClass
 		if _def, err = r.marshalOC(def); err == nil {
@@ -103,7 +103,7 @@ ParseDITContentRule returns an error following an attempt to parse raw into an
 instance of [DITContentRule] and append it to the [Schema.DITContentRules] stack.
 */
 func (r Schema) ParseDITContentRule(raw string) error {
-	def, err := parseDC(raw)
+	def, err := parseDC(qdEscapes(raw))
 	if err == nil {
 		var _def DITContentRule
 		if _def, err = r.marshalDC(def); err == nil {
@@ -119,7 +119,7 @@ ParseNameForm returns an error following an attempt to parse raw into an
 instance of [NameForm] and append it to the [Schema.NameForms] stack.
 */
 func (r Schema) ParseNameForm(raw string) error {
-	def, err := parseNF(raw)
+	def, err := parseNF(qdEscapes(raw))
 	if err == nil {
 		var _def NameForm
 		if _def, err = r.marshalNF(def); err == nil {
@@ -135,7 +135,7 @@ ParseDITStructureRule returns an error following an attempt to parse raw into an
 instance of [DITStructureRule] and append it to the [Schema.DITStructureRules] stack.
 */
 func (r Schema) ParseDITStructureRule(raw string) error {
-	def, err := parseDS(raw)
+	def, err := parseDS(qdEscapes(raw))
 	if err == nil {
 		var _def DITStructureRule
 		if _def, err = r.marshalDS(def); err == nil {
@@ -210,19 +210,12 @@ func (r Schema) marshalLS(s antlr4512.LDAPSyntax) (def LDAPSyntax, err error) {
 	_def.schema = r
 	_def.Extensions.setDefinition(LDAPSyntax{_def})
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = LDAPSyntax{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = LDAPSyntax{_def}
 
 	return
 }
@@ -279,23 +272,16 @@ func (r Schema) marshalMR(s antlr4512.MatchingRule) (def MatchingRule, err error
 	_def.Syntax = syn
 	_def.Extensions.setDefinition(MatchingRule{_def})
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = MatchingRule{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = MatchingRule{_def}
 
 	return
 }
@@ -304,13 +290,50 @@ func (r Schema) incorporateMU(s antlr4512.MatchingRuleUses) (err error) {
 	for i := 0; i < len(s) && err == nil; i++ {
 		var def MatchingRuleUse
 		if def, err = r.marshalMU(s[i]); err == nil {
-			r.MatchingRuleUses().push(def)
+			if mu := r.MatchingRuleUses().get(def.NumericOID()); !mu.IsZero() {
+				r.mergeMatchingRuleUse(mu, def)
+			} else {
+				r.MatchingRuleUses().push(def)
+			}
 		}
 	}
 
 	return
 }
 
+/*
+mergeMatchingRuleUse folds the APPLIES values of src -- as well as its
+description and extensions, should mu lack them -- into mu, which bears
+the same OID and already resides within the receiver instance.  This is
+the case when definitions are parsed from several files, each of which
+produces a [MatchingRuleUse] for a given [MatchingRule].
+*/
+func (r Schema) mergeMatchingRuleUse(mu, src MatchingRuleUse) {
+	if src.IsZero() || r.IsFrozen() {
+		return
+	}
+
+	mu = r.ownMatchingRuleUse(mu)
+	for i := 0; i < src.Applies().len(); i++ {
+		if at := src.Applies().index(i); !mu.Applies().contains(at.NumericOID()) {
+			mu.matchingRuleUse.setApplies(at)
+		}
+	}
+
+	if len(mu.Description()) == 0 {
+		mu.matchingRuleUse.Desc = src.Description()
+	}
+
+	if mu.Extensions().len() == 0 {
+		for i := 0; i < src.Extensions().len(); i++ {
+			ext := src.Extensions().index(i)
+			mu.Extensions().Set(ext.XString, ext.Values.List()...)
+		}
+	}
+
+	mu.matchingRuleUse.rendering.reset()
+}
+
 func (r Schema) marshalMU(s antlr4512.MatchingRuleUse) (def MatchingRuleUse, err error) {
 	if !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
@@ -342,23 +365,16 @@ func (r Schema) marshalMU(s antlr4512.MatchingRuleUse) (def MatchingRuleUse, err
 		_def.Applies.push(at)
 	}
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = MatchingRuleUse{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = MatchingRuleUse{_def}
 
 	return
 }
@@ -375,7 +391,7 @@ func (r Schema) incorporateAT(s antlr4512.AttributeTypes) (err error) {
 }
 
 func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err error) {
-	// try to resolve a macro only if no numeric OID is present.
+	// resolve a macro, if present, to a numeric OID.
 	if s.OID = handleMacro(r, s.Macro, s.OID); !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
 		return
@@ -434,24 +450,17 @@ func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err err
 	// marshal our intended attribute usage
 	_def.marshalUsage(s)
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	// Process and set any names
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = AttributeType{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = AttributeType{_def}
 
 	return
 }
@@ -514,7 +523,7 @@ func (r Schema) incorporateOC(s antlr4512.ObjectClasses) (err error) {
 }
 
 func (r Schema) marshalOC(s antlr4512.ObjectClass) (def ObjectClass, err error) {
-	// try to resolve a macro only if no numeric OID is present.
+	// resolve a macro, if present, to a numeric OID.
 	if s.OID = handleMacro(r, s.Macro, s.OID); !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
 		return
@@ -574,24 +583,17 @@ func (r Schema) marshalOC(s antlr4512.ObjectClass) (def ObjectClass, err error)
 		_def.SuperClasses.push(m)
 	}
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	// Process and set any names
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = ObjectClass{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = ObjectClass{_def}
 
 	return
 }
@@ -665,26 +667,19 @@ func (r Schema) marshalDC(s antlr4512.DITContentRule) (def DITContentRule, err e
 		_def.Aux.push(m)
 	}
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
 
-		def = DITContentRule{_def}
-		if !def.Compliant() {
-			err = ErrDefNonCompliant
-		}
+	def = DITContentRule{_def}
+	if !def.Compliant() {
+		err = ErrDefNonCompliant
 	}
 
 	return
@@ -741,23 +736,16 @@ func (r Schema) marshalNF(s antlr4512.NameForm) (def NameForm, err error) {
 		_def.May.push(m)
 	}
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = NameForm{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = NameForm{_def}
 
 	return
 }
@@ -806,23 +794,16 @@ func (r Schema) marshalDS(s antlr4512.DITStructureRule) (def DITStructureRule, e
 		_def.SuperRules.push(m)
 	}
 
-	// Marshal our extensions, taking our sorting preference into account
-	marshalExt(s.Extensions, _def.Extensions,
-		r.Options().Positive(SortExtensions))
+	// Marshal our extensions in name order
+	marshalExt(s.Extensions, _def.Extensions)
 
 	for _, name := range s.Name {
 		_def.Name.push(name)
 	}
 
-	str, err := _def.prepareString() // perform one-time text/template op
-	if err == nil {
-		// Save the stringer
-		_def.stringer = func() string {
-			// Return a preserved value.
-			return str
-		}
-		def = DITStructureRule{_def}
-	}
+	// Defer the text/template op until first use
+	_def.stringer, _def.rendering = defaultStringer(_def.prepareString)
+	def = DITStructureRule{_def}
 
 	return
 }
@@ -837,41 +818,31 @@ func sortList(list []string, sortBySlice bool) []string {
 }
 
 /*
-marshalExt funnels mext into ext with or without sorting.
+marshalExt funnels mext into ext in order of extension name, such that
+the result does not vary with the iteration order of mext.
 */
-func marshalExt(mext map[string][]string, ext Extensions, sortByXStr bool) {
-	if !sortByXStr {
-		for k, v := range mext {
-			ext.Set(k, v...)
-		}
-		return
-	}
-
-	// Get a list of keys from mext
+func marshalExt(mext map[string][]string, ext Extensions) {
 	keys := make([]string, 0, len(mext))
 	for k := range mext {
 		keys = append(keys, k)
 	}
-
-	// Sort keys alphabetically
 	sort.Strings(keys)
 
-	// Use new sorting scheme to influence
-	// Extension initialization within ext.
 	for _, k := range keys {
 		ext.Set(k, mext[k]...)
 	}
 }
 
+/*
+handleMacro returns the numeric OID expressed by macro m, if resolvable
+through the [Macros] of r, else o.  Resolution takes precedence over o,
+which may have been produced by the ANTLR parser without a separating
+period between the macro OID and suffix.
+*/
 func handleMacro(r Schema, m []string, o string) (resv string) {
-	if len(o) > 0 {
-		resv = o
-		return
-	}
-
-	if len(m) == 2 && len(o) == 0 {
-		mc, found := r.Macros().Resolve(m[0])
-		if found {
+	resv = o
+	if len(m) == 2 {
+		if mc, found := r.Macros().Resolve(m[0]); found {
 			resv = mc + `.` + m[1]
 		}
 	}
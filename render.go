@@ -0,0 +1,84 @@
+package schemax
+
+/*
+render.go implements deferred rendering of definition strings.
+*/
+
+import (
+	"sync/atomic"
+)
+
+/*
+rendering caches the string representation produced by the default
+[Stringer] of a definition.  The text/template operation is deferred
+until the string is first requested, and is repeated only following a
+change to the definition (see reset).
+
+Instances of this type are safe for concurrent use.
+*/
+type rendering struct {
+	str atomic.Pointer[string]
+}
+
+func newRendering() *rendering {
+	return &rendering{}
+}
+
+/*
+get returns the cached string, calling prepare to produce it if need be.
+Should prepare return an error, a zero string is returned and nothing is
+cached.
+*/
+func (r *rendering) get(prepare func() (string, error)) (s string) {
+	if r == nil {
+		return
+	}
+
+	if p := r.str.Load(); p != nil {
+		return *p
+	}
+
+	var err error
+	if s, err = prepare(); err == nil {
+		r.str.Store(&s)
+	}
+
+	return
+}
+
+/*
+reset discards the cached string, if any, such that the next call of get
+renders the definition anew.
+*/
+func (r *rendering) reset() {
+	if r != nil {
+		r.str.Store(nil)
+	}
+}
+
+/*
+defaultStringer returns the default [Stringer] of a definition alongside
+the *rendering instance in which its output is cached.  prepare shall be
+the prepareString method of the definition.
+*/
+func defaultStringer(prepare func() (string, error)) (Stringer, *rendering) {
+	rd := newRendering()
+	return func() string {
+		return rd.get(prepare)
+	}, rd
+}
+
+/*
+copyStringer returns the [Stringer] and *rendering instances to be used
+by a definition whose contents were copied from another.  A user-provided
+[Stringer] (indicated by a nil rd) is returned as-is, whereas the default
+[Stringer] is recreated by way of prepare, as neither it nor its cache may
+be shared between definitions.
+*/
+func copyStringer(stringer Stringer, rd *rendering, prepare func() (string, error)) (Stringer, *rendering) {
+	if rd == nil {
+		return stringer, nil
+	}
+
+	return defaultStringer(prepare)
+}
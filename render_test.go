@@ -0,0 +1,144 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates that the string representation of a definition
+reflects changes made by way of its Set methods.
+*/
+func ExampleAttributeType_String_afterChange() {
+	sch := NewSchema()
+	sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.77.1
+		NAME 'renderAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+
+	def := sch.AttributeTypes().Get(`renderAttr`)
+	fmt.Println(def)
+
+	def.SetDescription(`Rendered anew`)
+	fmt.Println(def)
+	// Output:
+	// ( 1.3.6.1.4.1.56521.999.77.1 NAME 'renderAttr' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )
+	// ( 1.3.6.1.4.1.56521.999.77.1 NAME 'renderAttr' DESC 'Rendered anew' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )
+}
+
+func TestRendering(t *testing.T) {
+	sch := NewSchema()
+	def := sch.AttributeTypes().Get(`cn`)
+
+	// nothing is rendered until requested
+	if def.attributeType.rendering.str.Load() != nil {
+		t.Errorf("%s failed: definition rendered during parsing", t.Name())
+		return
+	}
+
+	want := def.String()
+	if p := def.attributeType.rendering.str.Load(); p == nil || *p != want {
+		t.Errorf("%s failed: rendered string not cached", t.Name())
+		return
+	}
+
+	// ... and thereafter only upon request following a change
+	def.SetDescription(`Common Name`)
+	if def.attributeType.rendering.str.Load() != nil {
+		t.Errorf("%s failed: definition rendered prior to request", t.Name())
+		return
+	} else if def.String() == want {
+		t.Errorf("%s failed: change not rendered", t.Name())
+		return
+	}
+	want = def.String()
+
+	// clones render their own content
+	cl := sch.Clone().AttributeTypes().Get(`cn`)
+	cl.SetName(`clonedName`)
+	if def.String() != want || cl.String() == want {
+		t.Errorf("%s failed: rendering shared between clones", t.Name())
+		return
+	}
+
+	// user-provided stringers are honored, and survive replacement
+	custom := func() string { return `custom` }
+	def.SetStringer(custom)
+	if got := def.String(); got != `custom` {
+		t.Errorf("%s failed: want 'custom', got '%s'", t.Name(), got)
+		return
+	}
+
+	rep := NewAttributeType().SetSchema(sch).SetNumericOID(def.NumericOID())
+	rep.attributeType.replace(def)
+	if got := rep.String(); got != `custom` {
+		t.Errorf("%s failed: replacement lost stringer: got '%s'", t.Name(), got)
+		return
+	}
+
+	// reverting to the default stringer renders anew
+	def.SetStringer()
+	if got := def.String(); got != want {
+		t.Errorf("%s failed: want '%s', got '%s'", t.Name(), want, got)
+		return
+	}
+
+	// template errors are reported upon registration
+	bad := NewSchema()
+	if err := bad.SetTemplate(`attributeType`, `{{ .Definition.Bogus }}`); err == nil {
+		t.Errorf("%s failed: template error not reported", t.Name())
+		return
+	} else if bad.Template(`attributeType`) != attributeTypeTmpl {
+		t.Errorf("%s failed: bogus template registered", t.Name())
+		return
+	}
+
+	var zero *rendering
+	zero.reset()
+	if got := zero.get(nil); got != `` {
+		t.Errorf("%s failed: expected zero string from nil rendering", t.Name())
+	}
+}
+
+/*
+TestRendering_allocs verifies that parsing does not perform the text/template
+op, by comparing the allocations made when marshaling a parsed definition
+with those made when it is also rendered.
+*/
+func TestRendering_allocs(t *testing.T) {
+	sch := NewSchema()
+	mp, err := parseAT(`( 1.3.6.1.4.1.56521.999.79.1
+		NAME 'allocAttr'
+		DESC 'Allocation test'
+		EQUALITY caseIgnoreMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	parsed := testing.AllocsPerRun(50, func() {
+		sch.marshalAT(mp)
+	})
+	rendered := testing.AllocsPerRun(50, func() {
+		def, _ := sch.marshalAT(mp)
+		_ = def.String()
+	})
+
+	if parsed >= rendered {
+		t.Errorf("%s failed: definition rendered during parsing (%.0f allocs, %.0f with String)",
+			t.Name(), parsed, rendered)
+	}
+}
+
+func BenchmarkSchema_ParseAttributeType(b *testing.B) {
+	sch := NewSchema()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := sch.ParseAttributeType(fmt.Sprintf(`( 1.3.6.1.4.1.56521.999.78.%d
+			NAME 'benchRenderAttr%d'
+			SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`, i, i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,6 +4,10 @@ package schemax
 schema.go centralizes all schema operations within a single construct.
 */
 
+import (
+	"sync"
+)
+
 const (
 	ldapSyntaxesIndex      int = iota // 0
 	matchingRulesIndex                // 1
@@ -21,8 +25,20 @@ package-included definitions. See the internal directory
 contents for a complete manifest.
 
 [Option] instances may be input in variadic form.
+
+The package-included definitions are parsed only once per process for
+each distinct combination of [Option] values; all subsequent calls return
+an independent copy (see [Schema.Clone]) of the previously parsed result.
 */
-func NewSchema(o ...Option) (r Schema) {
+func NewSchema(o ...Option) Schema {
+	return builtinSchema(true, o...)
+}
+
+/*
+newSchema returns an instance of [Schema] containing ALL package-included
+definitions, parsed anew.
+*/
+func newSchema(o ...Option) (r Schema) {
 	r = initSchema(o...)
 	var err error
 
@@ -68,8 +84,19 @@ of other (dependent) definition types, namely [AttributeType]
 instances.
 
 [Option] instances may be input in variadic form.
+
+As with [NewSchema], the package-included definitions are parsed only once
+per process for each distinct combination of [Option] values.
 */
-func NewBasicSchema(o ...Option) (r Schema) {
+func NewBasicSchema(o ...Option) Schema {
+	return builtinSchema(false, o...)
+}
+
+/*
+newBasicSchema returns an instance of [Schema] containing the basic set of
+package-included definitions, parsed anew.
+*/
+func newBasicSchema(o ...Option) (r Schema) {
 	r = initSchema(o...)
 	var err error
 
@@ -105,21 +132,82 @@ func NewEmptySchema(o ...Option) (r Schema) {
 }
 
 /*
-initSchema returns an initialized instance of Schema.
+newOptions returns an instance of [Options] bearing the input [Option]
+values.
 */
-func initSchema(o ...Option) Schema {
-	opts := newOpts()
+func newOptions(o ...Option) (opts Options) {
+	opts = newOpts()
 	for i := 0; i < len(o); i++ {
 		opts.Shift(o[i])
 	}
 
-	return Schema(stackageList().
+	return
+}
+
+/*
+builtins contains the previously parsed instances of [Schema] returned
+by way of [NewSchema] and [NewBasicSchema], keyed by builtinKey. These
+instances are never returned directly, only cloned.
+*/
+var (
+	builtins      map[builtinKey]Schema = make(map[builtinKey]Schema)
+	builtinsMutex *sync.Mutex           = &sync.Mutex{}
+)
+
+type builtinKey struct {
+	full bool
+	opts int
+}
+
+/*
+builtinSchema returns a copy of the cached [Schema] bearing either the full
+or basic set of package-included definitions, parsing them first if need be.
+*/
+func builtinSchema(full bool, o ...Option) Schema {
+	return builtinTemplate(full, o...).Clone()
+}
+
+/*
+builtinTemplate returns the cached [Schema] bearing either the full or
+basic set of package-included definitions, parsing them first if need be.
+*/
+func builtinTemplate(full bool, o ...Option) (tmpl Schema) {
+	key := builtinKey{full: full, opts: newOptions(o...).cast().Int()}
+
+	builtinsMutex.Lock()
+	defer builtinsMutex.Unlock()
+
+	var found bool
+	if tmpl, found = builtins[key]; !found {
+		if full {
+			tmpl = newSchema(o...)
+		} else {
+			tmpl = newBasicSchema(o...)
+		}
+		builtins[key] = tmpl
+	}
+
+	return
+}
+
+/*
+initSchema returns an initialized instance of Schema.
+*/
+func initSchema(o ...Option) (r Schema) {
+	opts := newOptions(o...)
+
+	obs := newObservers()
+
+	r = Schema(stackageList().
 		SetID(`cn=schema`).
 		SetCategory(`subschemaSubentry`).
 		SetDelimiter(rune(10)).
 		SetAuxiliary(map[string]any{
-			`macros`:  newMacros(),
-			`options`: opts,
+			`options`:   opts,
+			`observers`: obs,
+			`snapshots`: newSnapshots(),
+			`sources`:   newSources(),
+			`templates`: newTemplates(),
 		}).
 		Mutex().
 		Push(NewLDAPSyntaxes(), // 0
@@ -130,6 +218,14 @@ func initSchema(o ...Option) Schema {
 			NewDITContentRules(),    // 5
 			NewNameForms(),          // 6
 			NewDITStructureRules())) // 7
+
+	for _, defs := range r.collections() {
+		setObservers(defs.cast(), obs)
+		setCollectionSchema(defs.cast(), r)
+	}
+	r.setMacros(newMacros())
+
+	return
 }
 
 /*
@@ -208,15 +304,34 @@ types are matched using their respective numeric OIDs.
 
 All replacement [Definition] instances are subject to compliancy checks.
 
+Note that replacement occurs in place, and is not synchronized.  Where the
+receiver is in use by other goroutines, use [Tx.Replace] and have readers
+consult [Schema.Snapshot] instead.  This method has no effect upon a frozen
+[Schema].
+
+Where the receiver is an overlay (see [Schema.Overlay]) and the [Definition]
+to be replaced resides within the base, x is added to the receiver so as to
+shadow the base [Definition], which is left unaltered.
+
 This is a fluent method.
 */
 func (r Schema) Replace(x Definition) Schema {
-	if x.IsZero() {
+	if x.IsZero() || r.IsFrozen() {
 		return r
-	} else if !r.Options().Positive(AllowOverride) {
+	} else if !r.Options().Positive(AllowOverride) || r.shadow(x) {
 		return r
 	}
 
+	r.replace(x)
+
+	return r
+}
+
+/*
+replace overrides the [Definition] identified by x without regard for
+the [AllowOverride] option.
+*/
+func (r Schema) replace(x Definition) {
 	tmap := map[string]func(){
 		`ldapSyntax`: func() {
 			orig := r.LDAPSyntaxes().Get(x.NumericOID())
@@ -255,8 +370,6 @@ func (r Schema) Replace(x Definition) Schema {
 	if fn, ok := tmap[x.Type()]; ok {
 		fn()
 	}
-
-	return r
 }
 
 /*
@@ -266,11 +379,209 @@ func (r Schema) IsZero() bool {
 	return r.cast().IsZero()
 }
 
+/*
+Compliant returns a Boolean value indicative of the receiver instance
+being fully compliant, in that:
+
+  - All [Definition] instances within all collections are compliant
+  - All [Definition] instances referenced by any other [Definition]
+    (e.g.: an [AttributeType] within an [ObjectClass] MUST clause)
+    reside within the receiver instance
+*/
+func (r Schema) Compliant() bool {
+	if r.IsZero() {
+		return false
+	}
+
+	for _, defs := range r.collections() {
+		if !defs.Compliant() {
+			return false
+		}
+	}
+
+	return r.resolvable()
+}
+
+/*
+resolvable returns a Boolean value indicative of whether all references
+made by all [Definition] instances within the receiver instance resolve
+to [Definition] instances also present within the receiver instance.
+*/
+func (r Schema) resolvable() bool {
+	has := func(defs Definitions, def Definition) bool {
+		return def.IsZero() || defs.Contains(def.NumericOID())
+	}
+
+	hasATs := func(ats AttributeTypes) bool {
+		for i := 0; i < ats.len(); i++ {
+			if !has(r.AttributeTypes(), ats.index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	hasOCs := func(ocs ObjectClasses) bool {
+		for i := 0; i < ocs.len(); i++ {
+			if !has(r.ObjectClasses(), ocs.index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := 0; i < r.MatchingRules().len(); i++ {
+		mr := r.MatchingRules().index(i)
+		if !has(r.LDAPSyntaxes(), mr.matchingRule.Syntax) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.AttributeTypes().len(); i++ {
+		at := r.AttributeTypes().index(i)
+		if !(has(r.AttributeTypes(), at.attributeType.SuperType) &&
+			has(r.MatchingRules(), at.attributeType.Equality) &&
+			has(r.MatchingRules(), at.attributeType.Ordering) &&
+			has(r.MatchingRules(), at.attributeType.Substring) &&
+			has(r.LDAPSyntaxes(), at.attributeType.Syntax)) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.MatchingRuleUses().len(); i++ {
+		mu := r.MatchingRuleUses().index(i)
+		if !(has(r.MatchingRules(), mu.matchingRuleUse.OID) &&
+			hasATs(mu.matchingRuleUse.Applies)) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.ObjectClasses().len(); i++ {
+		oc := r.ObjectClasses().index(i)
+		if !(hasOCs(oc.objectClass.SuperClasses) &&
+			hasATs(oc.objectClass.Must) &&
+			hasATs(oc.objectClass.May)) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.DITContentRules().len(); i++ {
+		dc := r.DITContentRules().index(i)
+		if !(has(r.ObjectClasses(), dc.dITContentRule.OID) &&
+			hasOCs(dc.dITContentRule.Aux) &&
+			hasATs(dc.dITContentRule.Must) &&
+			hasATs(dc.dITContentRule.May) &&
+			hasATs(dc.dITContentRule.Not)) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.NameForms().len(); i++ {
+		nf := r.NameForms().index(i)
+		if !(has(r.ObjectClasses(), nf.nameForm.Structural) &&
+			hasATs(nf.nameForm.Must) &&
+			hasATs(nf.nameForm.May)) {
+			return false
+		}
+	}
+
+	for i := 0; i < r.DITStructureRules().len(); i++ {
+		ds := r.DITStructureRules().index(i)
+		if !has(r.NameForms(), ds.dITStructureRule.Form) {
+			return false
+		}
+
+		sup := ds.dITStructureRule.SuperRules
+		for j := 0; j < sup.len(); j++ {
+			if r.DITStructureRules().get(sup.index(j).RuleID()).IsZero() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+/*
+collections returns all collections within the receiver instance, in
+order of their respective index within the receiver.
+*/
+func (r Schema) collections() []Definitions {
+	return []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	}
+}
+
+/*
+collection returns the collection within the receiver instance which
+stores [Definition] instances of the named type, e.g.: "attributeType".
+*/
+func (r Schema) collection(typ string) (defs Definitions) {
+	switch typ {
+	case `ldapSyntax`:
+		defs = r.LDAPSyntaxes()
+	case `matchingRule`:
+		defs = r.MatchingRules()
+	case `attributeType`:
+		defs = r.AttributeTypes()
+	case `matchingRuleUse`:
+		defs = r.MatchingRuleUses()
+	case `objectClass`:
+		defs = r.ObjectClasses()
+	case `dITContentRule`:
+		defs = r.DITContentRules()
+	case `nameForm`:
+		defs = r.NameForms()
+	case `dITStructureRule`:
+		defs = r.DITStructureRules()
+	}
+
+	return
+}
+
+/*
+index returns the integer index at which the [Definition] identified by
+x resides within the appropriate collection of the receiver instance.
+Identification is based upon the numeric OID, or the rule ID in the case
+of a [DITStructureRule].  A value of -1 is returned if not found.
+*/
+func (r Schema) index(x Definition) int {
+	defs := r.collection(x.Type())
+	if defs == nil {
+		return -1
+	}
+
+	for i := 0; i < defs.Len(); i++ {
+		slice, _ := defs.cast().Index(i)
+		switch tv := slice.(type) {
+		case DITStructureRule:
+			if tv.RuleID() == x.(DITStructureRule).RuleID() {
+				return i
+			}
+		case Definition:
+			if tv.NumericOID() == x.NumericOID() {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
 /*
 Counters returns an instance of [Counters] bearing the current number
 of definitions by category.
 
-The return instance is merely a snapshot in time and is NOT thread-safe.
+The return instance is merely a point-in-time tally.  For consistent
+results in the presence of concurrent writers, call this method upon an
+instance obtained using [Schema.Snapshot].
 */
 func (r Schema) Counters() Counters {
 	return Counters{
@@ -291,12 +602,18 @@ usable schema definitions. This method operates similarly to the
 [Schema.ParseFile] method, except this method expects "pre-read" raw
 definition bytes rather than a filesystem path leading to such content.
 
+Macros registered within the receiver instance may be referenced by the
+definitions within raw.  Macros declared within raw by way of OpenLDAP
+"objectidentifier" statements are registered within the receiver.
+
 This method wraps the [antlr4512.Schema.ParseRaw] method.
 */
 func (r Schema) ParseRaw(raw []byte) (err error) {
 	s := new4512Schema()
-	if err = s.ParseRaw(raw); err == nil {
+	r.seedMacros(s.OM)
+	if err = s.ParseRaw([]byte(qdEscapes(string(raw)))); err == nil {
 		// begin second phase
+		r.adoptMacros(s.OM)
 		err = r.incorporate(s)
 	}
 
@@ -308,16 +625,14 @@ ParseFile returns an error following an attempt to parse file. Only
 files ending in ".schema" will be considered, however submission of
 non-qualifying files shall not produce an error.
 
-This method wraps the [antlr4512.Schema.ParseFile] method.
-*/
-func (r Schema) ParseFile(file string) (err error) {
-	s := new4512Schema()
-	if err = s.ParseFile(file); err == nil {
-		// begin second phase
-		err = r.incorporate(s)
-	}
+The file from which each definition was parsed is recorded; see the
+[Schema.SourceFile] method.  Macros declared within the file by way of
+OpenLDAP "objectidentifier" statements are registered within the receiver.
 
-	return
+This method wraps the [antlr4512.Schema.ParseRaw] method.
+*/
+func (r Schema) ParseFile(file string) error {
+	return r.parseFile(file, nil)
 }
 
 /*
@@ -327,14 +642,11 @@ indefinitely.  Files encountered will only be read if their name
 ends in ".schema", at which point their contents are read into
 bytes, processed using ANTLR and written to the receiver instance.
 
-This method wraps the [antlr4512.Schema.ParseDirectory] method.
+Files are parsed one at a time in lexical order, as with successive
+calls of [Schema.ParseFile].  Macros declared within a file remain
+available to those files which follow it.  The file from which each
+definition was parsed is recorded; see [Schema.SourceFile].
 */
-func (r Schema) ParseDirectory(dir string) (err error) {
-	s := new4512Schema()
-	if err = s.ParseDirectory(dir); err == nil {
-		// begin second phase
-		err = r.incorporate(s)
-	}
-
-	return
+func (r Schema) ParseDirectory(dir string) error {
+	return r.parseDirectory(dir)
 }
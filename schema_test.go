@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/JesseCoretta/go-antlr4512"
@@ -474,6 +475,64 @@ func TestSchema_ParseFileAndDirectory(t *testing.T) {
 	_ = mySchema.ParseDirectory(bogusName)
 }
 
+func TestNewSchema_builtins(t *testing.T) {
+	one, two := NewSchema(), NewSchema()
+	if one == two || one.Counters() != two.Counters() {
+		t.Errorf("%s failed: copies not independent or not identical", t.Name())
+		return
+	}
+
+	cn := one.AttributeTypes().Get(`cn`)
+	if cn.attributeType == two.AttributeTypes().Get(`cn`).attributeType {
+		t.Errorf("%s failed: definitions shared between instances", t.Name())
+		return
+	} else if cn.SuperType().Schema() != one {
+		t.Errorf("%s failed: references not bound to copy", t.Name())
+		return
+	}
+
+	one.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.33.1
+		NAME 'builtinAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	if NewSchema().AttributeTypes().Contains(`builtinAttr`) {
+		t.Errorf("%s failed: cached schema altered", t.Name())
+		return
+	}
+
+	// options influencing the parsing process yield
+	// distinct results.
+	hung := NewSchema(HangingIndents).ObjectClasses().Get(`person`)
+	flat := NewSchema().ObjectClasses().Get(`person`)
+	if hung.String() == flat.String() {
+		t.Errorf("%s failed: options disregarded", t.Name())
+		return
+	} else if !NewSchema(HangingIndents).Options().Positive(HangingIndents) {
+		t.Errorf("%s failed: options not preserved", t.Name())
+		return
+	}
+
+	if basic := NewBasicSchema(); basic.Counters().AT != 0 || basic.Counters().LS == 0 {
+		t.Errorf("%s failed: unexpected basic schema counters %#v", t.Name(), basic.Counters())
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewSchema(SortExtensions).AttributeTypes().Get(`cn`)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkNewSchema(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewSchema()
+	}
+}
+
 func TestLoads_codecov(t *testing.T) {
 	coolSchema := NewEmptySchema()
 	coolSchema.LoadRFC4517Syntaxes()
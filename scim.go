@@ -0,0 +1,492 @@
+package schemax
+
+/*
+scim.go implements the export of definitions as SCIM 2.0 (RFC 7643) schema
+resources, as well as the mapping of SCIM core resource attributes to the
+attribute types of a Schema.
+*/
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+/*
+scimSchemaDocument is the SCIM schema resource form of an [ObjectClass],
+per Section 7 of RFC 7643.
+*/
+type scimSchemaDocument struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Attributes  []scimAttribute `json:"attributes"`
+	Meta        scimMeta        `json:"meta"`
+}
+
+/*
+scimMeta is the "meta" complex attribute of a SCIM schema resource.
+*/
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+/*
+scimAttribute is the SCIM attribute definition form of an [AttributeType],
+per Section 7 of RFC 7643.
+*/
+type scimAttribute struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MultiValued bool   `json:"multiValued"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	CaseExact   bool   `json:"caseExact"`
+	Mutability  string `json:"mutability"`
+	Returned    string `json:"returned"`
+	Uniqueness  string `json:"uniqueness"`
+}
+
+/*
+scimSyntaxes maps the numeric OIDs of RFC 4517 syntaxes to SCIM attribute
+data types.  Syntaxes not present are treated as strings.
+*/
+var scimSyntaxes = map[string]string{
+	`1.3.6.1.4.1.1466.115.121.1.5`:  `binary`,   // Binary
+	`1.3.6.1.4.1.1466.115.121.1.7`:  `boolean`,  // Boolean
+	`1.3.6.1.4.1.1466.115.121.1.8`:  `binary`,   // Certificate
+	`1.3.6.1.4.1.1466.115.121.1.9`:  `binary`,   // Certificate List
+	`1.3.6.1.4.1.1466.115.121.1.10`: `binary`,   // Certificate Pair
+	`1.3.6.1.4.1.1466.115.121.1.24`: `dateTime`, // Generalized Time
+	`1.3.6.1.4.1.1466.115.121.1.27`: `integer`,  // Integer
+	`1.3.6.1.4.1.1466.115.121.1.28`: `binary`,   // JPEG
+	`1.3.6.1.4.1.1466.115.121.1.40`: `binary`,   // Octet String
+	`1.3.6.1.4.1.1466.115.121.1.53`: `dateTime`, // UTC Time
+}
+
+/*
+scimCaseExact contains the numeric OIDs of RFC 4517 equality matching
+rules which are sensitive to case.
+*/
+var scimCaseExact = map[string]bool{
+	`2.5.13.5`:                   true, // caseExactMatch
+	`1.3.6.1.4.1.1466.109.114.1`: true, // caseExactIA5Match
+	`2.5.13.16`:                  true, // bitStringMatch
+	`2.5.13.17`:                  true, // octetStringMatch
+}
+
+/*
+SCIMSchema returns a SCIM schema resource (see Section 7 of RFC 7643)
+describing the receiver instance, alongside an error.  The resource
+bears one attribute per [AttributeType] allowed by the receiver --
+including those inherited from its super classes (see [ObjectClass.AllMust]
+and [ObjectClass.AllMay]) -- in the manner described by
+[AttributeType.SCIMAttribute].  Attributes required by the receiver are
+marked as such.
+
+The id of the resource is set to id, which should be a URN.  If id is a
+zero string, "urn:oid:" followed by the numeric OID of the receiver is
+used instead.
+
+An error is returned if the receiver is zero.
+*/
+func (r ObjectClass) SCIMSchema(id string) (doc []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	if len(id) == 0 {
+		id = `urn:oid:` + r.NumericOID()
+	}
+
+	ss := scimSchemaDocument{
+		ID:          id,
+		Name:        docLabel(r),
+		Description: r.Description(),
+		Meta: scimMeta{
+			ResourceType: `Schema`,
+			Location:     `/v2/Schemas/` + id,
+		},
+	}
+
+	must := r.AllMust()
+	for i := 0; i < must.Len(); i++ {
+		sa := must.Index(i).scimAttribute()
+		sa.Required = true
+		ss.Attributes = append(ss.Attributes, sa)
+	}
+
+	may := r.AllMay()
+	for i := 0; i < may.Len(); i++ {
+		if at := may.Index(i); !must.Contains(at.NumericOID()) {
+			ss.Attributes = append(ss.Attributes, at.scimAttribute())
+		}
+	}
+
+	doc, err = json.MarshalIndent(ss, ``, `  `)
+
+	return
+}
+
+/*
+SCIMAttribute returns a SCIM attribute definition (see Section 7 of RFC
+7643) describing the receiver instance, alongside an error.  The fields
+of the definition are derived as follows:
+
+  - "name" is the principal name (or numeric OID) of the receiver
+  - "type" is derived from the effective syntax of the receiver (see
+    [AttributeType.EffectiveSyntax]), e.g.: "integer" for Integer values,
+    "boolean" for Boolean values, "dateTime" for Generalized Time and UTC
+    Time values and "binary" for Octet String and similar values, else
+    "string"
+  - "multiValued" is the inverse of [AttributeType.SingleValue]
+  - "caseExact" is true if the effective equality rule of the receiver
+    (see [AttributeType.EffectiveEquality]) is sensitive to case, such as
+    caseExactMatch or octetStringMatch
+  - "mutability" is "readOnly" if [AttributeType.NoUserModification]
+    returns true, else "readWrite" (or "writeOnly" in the case of the
+    RFC 4519 userPassword type, for which "returned" is also "never")
+
+The "required" field is always false; see [ObjectClass.SCIMSchema].  An
+error is returned if the receiver is zero.
+*/
+func (r AttributeType) SCIMAttribute() (doc []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	doc, err = json.MarshalIndent(r.scimAttribute(), ``, `  `)
+
+	return
+}
+
+func (r AttributeType) scimAttribute() (sa scimAttribute) {
+	sa = scimAttribute{
+		Name:        docLabel(r),
+		Type:        `string`,
+		MultiValued: !r.SingleValue(),
+		Description: r.Description(),
+		CaseExact:   scimCaseExact[r.EffectiveEquality().NumericOID()],
+		Mutability:  `readWrite`,
+		Returned:    `default`,
+		Uniqueness:  `none`,
+	}
+
+	if typ, found := scimSyntaxes[r.EffectiveSyntax().NumericOID()]; found {
+		sa.Type = typ
+	}
+
+	if r.NoUserModification() {
+		sa.Mutability = `readOnly`
+	} else if r.NumericOID() == `2.5.4.35` {
+		// userPassword
+		sa.Mutability = `writeOnly`
+		sa.Returned = `never`
+	}
+
+	return
+}
+
+/*
+SCIMMapping is a declarative mapping of the attributes of a SCIM core
+resource, such as "User" or "Group", to the [AttributeType] instances
+of entries bearing a particular [ObjectClass].
+
+See [NewSCIMUserMapping] and [NewSCIMGroupMapping] for ready-made
+instances, and [SCIMMapping.Validate] for validation against a [Schema].
+*/
+type SCIMMapping struct {
+	// Resource is the name of the SCIM resource type, i.e.: "User" or
+	// "Group".
+	Resource string
+
+	// ObjectClass is the name or numeric OID of the structural class of
+	// entries representing the resource, e.g.: "inetOrgPerson".
+	ObjectClass string
+
+	// Auxiliary contains the names or numeric OIDs of any auxiliary
+	// classes also borne by such entries.
+	Auxiliary []string
+
+	// Attributes maps SCIM attribute paths (see Section 3.10 of RFC 7644)
+	// to the names or numeric OIDs of attribute types.  A path may bear
+	// a value filter, e.g.: `phoneNumbers[type eq "mobile"].value`, in
+	// which case it is considered singular.  Attributes of the enterprise
+	// User extension are expressed using their fully-qualified URNs.
+	Attributes map[string]string
+}
+
+/*
+scimEnterpriseUser is the schema URN of the enterprise User extension per
+Section 4.3 of RFC 7643.
+*/
+const scimEnterpriseUser = `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User`
+
+/*
+scimCoreAttributes maps SCIM core resource types to their top-level
+attributes per Section 4 of RFC 7643, each of which is associated with a
+Boolean value indicative of whether it is multi-valued.
+*/
+var scimCoreAttributes = map[string]map[string]bool{
+	`User`: {
+		`id`: false, `externalId`: false, `userName`: false, `name`: false,
+		`displayName`: false, `nickName`: false, `profileUrl`: false,
+		`title`: false, `userType`: false, `preferredLanguage`: false,
+		`locale`: false, `timezone`: false, `active`: false, `password`: false,
+		`emails`: true, `phoneNumbers`: true, `ims`: true, `photos`: true,
+		`addresses`: true, `groups`: true, `entitlements`: true, `roles`: true,
+		`x509Certificates`:                     true,
+		scimEnterpriseUser + `:employeeNumber`: false,
+		scimEnterpriseUser + `:costCenter`:     false,
+		scimEnterpriseUser + `:organization`:   false,
+		scimEnterpriseUser + `:division`:       false,
+		scimEnterpriseUser + `:department`:     false,
+		scimEnterpriseUser + `:manager`:        false,
+	},
+	`Group`: {
+		`id`: false, `externalId`: false, `displayName`: false, `members`: true,
+	},
+}
+
+/*
+NewSCIMUserMapping returns a new [SCIMMapping] which maps the attributes
+of the SCIM core User resource, as well as those of the enterprise User
+extension, to the attribute types of the RFC 2798 inetOrgPerson class.
+*/
+func NewSCIMUserMapping() SCIMMapping {
+	return SCIMMapping{
+		Resource:    `User`,
+		ObjectClass: `inetOrgPerson`,
+		Attributes: map[string]string{
+			`userName`:                                `uid`,
+			`name.formatted`:                          `cn`,
+			`name.familyName`:                         `sn`,
+			`name.givenName`:                          `givenName`,
+			`displayName`:                             `displayName`,
+			`title`:                                   `title`,
+			`preferredLanguage`:                       `preferredLanguage`,
+			`password`:                                `userPassword`,
+			`emails.value`:                            `mail`,
+			`phoneNumbers[type eq "work"].value`:      `telephoneNumber`,
+			`phoneNumbers[type eq "mobile"].value`:    `mobile`,
+			`phoneNumbers[type eq "home"].value`:      `homePhone`,
+			`phoneNumbers[type eq "pager"].value`:     `pager`,
+			`phoneNumbers[type eq "fax"].value`:       `facsimileTelephoneNumber`,
+			`addresses[type eq "work"].formatted`:     `postalAddress`,
+			`addresses[type eq "work"].streetAddress`: `street`,
+			`addresses[type eq "work"].locality`:      `l`,
+			`addresses[type eq "work"].region`:        `st`,
+			`addresses[type eq "work"].postalCode`:    `postalCode`,
+			`addresses[type eq "home"].formatted`:     `homePostalAddress`,
+			`photos.value`:                            `jpegPhoto`,
+			`x509Certificates.value`:                  `userCertificate`,
+			scimEnterpriseUser + `:employeeNumber`:    `employeeNumber`,
+			scimEnterpriseUser + `:organization`:      `o`,
+			scimEnterpriseUser + `:department`:        `ou`,
+			scimEnterpriseUser + `:manager.value`:     `manager`,
+		},
+	}
+}
+
+/*
+NewSCIMGroupMapping returns a new [SCIMMapping] which maps the attributes
+of the SCIM core Group resource to the attribute types of the RFC 4519
+groupOfNames class.
+*/
+func NewSCIMGroupMapping() SCIMMapping {
+	return SCIMMapping{
+		Resource:    `Group`,
+		ObjectClass: `groupOfNames`,
+		Attributes: map[string]string{
+			`displayName`:   `cn`,
+			`members.value`: `member`,
+		},
+	}
+}
+
+/*
+Paths returns the SCIM attribute paths of the receiver instance in sorted
+order.
+*/
+func (r SCIMMapping) Paths() (paths []string) {
+	for path := range r.Attributes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return
+}
+
+/*
+AttributeType returns the name or numeric OID of the attribute type to
+which the SCIM attribute path is mapped, alongside a Boolean value
+indicative of success.  Case is not significant in the matching process.
+*/
+func (r SCIMMapping) AttributeType(path string) (at string, found bool) {
+	for _, p := range r.Paths() {
+		if found = eq(p, path); found {
+			at = r.Attributes[p]
+			break
+		}
+	}
+
+	return
+}
+
+/*
+SCIMPaths returns the sorted SCIM attribute paths which are mapped to the
+attribute type identified by at, which may be a name or numeric OID. Case
+is not significant in the matching process.
+
+If s is a non-zero [Schema], any alternate names or OID of at are also
+considered.
+*/
+func (r SCIMMapping) SCIMPaths(at string, s ...Schema) (paths []string) {
+	var def AttributeType
+	if len(s) > 0 && !s[0].IsZero() {
+		def = s[0].AttributeTypes().Get(at)
+	}
+
+	for _, path := range r.Paths() {
+		id := r.Attributes[path]
+		if eq(id, at) || (!def.IsZero() && def.NumericOID() == s[0].AttributeTypes().Get(id).NumericOID()) {
+			paths = append(paths, path)
+		}
+	}
+
+	return
+}
+
+/*
+Validate returns an error following an assessment of the receiver instance
+against s.  An error is returned should any of the following be true:
+
+  - the SCIM resource type is not "User" or "Group"
+  - any class is not present within s, or the structural class is not
+    STRUCTURAL, or any auxiliary class is not AUXILIARY
+  - any SCIM path does not refer to an attribute of the resource type
+    per RFC 7643, including those of the enterprise User extension
+  - any attribute type is not present within s, or is not allowed by
+    the classes of the receiver (see [ObjectClass.AllMust] and
+    [ObjectClass.AllMay])
+  - any multi-valued SCIM attribute is mapped to a single-valued type
+    (see [AttributeType.SingleValue])
+*/
+func (r SCIMMapping) Validate(s Schema) (err error) {
+	if s.IsZero() {
+		err = ErrNilInput
+		return
+	}
+
+	if _, found := scimCoreAttributes[r.Resource]; !found {
+		err = mkerr(ErrInvalidInput.Error() + `: unknown SCIM resource type '` + r.Resource + `'`)
+		return
+	}
+
+	var allowed AttributeTypes
+	if allowed, err = r.allowed(s); err != nil {
+		return
+	}
+
+	for _, path := range r.Paths() {
+		root, filtered := scimPathRoot(path)
+		multi, known := scimCoreAttribute(r.Resource, root)
+		if !known {
+			err = mkerr(ErrInvalidInput.Error() + `: unknown ` + r.Resource + ` attribute '` + path + `'`)
+			break
+		}
+
+		id := r.Attributes[path]
+		at := s.AttributeTypes().Get(id)
+		if at.IsZero() {
+			err = mkerr(ErrAttributeTypeNotFound.Error() + `: ` + id)
+		} else if !allowed.Contains(at.NumericOID()) {
+			err = mkerr(ErrInvalidInput.Error() + `: ` + id + ` not allowed by ` + r.ObjectClass)
+		} else if multi && !filtered && at.SingleValue() {
+			err = mkerr(ErrInvalidInput.Error() + `: multi-valued ` + path + ` mapped to single-valued ` + id)
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return
+}
+
+/*
+allowed returns the attribute types allowed by the classes of the receiver
+instance, alongside an error.
+*/
+func (r SCIMMapping) allowed(s Schema) (allowed AttributeTypes, err error) {
+	oc := s.ObjectClasses().Get(r.ObjectClass)
+	if oc.IsZero() {
+		err = mkerr(ErrObjectClassNotFound.Error() + `: ` + r.ObjectClass)
+		return
+	} else if oc.Kind() != StructuralKind {
+		err = mkerr(ErrInvalidInput.Error() + `: ` + r.ObjectClass + ` is not STRUCTURAL`)
+		return
+	}
+
+	allowed = oc.AllMust()
+	pushAttributeTypes(allowed, oc.AllMay())
+
+	for _, id := range r.Auxiliary {
+		aux := s.ObjectClasses().Get(id)
+		if aux.IsZero() {
+			err = mkerr(ErrObjectClassNotFound.Error() + `: ` + id)
+			break
+		} else if aux.Kind() != AuxiliaryKind {
+			err = mkerr(ErrInvalidInput.Error() + `: ` + id + ` is not AUXILIARY`)
+			break
+		}
+		pushAttributeTypes(allowed, aux.AllMust())
+		pushAttributeTypes(allowed, aux.AllMay())
+	}
+
+	return
+}
+
+/*
+scimPathRoot returns the top-level attribute name of the SCIM attribute
+path, alongside a Boolean value indicative of the presence of a value
+filter.  Attributes of the enterprise User extension retain their URN.
+*/
+func scimPathRoot(path string) (root string, filtered bool) {
+	var urn string
+	if pfx := scimEnterpriseUser + `:`; len(path) > len(pfx) && eq(path[:len(pfx)], pfx) {
+		urn, path = pfx, path[len(pfx):]
+	}
+
+	if idx := stridx(path, `[`); idx != -1 {
+		filtered = true
+		path = path[:idx]
+	}
+
+	if idx := stridx(path, `.`); idx != -1 {
+		path = path[:idx]
+	}
+
+	root = urn + path
+
+	return
+}
+
+/*
+scimCoreAttribute returns a Boolean value indicative of whether the named
+top-level attribute of the SCIM resource type is multi-valued, alongside
+a Boolean value indicative of its existence.  Case is not significant in
+the matching process.
+*/
+func scimCoreAttribute(resource, name string) (multi, known bool) {
+	for attr, m := range scimCoreAttributes[resource] {
+		if known = eq(attr, name); known {
+			multi = m
+			break
+		}
+	}
+
+	return
+}
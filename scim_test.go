@@ -0,0 +1,145 @@
+package schemax
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the export of the "cn" [AttributeType] as a
+SCIM attribute definition.
+*/
+func ExampleAttributeType_SCIMAttribute() {
+	doc, err := mySchema.AttributeTypes().Get(`cn`).SCIMAttribute()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s", doc)
+	// Output: {
+	//   "name": "cn",
+	//   "type": "string",
+	//   "multiValued": true,
+	//   "description": "RFC4519: common name(s) for which the entity is known by",
+	//   "required": false,
+	//   "caseExact": false,
+	//   "mutability": "readWrite",
+	//   "returned": "default",
+	//   "uniqueness": "none"
+	// }
+}
+
+/*
+This example demonstrates the validation of a customized SCIM User
+mapping against a [Schema], followed by a lookup of the SCIM attribute
+paths mapped to an [AttributeType].
+*/
+func ExampleSCIMMapping_Validate() {
+	m := NewSCIMUserMapping()
+	m.Attributes[`nickName`] = `displayName`
+
+	if err := m.Validate(mySchema); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(m.SCIMPaths(`displayName`))
+	// Output: [displayName nickName]
+}
+
+func TestObjectClass_SCIMSchema(t *testing.T) {
+	doc, err := mySchema.ObjectClasses().Get(`inetOrgPerson`).SCIMSchema(`urn:example:params:scim:schemas:inetOrgPerson`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	var ss scimSchemaDocument
+	if err = json.Unmarshal(doc, &ss); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if ss.Name != `inetOrgPerson` || ss.Meta.Location != `/v2/Schemas/urn:example:params:scim:schemas:inetOrgPerson` {
+		t.Errorf("%s failed: unexpected resource header:\n%s", t.Name(), doc)
+		return
+	}
+
+	attrs := make(map[string]scimAttribute)
+	for _, sa := range ss.Attributes {
+		attrs[sa.Name] = sa
+	}
+
+	for name, want := range map[string]scimAttribute{
+		`sn`:             {Type: `string`, MultiValued: true, Required: true},
+		`displayName`:    {Type: `string`},
+		`mail`:           {Type: `string`, MultiValued: true},
+		`uid`:            {Type: `string`, MultiValued: true},
+		`jpegPhoto`:      {Type: `binary`, MultiValued: true},
+		`userPassword`:   {Type: `binary`, MultiValued: true, CaseExact: true},
+		`employeeNumber`: {Type: `string`},
+	} {
+		got := attrs[name]
+		if got.Type != want.Type || got.MultiValued != want.MultiValued ||
+			got.Required != want.Required || got.CaseExact != want.CaseExact {
+			t.Errorf("%s failed [%s]:\n\twant: %#v\n\tgot:  %#v", t.Name(), name, want, got)
+			return
+		}
+	}
+
+	if doc, err = mySchema.ObjectClasses().Get(`person`).SCIMSchema(``); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = json.Unmarshal(doc, &ss); err != nil || ss.ID != `urn:oid:2.5.6.6` {
+		t.Errorf("%s failed: unexpected id %q (%v)", t.Name(), ss.ID, err)
+		return
+	}
+
+	if _, err = (ObjectClass{}).SCIMSchema(``); err == nil {
+		t.Errorf("%s failed: expected error for zero class", t.Name())
+	}
+}
+
+func TestSCIMMapping_Validate(t *testing.T) {
+	for _, m := range []SCIMMapping{NewSCIMUserMapping(), NewSCIMGroupMapping()} {
+		if err := m.Validate(mySchema); err != nil {
+			t.Errorf("%s failed [%s]: %v", t.Name(), m.Resource, err)
+			return
+		}
+	}
+
+	// A value filter renders a multi-valued path singular.
+	m := NewSCIMUserMapping()
+	m.Attributes[`emails[type eq "work"].value`] = `displayName`
+	if err := m.Validate(mySchema); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if at, found := m.AttributeType(`Name.FamilyName`); !found || at != `sn` {
+		t.Errorf("%s failed: want sn, got %q", t.Name(), at)
+		return
+	}
+
+	for idx, bad := range []func(*SCIMMapping){
+		func(m *SCIMMapping) { m.Resource = `Device` },
+		func(m *SCIMMapping) { m.ObjectClass = `bogusClass` },
+		func(m *SCIMMapping) { m.ObjectClass = `top` },
+		func(m *SCIMMapping) { m.Auxiliary = []string{`person`} },
+		func(m *SCIMMapping) { m.Attributes[`shoeSize`] = `cn` },
+		func(m *SCIMMapping) { m.Attributes[`nickName`] = `bogusType` },
+		func(m *SCIMMapping) { m.Attributes[`nickName`] = `c` },
+		func(m *SCIMMapping) { m.Attributes[`emails.value`] = `displayName` },
+	} {
+		m := NewSCIMUserMapping()
+		bad(&m)
+		if err := m.Validate(mySchema); err == nil {
+			t.Errorf("%s failed: expected error for case %d", t.Name(), idx)
+			return
+		}
+	}
+
+	if err := NewSCIMGroupMapping().Validate(Schema{}); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+	}
+}
@@ -0,0 +1,168 @@
+package schemax
+
+/*
+snapshot.go implements the concurrency model for Schema instances.
+*/
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+/*
+snapshots coordinates writers of a [Schema] and publishes the immutable
+snapshots consumed by its readers.
+*/
+type snapshots struct {
+	writer  *sync.Mutex // held by an active Tx
+	mutex   *sync.Mutex // guards publication and snapshot construction
+	current atomic.Pointer[snapshot]
+}
+
+/*
+snapshot is an immutable copy of a [Schema] alongside the change count
+of the originating [Schema] at the time the copy was made.
+*/
+type snapshot struct {
+	version uint64
+	schema  Schema
+}
+
+func newSnapshots() *snapshots {
+	return &snapshots{
+		writer: &sync.Mutex{},
+		mutex:  &sync.Mutex{},
+	}
+}
+
+/*
+Snapshot returns a frozen, immutable copy of the receiver instance which
+may be shared freely between any number of goroutines.
+
+The concurrency model of this package is as follows:
+
+  - Readers obtain a snapshot using this method, and perform all lookups
+    against it.  Snapshots are never altered once published, thus no
+    locking is required to read them.
+  - Writers stage changes using a [Tx] (see [Schema.Begin]).  Transactions
+    against the same [Schema] are serialized, and [Tx.Commit] publishes a
+    new snapshot atomically.  Readers holding a prior snapshot continue
+    to see the prior state.
+  - Changes made directly to the receiver (e.g.: [Schema.ParseAttributeType],
+    [Schema.Replace], [Macros.Set] or the various Set methods of its
+    definitions) are reflected by the next snapshot, but are NOT
+    synchronized; they must not occur while other goroutines call this
+    method or use the receiver.
+
+Provided no changes have occurred since the last call, this method returns
+the previously published snapshot without locking or copying.
+
+All write operations upon a snapshot (e.g.: pushes, [Schema.Replace],
+[Macros.Set] and [Schema.Begin]) are discarded, as are calls of the various
+Set methods of the [Definition] instances within.  Use [Schema.Clone] to
+obtain a modifiable copy of a snapshot.
+
+If the receiver is already frozen, it is returned as-is.  A zero instance
+of [Schema] is returned if the receiver is zero.
+*/
+func (r Schema) Snapshot() (s Schema) {
+	if r.IsZero() || r.IsFrozen() {
+		return r
+	}
+
+	sn := r.snapshots()
+	if curr := sn.current.Load(); curr != nil && curr.version == r.observers().version() {
+		return curr.schema
+	}
+
+	sn.mutex.Lock()
+	defer sn.mutex.Unlock()
+
+	// Another goroutine may have published
+	// a snapshot while we awaited the lock.
+	v := r.observers().version()
+	if curr := sn.current.Load(); curr != nil && curr.version == v {
+		return curr.schema
+	}
+
+	s = r.freeze()
+	sn.current.Store(&snapshot{version: v, schema: s})
+
+	return
+}
+
+/*
+IsFrozen returns a Boolean value indicative of the receiver instance
+being an immutable snapshot. See [Schema.Snapshot] for details.
+*/
+func (r Schema) IsFrozen() (frozen bool) {
+	if !r.IsZero() {
+		frozen, _ = r.cast().Auxiliary()[`frozen`].(bool)
+	}
+
+	return
+}
+
+/*
+freeze returns a read-only copy of the receiver instance.
+*/
+func (r Schema) freeze() (s Schema) {
+	s = r.Clone()
+
+	m := s.Macros()
+	m.ro = true
+	s.setMacros(m)
+	s.cast().Auxiliary()[`frozen`] = true
+
+	// Bring each index up to date, as readers
+	// of a snapshot must never need to do so.
+	for _, defs := range s.collections() {
+		defs.cast().ReadOnly(true)
+		collectionLookup(defs.cast()).table(defs.cast())
+	}
+	s.cast().ReadOnly(true)
+
+	return
+}
+
+/*
+modify prepares for a change to x by way of one of its Set methods, the
+cached rendering of which is rnd.
+
+Should x reside within a frozen [Schema], false is returned and the change
+must be discarded.  Should x reside within any other [Schema], the change
+is recorded such that any previously published snapshot is superseded.
+*/
+func modify(x Definition, rnd *rendering) bool {
+	if s := x.Schema(); member(s, x) {
+		if s.IsFrozen() {
+			return false
+		}
+		s.observers().modify(x)
+	}
+	rnd.reset()
+
+	return true
+}
+
+/*
+republish replaces the current snapshot of the receiver instance, if one
+was ever requested, with a copy reflecting the current state.  The caller
+must hold the mutex.
+*/
+func (r Schema) republish() {
+	if sn := r.snapshots(); sn.current.Load() != nil {
+		sn.current.Store(&snapshot{
+			version: r.observers().version(),
+			schema:  r.freeze(),
+		})
+	}
+}
+
+func (r Schema) snapshots() (sn *snapshots) {
+	if !r.IsZero() {
+		sn, _ = r.cast().Auxiliary()[`snapshots`].(*snapshots)
+	}
+
+	return
+}
@@ -0,0 +1,195 @@
+package schemax
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+/*
+This example demonstrates the use of immutable snapshots by readers while
+a writer publishes changes by way of a [Tx].
+*/
+func ExampleSchema_Snapshot() {
+	sch := NewSchema()
+	snap := sch.Snapshot()
+
+	tx := sch.Begin()
+	tx.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.66.1
+		NAME 'snapAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	if err := tx.Commit(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(snap.AttributeTypes().Contains(`snapAttr`))
+	fmt.Println(sch.Snapshot().AttributeTypes().Contains(`snapAttr`))
+	// Output:
+	// false
+	// true
+}
+
+func TestSchema_Snapshot(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+
+	snap := sch.Snapshot()
+	if !snap.IsFrozen() || sch.IsFrozen() {
+		t.Errorf("%s failed: unexpected frozen states", t.Name())
+		return
+	} else if snap.Counters() != sch.Counters() {
+		t.Errorf("%s failed: counters mismatch", t.Name())
+		return
+	} else if sch.Snapshot() != snap || snap.Snapshot() != snap {
+		t.Errorf("%s failed: unchanged schema produced new snapshot", t.Name())
+		return
+	}
+
+	// writes to a snapshot are discarded
+	before := snap.Counters()
+	snap.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.66.2
+		NAME 'frozenAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	snap.Macros().Set(`frozenMacro`, `1.3.6.1.4.1.56521.999.66`)
+	gon := snap.ObjectClasses().Get(`groupOfNames`)
+	snap.Replace(NewObjectClass().
+		SetSchema(snap).
+		SetNumericOID(gon.NumericOID()).
+		SetName(gon.Name()).
+		SetKind(gon.Kind()).
+		SetSuperClass(`top`).
+		SetMay(`member`))
+
+	if snap.Counters() != before {
+		t.Errorf("%s failed: frozen schema altered by push", t.Name())
+		return
+	} else if _, found := snap.Macros().Resolve(`frozenMacro`); found {
+		t.Errorf("%s failed: frozen macros altered", t.Name())
+		return
+	} else if gon.Must().Len() != 2 {
+		t.Errorf("%s failed: frozen definition replaced", t.Name())
+		return
+	} else if !snap.Begin().IsZero() {
+		t.Errorf("%s failed: transaction begun on frozen schema", t.Name())
+		return
+	}
+
+	// frozen definitions refuse writes
+	gon.SetName(`frozenName`).SetDescription(`frozen`)
+	if gon.Name() != `groupOfNames` || gon.Description() != `` ||
+		snap.ObjectClasses().Contains(`frozenName`) {
+		t.Errorf("%s failed: frozen definition altered", t.Name())
+		return
+	}
+
+	// clones of a snapshot are writable
+	if cl := snap.Clone(); cl.IsFrozen() {
+		t.Errorf("%s failed: clone of snapshot is frozen", t.Name())
+		return
+	}
+
+	// direct changes are reflected by the next snapshot
+	sch.Macros().Set(`liveMacro`, `1.3.6.1.4.1.56521.999.66`)
+	next := sch.Snapshot()
+	if next == snap {
+		t.Errorf("%s failed: stale snapshot returned", t.Name())
+		return
+	} else if _, found := next.Macros().Resolve(`liveMacro`); !found {
+		t.Errorf("%s failed: direct change not reflected", t.Name())
+		return
+	}
+
+	// changes to live definitions are reflected by the next snapshot
+	sch.AttributeTypes().Get(`cn`).SetDescription(`Common Name`)
+	if after := sch.Snapshot(); after == next {
+		t.Errorf("%s failed: stale snapshot returned after Set call", t.Name())
+		return
+	} else if after.AttributeTypes().Get(`cn`).Description() != `Common Name` ||
+		next.AttributeTypes().Get(`cn`).Description() == `Common Name` {
+		t.Errorf("%s failed: unexpected snapshot contents after Set call", t.Name())
+		return
+	}
+	next = sch.Snapshot()
+
+	// committed changes are published eagerly
+	tx := sch.Begin()
+	tx.Remove(tx.Schema().ObjectClasses().Get(`dcObject`))
+	if err := tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	sn := sch.snapshots()
+	if published := sn.current.Load().schema; published == next {
+		t.Errorf("%s failed: snapshot not published upon commit", t.Name())
+		return
+	} else if sch.Snapshot() != published {
+		t.Errorf("%s failed: published snapshot not returned", t.Name())
+		return
+	} else if published.ObjectClasses().Contains(`dcObject`) ||
+		!next.ObjectClasses().Contains(`dcObject`) {
+		t.Errorf("%s failed: unexpected snapshot contents", t.Name())
+		return
+	}
+
+	var zero Schema
+	if !zero.Snapshot().IsZero() || zero.IsFrozen() {
+		t.Errorf("%s failed: expected zero instance", t.Name())
+	}
+}
+
+/*
+TestSchema_Snapshot_concurrency exercises concurrent readers and writers,
+and is best run using the race detector (go test -race).
+*/
+func TestSchema_Snapshot_concurrency(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+
+	const writers, readers, rounds = 4, 16, 10
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				tx := sch.Begin()
+				err := tx.ParseAttributeType(fmt.Sprintf(`( 1.3.6.1.4.1.56521.999.67.%d.%d
+					NAME 'concurrentAttr%d-%d'
+					SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`, w, i, w, i))
+				if err != nil {
+					tx.Rollback()
+					t.Errorf("%s failed: %v", t.Name(), err)
+					return
+				} else if err = tx.Commit(); err != nil {
+					t.Errorf("%s failed: %v", t.Name(), err)
+					return
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds*writers; i++ {
+				snap := sch.Snapshot()
+				cn := snap.AttributeTypes().Get(`cn`)
+				if cn.IsZero() || cn.Schema() != snap || cn.SuperType().Schema() != snap {
+					t.Errorf("%s failed: inconsistent snapshot", t.Name())
+					return
+				}
+				_ = cn.String()
+				_ = snap.ObjectClasses().Get(`person`).Must().Len()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	want := NewSchema().AttributeTypes().Len() + writers*rounds
+	if got := sch.Snapshot().AttributeTypes().Len(); got != want {
+		t.Errorf("%s failed: want %d attributeTypes, got %d", t.Name(), want, got)
+	}
+}
@@ -0,0 +1,366 @@
+package schemax
+
+/*
+sql.go implements the generation of SQL DDL statements describing tables
+in which directory entries may be mirrored.
+*/
+
+/*
+SQLDialect describes the SQL dialect of the statements produced by way of
+the [Schema.SQL] method.
+*/
+type SQLDialect uint8
+
+const (
+	ANSISQL    SQLDialect = iota // ISO/ANSI SQL
+	PostgreSQL                   // PostgreSQL
+)
+
+/*
+SQLConfig contains the settings which govern the DDL statements produced
+by way of the [Schema.SQL] method.  A zero instance of this type is valid.
+*/
+type SQLConfig struct {
+	// Dialect is the SQL dialect of the statements.  The dialect
+	// governs the type of binary columns, as well as the form of
+	// suggested indexes.
+	Dialect SQLDialect
+
+	// ObjectClasses contains the names or numeric OIDs of the STRUCTURAL
+	// object classes for which tables are generated.  If empty, tables
+	// are generated for all STRUCTURAL object classes.
+	ObjectClasses []string
+
+	// Auxiliary contains the names or numeric OIDs of AUXILIARY object
+	// classes whose attribute types are added to every table, alongside
+	// those of the auxiliary classes permitted by the DITContentRule of
+	// each class, if any.
+	Auxiliary []string
+
+	// VarcharLength is the length of VARCHAR columns whose attribute type
+	// lacks a minimum upper bound (see AttributeType.MinimumUpperBounds).
+	// Zero implies 255.
+	VarcharLength uint
+}
+
+/*
+sqlTypes maps the numeric OIDs of RFC 4517 syntaxes to SQL column types.
+Syntaxes not present are represented as VARCHAR columns, while BLOB is
+replaced per dialect.
+*/
+var sqlTypes = map[string]string{
+	`1.3.6.1.4.1.1466.115.121.1.5`:  `BLOB`,                     // Binary
+	`1.3.6.1.4.1.1466.115.121.1.7`:  `BOOLEAN`,                  // Boolean
+	`1.3.6.1.4.1.1466.115.121.1.8`:  `BLOB`,                     // Certificate
+	`1.3.6.1.4.1.1466.115.121.1.9`:  `BLOB`,                     // Certificate List
+	`1.3.6.1.4.1.1466.115.121.1.10`: `BLOB`,                     // Certificate Pair
+	`1.3.6.1.4.1.1466.115.121.1.24`: `TIMESTAMP WITH TIME ZONE`, // Generalized Time
+	`1.3.6.1.4.1.1466.115.121.1.27`: `BIGINT`,                   // Integer
+	`1.3.6.1.4.1.1466.115.121.1.28`: `BLOB`,                     // JPEG
+	`1.3.6.1.4.1.1466.115.121.1.40`: `BLOB`,                     // Octet String
+	`1.3.6.1.4.1.1466.115.121.1.53`: `TIMESTAMP WITH TIME ZONE`, // UTC Time
+}
+
+/*
+sqlCaseIgnore contains the numeric OIDs of RFC 4517 equality matching
+rules which are not sensitive to case.
+*/
+var sqlCaseIgnore = map[string]bool{
+	`2.5.13.2`:                   true, // caseIgnoreMatch
+	`2.5.13.11`:                  true, // caseIgnoreListMatch
+	`1.3.6.1.4.1.1466.109.114.2`: true, // caseIgnoreIA5Match
+}
+
+/*
+SQL returns DDL statements describing a relational store for entries of
+the STRUCTURAL object classes of the receiver instance, alongside an error.
+
+One table is generated per class, bearing an "id" primary key column, a
+unique "dn" column, and one column per single-valued [AttributeType]
+(see [AttributeType.SingleValue]) allowed by the class, its super classes
+and its auxiliary classes (see [SQLConfig.Auxiliary]).  Should a
+[DITContentRule] govern the class, the auxiliary classes and the MUST,
+MAY and NOT clauses of the rule are also honored.  Columns of types
+required by the class, its super classes or its [DITContentRule] are
+declared NOT NULL.  Each multi-valued [AttributeType] is given a child
+table, named after the class table and the type, whose rows bear the "id"
+of the entry and a "value" column.
+
+Column types are derived from the effective syntax of each [AttributeType]
+(see [AttributeType.EffectiveSyntax]): Integer as BIGINT, Boolean as BOOLEAN,
+Generalized Time and UTC Time as TIMESTAMP WITH TIME ZONE, as both bear
+an offset from UTC, binary values as BLOB (BYTEA for [PostgreSQL]), and
+all others as VARCHAR, the length of which is the minimum upper bound of
+the type (if any) or per [SQLConfig.VarcharLength].
+
+An index is suggested for each non-binary column whose [AttributeType]
+bears an equality or ordering rule.  For [PostgreSQL], case-insensitive
+equality rules yield an index of the lower-cased column, and substring
+rules yield a trigram index, which requires the pg_trgm extension; for
+other dialects, substring rules are noted by way of comments.
+
+Identifiers are the lower-cased names of definitions, in which hyphens
+are replaced with underscores, enclosed in double quotes.  An error is
+returned if the receiver is zero, if any class within cfg is not found
+or is not of the expected kind, or if any identifier would be declared
+more than once.
+*/
+func (r Schema) SQL(cfg SQLConfig) (ddl string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	if cfg.VarcharLength == 0 {
+		cfg.VarcharLength = 255
+	}
+
+	var ocs, aux []ObjectClass
+	if ocs, err = r.sqlClasses(cfg.ObjectClasses, StructuralKind); err == nil {
+		aux, err = r.sqlClasses(cfg.Auxiliary, AuxiliaryKind)
+	}
+
+	g := sqlGen{
+		cfg:  cfg,
+		used: make(map[string]string),
+	}
+	g.line(`-- Generated by schemax; DO NOT EDIT.`)
+
+	for i := 0; i < len(ocs) && err == nil; i++ {
+		dc := r.DITContentRules().Get(ocs[i].NumericOID())
+		err = g.table(ocs[i], dc, append(append([]ObjectClass{}, aux...), classList(dc.Aux())...))
+	}
+
+	if err == nil {
+		ddl = join(g.stmts, string(rune(10)))
+	}
+
+	return
+}
+
+/*
+sqlClasses returns the object classes identified by ids, each of which
+must be of kind, alongside an error.  If ids is empty and kind is that of
+STRUCTURAL classes, all such classes are returned.
+*/
+func (r Schema) sqlClasses(ids []string, kind uint) (ocs []ObjectClass, err error) {
+	all := r.ObjectClasses()
+	if len(ids) == 0 && kind == StructuralKind {
+		for i := 0; i < all.Len(); i++ {
+			if oc := all.Index(i); oc.Kind() == kind {
+				ocs = append(ocs, oc)
+			}
+		}
+		return
+	}
+
+	kinds := map[uint]string{StructuralKind: `STRUCTURAL`, AuxiliaryKind: `AUXILIARY`}
+	for _, id := range ids {
+		oc := all.Get(id)
+		if oc.IsZero() {
+			err = mkerr(ErrObjectClassNotFound.Error() + `: ` + id)
+			break
+		} else if oc.Kind() != kind {
+			err = mkerr(ErrInvalidInput.Error() + `: ` + id + ` is not ` + kinds[kind])
+			break
+		}
+		ocs = append(ocs, oc)
+	}
+
+	return
+}
+
+/*
+classList returns the members of ocs as a slice of [ObjectClass].
+*/
+func classList(ocs ObjectClasses) (list []ObjectClass) {
+	for i := 0; i < ocs.Len(); i++ {
+		list = append(list, ocs.Index(i))
+	}
+
+	return
+}
+
+/*
+sqlGen accumulates the statements produced by [Schema.SQL].
+*/
+type sqlGen struct {
+	cfg   SQLConfig
+	stmts []string
+	used  map[string]string // identifier -> definition name
+}
+
+/*
+line appends s to the statements.
+*/
+func (r *sqlGen) line(s string) {
+	r.stmts = append(r.stmts, s)
+}
+
+/*
+declare returns the quoted identifier derived from name, or an error if
+it was previously declared.
+*/
+func (r *sqlGen) declare(name, of string) (ident string, err error) {
+	ident = sqlIdent(name)
+	if prev, found := r.used[ident]; found {
+		err = mkerr(ErrNotUnique.Error() + `: SQL identifier ` + ident +
+			` of ` + of + ` conflicts with ` + prev)
+		return
+	}
+	r.used[ident] = of
+
+	return
+}
+
+/*
+sqlIdent returns the quoted SQL identifier derived from name.
+*/
+func sqlIdent(name string) string {
+	return `"` + repAll(lc(name), `-`, `_`) + `"`
+}
+
+/*
+table appends the statements describing the table of entries of oc, as
+well as its child tables and indexes, to the receiver instance.  dc is
+the DITContentRule governing oc, if any, and aux contains the auxiliary
+classes whose attribute types are also allowed.
+*/
+func (r *sqlGen) table(oc ObjectClass, dc DITContentRule, aux []ObjectClass) (err error) {
+	name := docLabel(oc)
+	var table string
+	if table, err = r.declare(name, docLabel(oc)); err != nil {
+		return
+	}
+
+	lf := string(rune(10))
+	must := oc.AllMust()
+	pushAttributeTypes(must, dc.Must())
+
+	allowed := NewAttributeTypeOIDList()
+	pushAttributeTypes(allowed, must)
+	pushAttributeTypes(allowed, oc.AllMay())
+	for _, a := range aux {
+		pushAttributeTypes(allowed, a.AllMust())
+		pushAttributeTypes(allowed, a.AllMay())
+	}
+	pushAttributeTypes(allowed, dc.May())
+
+	columns := []string{
+		`"id" BIGINT NOT NULL PRIMARY KEY`,
+		`"dn" VARCHAR(1024) NOT NULL UNIQUE`,
+	}
+	cols := map[string]string{`"id"`: `the primary key`, `"dn"`: `the entry DN`}
+
+	var children, indexes []string
+	for i := 0; i < allowed.Len(); i++ {
+		at := allowed.Index(i)
+		if dc.Not().Contains(at.NumericOID()) && !must.Contains(at.NumericOID()) {
+			continue
+		}
+		typ := r.columnType(at)
+
+		if at.SingleValue() {
+			col := sqlIdent(docLabel(at))
+			if prev, found := cols[col]; found {
+				return mkerr(ErrNotUnique.Error() + `: SQL identifier ` + col +
+					` of ` + docLabel(at) + ` conflicts with ` + prev)
+			}
+			cols[col] = docLabel(at)
+
+			if must.Contains(at.NumericOID()) {
+				typ += ` NOT NULL`
+			}
+			columns = append(columns, col+` `+typ)
+			indexes = append(indexes, r.indexes(name, table, col, at)...)
+			continue
+		}
+
+		var child string
+		if child, err = r.declare(name+`_`+docLabel(at), docLabel(at)+` of `+name); err != nil {
+			return
+		}
+
+		children = append(children, `CREATE TABLE `+child+` (`+lf+
+			`    "id" BIGINT NOT NULL REFERENCES `+table+` ("id") ON DELETE CASCADE,`+lf+
+			`    "value" `+typ+` NOT NULL`+lf+`);`)
+		indexes = append(indexes, `CREATE INDEX `+sqlIdent(name+`_`+docLabel(at)+`_id`)+
+			` ON `+child+` ("id");`)
+		indexes = append(indexes, r.indexes(name+`_`+docLabel(at), child, `"value"`, at)...)
+	}
+
+	r.line(``)
+	r.line(`-- ` + name + ` (` + oc.NumericOID() + `)`)
+	r.line(`CREATE TABLE ` + table + ` (` + lf + `    ` + join(columns, `,`+lf+`    `) + lf + `);`)
+	for _, stmt := range children {
+		r.line(stmt)
+	}
+	for _, stmt := range indexes {
+		r.line(stmt)
+	}
+
+	return
+}
+
+/*
+columnType returns the SQL type of the column describing at.
+*/
+func (r *sqlGen) columnType(at AttributeType) (typ string) {
+	var found bool
+	if typ, found = sqlTypes[at.EffectiveSyntax().NumericOID()]; !found {
+		mub := r.cfg.VarcharLength
+		// The syntax length, if any, may be inherited
+		// from a super type.
+		for sup := at; !sup.IsZero(); sup = sup.SuperType() {
+			if m := sup.MinimumUpperBounds(); m > 0 {
+				mub = m
+				break
+			}
+		}
+		typ = `VARCHAR(` + uitoa(mub) + `)`
+	} else if typ == `BLOB` && r.cfg.Dialect == PostgreSQL {
+		typ = `BYTEA`
+	}
+
+	return
+}
+
+/*
+indexes returns the index statements suggested for col of table per the
+matching rules of at.  prefix is the basis of the index names.
+*/
+func (r *sqlGen) indexes(prefix, table, col string, at AttributeType) (stmts []string) {
+	if _, binary := map[string]bool{`BLOB`: true, `BYTEA`: true}[r.columnType(at)]; binary {
+		return
+	}
+
+	name := func(suffix string) string {
+		return sqlIdent(prefix + `_` + trim(col, `"`) + `_` + suffix)
+	}
+	if col == `"value"` {
+		name = func(suffix string) string {
+			return sqlIdent(prefix + `_` + suffix)
+		}
+	}
+
+	eql := at.EffectiveEquality()
+	if !eql.IsZero() || !at.EffectiveOrdering().IsZero() {
+		expr := col
+		if r.cfg.Dialect == PostgreSQL && sqlCaseIgnore[eql.NumericOID()] {
+			expr = `lower(` + col + `)`
+		}
+		stmts = append(stmts, `CREATE INDEX `+name(`idx`)+` ON `+table+` (`+expr+`);`)
+	}
+
+	if sub := at.EffectiveSubstring(); !sub.IsZero() {
+		if r.cfg.Dialect == PostgreSQL {
+			stmts = append(stmts, `CREATE INDEX `+name(`sub`)+` ON `+table+
+				` USING gin (`+col+` gin_trgm_ops);`)
+		} else {
+			stmts = append(stmts, `-- suggested: substring index on `+table+
+				` (`+col+`) per `+docLabel(sub))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,149 @@
+package schemax
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/*
+This example demonstrates the generation of PostgreSQL DDL statements
+describing a relational store for entries of a simple STRUCTURAL class.
+*/
+func ExampleSchema_SQL() {
+	s := mySchema.Clone()
+	if err := s.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.88
+		NAME 'widget'
+		SUP top
+		STRUCTURAL
+		MUST cn
+		MAY ( c $ userPassword ) )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	ddl, err := s.SQL(SQLConfig{
+		Dialect:       PostgreSQL,
+		ObjectClasses: []string{`widget`},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(ddl)
+	// Output: -- Generated by schemax; DO NOT EDIT.
+	//
+	// -- widget (1.3.6.1.4.1.56521.999.88)
+	// CREATE TABLE "widget" (
+	//     "id" BIGINT NOT NULL PRIMARY KEY,
+	//     "dn" VARCHAR(1024) NOT NULL UNIQUE,
+	//     "c" VARCHAR(255)
+	// );
+	// CREATE TABLE "widget_objectclass" (
+	//     "id" BIGINT NOT NULL REFERENCES "widget" ("id") ON DELETE CASCADE,
+	//     "value" VARCHAR(255) NOT NULL
+	// );
+	// CREATE TABLE "widget_cn" (
+	//     "id" BIGINT NOT NULL REFERENCES "widget" ("id") ON DELETE CASCADE,
+	//     "value" VARCHAR(255) NOT NULL
+	// );
+	// CREATE TABLE "widget_userpassword" (
+	//     "id" BIGINT NOT NULL REFERENCES "widget" ("id") ON DELETE CASCADE,
+	//     "value" BYTEA NOT NULL
+	// );
+	// CREATE INDEX "widget_objectclass_id" ON "widget_objectclass" ("id");
+	// CREATE INDEX "widget_objectclass_idx" ON "widget_objectclass" ("value");
+	// CREATE INDEX "widget_cn_id" ON "widget_cn" ("id");
+	// CREATE INDEX "widget_cn_idx" ON "widget_cn" (lower("value"));
+	// CREATE INDEX "widget_cn_sub" ON "widget_cn" USING gin ("value" gin_trgm_ops);
+	// CREATE INDEX "widget_c_idx" ON "widget" (lower("c"));
+	// CREATE INDEX "widget_c_sub" ON "widget" USING gin ("c" gin_trgm_ops);
+	// CREATE INDEX "widget_userpassword_id" ON "widget_userpassword" ("id");
+}
+
+func TestSchema_SQL(t *testing.T) {
+	ddl, err := mySchema.SQL(SQLConfig{
+		ObjectClasses: []string{`arc`, `device`},
+		Auxiliary:     []string{`pkiUser`},
+		VarcharLength: 128,
+	})
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	for _, want := range []string{
+		// n is a SINGLE-VALUE type required by the content rule
+		`    "n" BIGINT NOT NULL,`,
+		// iRI is a multi-valued binary type required by the content rule
+		`CREATE TABLE "arc_iri" (`,
+		// pkiUser contributes userCertificate to every table
+		`CREATE TABLE "device_usercertificate" (`,
+		`    "value" BLOB NOT NULL`,
+		`    "value" VARCHAR(128) NOT NULL`,
+		`-- suggested: substring index on "device_cn" ("value") per caseIgnoreSubstringsMatch`,
+	} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("%s failed: missing %q in:\n%s", t.Name(), want, ddl)
+			return
+		}
+	}
+
+	// dotNotation is precluded by the NOT clause of the content rule,
+	// and binary values are never indexed.
+	for _, unwanted := range []string{`dotnotation`, `"arc_iri_idx"`, `lower(`} {
+		if strings.Contains(ddl, unwanted) {
+			t.Errorf("%s failed: unexpected %q in:\n%s", t.Name(), unwanted, ddl)
+			return
+		}
+	}
+
+	if ddl, err = mySchema.SQL(SQLConfig{}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if !strings.Contains(ddl, `CREATE TABLE "inetorgperson" (`) ||
+		strings.Contains(ddl, `CREATE TABLE "top" (`) {
+		t.Errorf("%s failed: unexpected tables for all STRUCTURAL classes", t.Name())
+		return
+	}
+
+	// time values bear an offset from UTC
+	sch := NewSchema()
+	if err = sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.96.1
+		NAME 'sqlTime'
+		EQUALITY generalizedTimeMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.24
+		SINGLE-VALUE )`); err == nil {
+		err = sch.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.96.2
+			NAME 'sqlTimeClass'
+			SUP top STRUCTURAL
+			MUST sqlTime )`)
+	}
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if ddl, err = sch.SQL(SQLConfig{ObjectClasses: []string{`sqlTimeClass`}}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if want := `    "sqltime" TIMESTAMP WITH TIME ZONE NOT NULL`; !strings.Contains(ddl, want) {
+		t.Errorf("%s failed: missing %q in:\n%s", t.Name(), want, ddl)
+		return
+	}
+
+	for idx, cfg := range []SQLConfig{
+		{ObjectClasses: []string{`bogusClass`}},
+		{ObjectClasses: []string{`top`}},
+		{Auxiliary: []string{`person`}},
+		{ObjectClasses: []string{`person`, `2.5.6.6`}},
+	} {
+		if _, err = mySchema.SQL(cfg); err == nil {
+			t.Errorf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+			return
+		}
+	}
+
+	if _, err = (Schema{}).SQL(SQLConfig{}); err == nil {
+		t.Errorf("%s failed: expected error for zero Schema", t.Name())
+	}
+}
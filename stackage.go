@@ -20,6 +20,9 @@ func newCollection(name string) (c collection) {
 			SetID(name).
 			SetCategory(`collection`).
 			SetDelimiter(rune(10)).
+			SetAuxiliary(map[string]any{
+				`lookup`: newLookup(),
+			}).
 			Mutex())
 }
 
@@ -0,0 +1,354 @@
+package schemax
+
+/*
+structdef.go implements the construction of definitions from annotated
+Go struct types.
+*/
+
+import (
+	"reflect"
+	"time"
+)
+
+/*
+structTypes maps Go kinds to the numeric OID of the RFC 4517 syntax and
+the name of the equality matching rule assigned to attribute types whose
+struct fields bear values of that kind, absent explicit tag values.
+*/
+var structTypes = map[reflect.Kind][2]string{
+	reflect.String: {`1.3.6.1.4.1.1466.115.121.1.15`, `caseIgnoreMatch`},
+	reflect.Bool:   {`1.3.6.1.4.1.1466.115.121.1.7`, `booleanMatch`},
+	reflect.Int:    {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Int8:   {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Int16:  {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Int32:  {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Int64:  {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Uint:   {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Uint8:  {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Uint16: {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Uint32: {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+	reflect.Uint64: {`1.3.6.1.4.1.1466.115.121.1.27`, `integerMatch`},
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte{})
+)
+
+/*
+StructDefinitionMaps returns an instance of [DefinitionMaps] describing
+the [ObjectClass] -- and any new [AttributeType] instances -- declared by
+the struct type of x, alongside an error.  x may be a struct, a pointer
+to a struct, or a [reflect.Type] thereof.  The [AttributeType] maps, if
+any, precede that of the [ObjectClass].
+
+Only fields bearing a "schemax" struct tag are considered.  The class
+itself is described by the tag of a blank (_) field, e.g.:
+
+	type ShoeWearer struct {
+		_        struct{} `schemax:"shoeWearer,oid=1.3.6.1.4.1.56521.999.9,kind=auxiliary,desc=Wears shoes"`
+		ShoeSize int      `schemax:"shoeSize,oid=1.3.6.1.4.1.56521.999.9.1,must"`
+		Laces    []string `schemax:"laceColor,oid=1.3.6.1.4.1.56521.999.9.2,equality=caseExactMatch"`
+		Owner    string   `schemax:"manager"`
+	}
+
+The first element of a tag is the name of the definition; if empty, the
+name of the struct type or field is used, its first letter lower-cased.
+The remaining elements are comma-delimited and may be as follows:
+
+  - oid=<numericoid>: the numeric OID of the definition, which is required
+    of the class; a field lacking an OID refers to an existing [AttributeType]
+    by name, which must be present within the relevant [Schema]
+  - desc=<text>: the description of the definition, which may not contain
+    commas
+  - sup=<name or oid>: the super class of the class, or the super type of
+    an [AttributeType]; this may be repeated for classes, which otherwise
+    extend "top"
+  - kind=<kind>: the kind of the class, i.e.: "structural" (the default),
+    "auxiliary" or "abstract"
+  - syntax=<numericoid>: the syntax of an [AttributeType], optionally bearing
+    a minimum upper bound, e.g.: "1.3.6.1.4.1.1466.115.121.1.15{64}"
+  - equality=, substr= and ordering=<name or oid>: the matching rules of
+    an [AttributeType]
+  - single or multi: whether an [AttributeType] is single-valued
+  - nousermod: the NO-USER-MODIFICATION flag of an [AttributeType]
+  - must: the [AttributeType] is required by the class, else it is optional
+
+Absent an explicit syntax, the syntax and equality rule of a new [AttributeType]
+are derived from the type of its field: strings yield Directory String and
+caseIgnoreMatch, integers yield Integer and integerMatch, Booleans yield
+Boolean and booleanMatch, [time.Time] yields Generalized Time and
+generalizedTimeMatch and []byte yields Octet String and octetStringMatch.
+Slices (other than []byte) thereof yield multi-valued types, while all
+others yield single-valued types.  Pointers are dereferenced.
+
+See [Schema.FromStruct] for the means of pushing the definitions into a
+[Schema].
+*/
+func StructDefinitionMaps(x any) (maps DefinitionMaps, err error) {
+	typ, ok := x.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(x)
+	}
+
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		err = mkerr(ErrInvalidType.Error() + `: struct required`)
+		return
+	}
+
+	oc := DefinitionMap{
+		`TYPE`: {`objectClass`},
+		`NAME`: {lowerFirst(typ.Name())},
+		`SUP`:  {`top`},
+	}
+
+	for i := 0; i < typ.NumField() && err == nil; i++ {
+		field := typ.Field(i)
+		tag, tagged := field.Tag.Lookup(`schemax`)
+		if !tagged || tag == `-` {
+			continue
+		} else if field.Name == `_` {
+			err = structClass(oc, tag)
+			continue
+		} else if !field.IsExported() {
+			continue
+		}
+
+		var at DefinitionMap
+		var must bool
+		if at, must, err = structAttribute(field, tag); err == nil {
+			clause := `MAY`
+			if must {
+				clause = `MUST`
+			}
+			oc[clause] = append(oc[clause], at.value(`NAME`))
+
+			if at.Contains(`NUMERICOID`) {
+				maps = append(maps, at)
+			}
+		}
+	}
+
+	if err == nil && !oc.Contains(`NUMERICOID`) {
+		err = mkerr(ErrMissingNumericOID.Error() + `: ` + oc.value(`NAME`))
+	}
+
+	if err != nil {
+		maps = nil
+	} else {
+		maps = append(maps, oc)
+	}
+
+	return
+}
+
+/*
+structClass writes the values within the class-level tag to oc.
+*/
+func structClass(oc DefinitionMap, tag string) (err error) {
+	elems := split(tag, `,`)
+	if name := trimS(elems[0]); len(name) > 0 {
+		oc[`NAME`] = []string{name}
+	}
+
+	var sups []string
+	for _, elem := range elems[1:] {
+		key, val := structTagElem(elem)
+		switch key {
+		case `oid`:
+			oc[`NUMERICOID`] = []string{val}
+		case `desc`:
+			oc[`DESC`] = []string{val}
+		case `kind`:
+			oc[`KIND`] = []string{val}
+		case `sup`:
+			sups = append(sups, val)
+		default:
+			err = mkerr(ErrInvalidInput.Error() + `: unknown class tag element '` + elem + `'`)
+			return
+		}
+	}
+
+	if len(sups) > 0 {
+		oc[`SUP`] = sups
+	}
+
+	return
+}
+
+/*
+structAttribute returns the [DefinitionMap] describing the attribute type
+of field per tag, a Boolean value indicative of whether it is required,
+and an error.
+*/
+func structAttribute(field reflect.StructField, tag string) (at DefinitionMap, must bool, err error) {
+	elems := split(tag, `,`)
+	name := trimS(elems[0])
+	if len(name) == 0 {
+		name = lowerFirst(field.Name)
+	}
+
+	at = DefinitionMap{
+		`TYPE`: {`attributeType`},
+		`NAME`: {name},
+	}
+
+	var single, multi bool
+	for _, elem := range elems[1:] {
+		key, val := structTagElem(elem)
+		switch key {
+		case `oid`:
+			at[`NUMERICOID`] = []string{val}
+		case `desc`:
+			at[`DESC`] = []string{val}
+		case `sup`:
+			at[`SUP`] = []string{val}
+		case `syntax`:
+			at[`SYNTAX`] = []string{val}
+		case `equality`:
+			at[`EQUALITY`] = []string{val}
+		case `substr`:
+			at[`SUBSTR`] = []string{val}
+		case `ordering`:
+			at[`ORDERING`] = []string{val}
+		case `nousermod`:
+			at[`NO-USER-MODIFICATION`] = []string{`TRUE`}
+		case `single`:
+			single = true
+		case `multi`:
+			multi = true
+		case `must`:
+			must = true
+		default:
+			err = mkerr(ErrInvalidInput.Error() + `: unknown field tag element '` + elem + `'`)
+			return
+		}
+	}
+
+	if !at.Contains(`NUMERICOID`) {
+		// A reference to an existing type.
+		return
+	}
+
+	typ := field.Type
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// Slices (other than []byte) imply multiple values.
+	if typ.Kind() == reflect.Slice && typ != bytesType {
+		typ = typ.Elem()
+		multi = multi || !single
+	}
+
+	if single || !multi {
+		at[`SINGLE-VALUE`] = []string{`TRUE`}
+	}
+
+	if at.Contains(`SYNTAX`) || at.Contains(`SUP`) {
+		return
+	}
+
+	var infer [2]string
+	switch {
+	case typ == timeType:
+		infer = [2]string{`1.3.6.1.4.1.1466.115.121.1.24`, `generalizedTimeMatch`}
+	case typ == bytesType:
+		infer = [2]string{`1.3.6.1.4.1.1466.115.121.1.40`, `octetStringMatch`}
+	default:
+		var found bool
+		if infer, found = structTypes[typ.Kind()]; !found {
+			err = mkerr(ErrInvalidType.Error() + `: unsupported type ` +
+				typ.String() + ` for field ` + field.Name)
+			return
+		}
+	}
+
+	at[`SYNTAX`] = []string{infer[0]}
+	if !at.Contains(`EQUALITY`) {
+		at[`EQUALITY`] = []string{infer[1]}
+	}
+
+	return
+}
+
+/*
+structTagElem returns the key and value of a tag element, e.g.: "oid" and
+"1.2.3" for "oid=1.2.3".
+*/
+func structTagElem(elem string) (key, val string) {
+	key = trimS(elem)
+	if idx := stridx(key, `=`); idx != -1 {
+		key, val = trimS(key[:idx]), trimS(key[idx+1:])
+	}
+	key = lc(key)
+
+	return
+}
+
+/*
+lowerFirst returns s with its first letter lower-cased.
+*/
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+
+	return lc(s[:1]) + s[1:]
+}
+
+/*
+FromStruct returns an error following an attempt to construct the [ObjectClass]
+and [AttributeType] definitions declared by each struct type within x (see
+[StructDefinitionMaps]), and to push them into the receiver instance by way
+of [Schema.FromMaps].
+
+An [AttributeType] already present within the receiver by the same numeric
+OID and name is not pushed again, thus several classes may declare fields
+of the same type.  All definitions are pushed within a single transaction
+(see [Schema.Begin]), thus the receiver is left intact should any of them
+fail, or should the result fail the [Schema.Compliant] checks.
+*/
+func (r Schema) FromStruct(x ...any) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	tx := r.Begin()
+	if tx.IsZero() {
+		err = mkerr(ErrInvalidInput.Error() + `: Schema is frozen`)
+		return
+	}
+
+	stage := tx.Schema()
+	for i := 0; i < len(x) && err == nil; i++ {
+		var maps DefinitionMaps
+		if maps, err = StructDefinitionMaps(x[i]); err != nil {
+			break
+		}
+
+		for j := 0; j < maps.Len() && err == nil; j++ {
+			def := maps.Index(j)
+			if def.Type() == `attributeType` {
+				existing := stage.AttributeTypes().Get(def.value(`NUMERICOID`))
+				if !existing.IsZero() && existing.Names().Contains(def.value(`NAME`)) {
+					continue
+				}
+			}
+			err = stage.FromMap(def)
+		}
+	}
+
+	if err != nil {
+		tx.Rollback()
+	} else {
+		err = tx.Commit()
+	}
+
+	return
+}
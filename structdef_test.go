@@ -0,0 +1,126 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+/*
+ShoeWearer is an auxiliary class declared by way of struct tags.
+*/
+type ShoeWearer struct {
+	_        struct{}  `schemax:"shoeWearer,oid=1.3.6.1.4.1.56521.999.9,kind=auxiliary,desc=Wears shoes"`
+	ShoeSize int       `schemax:",oid=1.3.6.1.4.1.56521.999.9.1,must"`
+	Laces    []string  `schemax:"laceColor,oid=1.3.6.1.4.1.56521.999.9.2,equality=caseExactMatch"`
+	Bought   time.Time `schemax:"shoesBought,oid=1.3.6.1.4.1.56521.999.9.3"`
+	Owner    string    `schemax:"manager"`
+	Notes    string
+}
+
+/*
+This example demonstrates the construction of an [ObjectClass] and its
+[AttributeType] instances from an annotated struct type, followed by
+their submission to a [Schema].
+*/
+func ExampleSchema_FromStruct() {
+	s := mySchema.Clone()
+	if err := s.FromStruct(ShoeWearer{}); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(s.ObjectClasses().Get(`shoeWearer`))
+	fmt.Println(s.AttributeTypes().Get(`laceColor`))
+	// Output: ( 1.3.6.1.4.1.56521.999.9
+	//     NAME 'shoeWearer'
+	//     DESC 'Wears shoes'
+	//     SUP top
+	//     AUXILIARY
+	//     MUST shoeSize
+	//     MAY ( laceColor
+	//         $ manager
+	//         $ shoesBought ) )
+	// ( 1.3.6.1.4.1.56521.999.9.2
+	//     NAME 'laceColor'
+	//     EQUALITY caseExactMatch
+	//     SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )
+}
+
+func TestSchema_FromStruct(t *testing.T) {
+	maps, err := StructDefinitionMaps(&ShoeWearer{})
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if maps.Len() != 4 {
+		t.Errorf("%s failed: want 4 maps, got %d", t.Name(), maps.Len())
+		return
+	}
+
+	for idx, want := range []map[string]string{
+		{`NAME`: `shoeSize`, `SYNTAX`: `1.3.6.1.4.1.1466.115.121.1.27`, `EQUALITY`: `integerMatch`, `SINGLE-VALUE`: `TRUE`},
+		{`NAME`: `laceColor`, `SYNTAX`: `1.3.6.1.4.1.1466.115.121.1.15`, `EQUALITY`: `caseExactMatch`, `SINGLE-VALUE`: ``},
+		{`NAME`: `shoesBought`, `SYNTAX`: `1.3.6.1.4.1.1466.115.121.1.24`, `EQUALITY`: `generalizedTimeMatch`, `SINGLE-VALUE`: `TRUE`},
+		{`NAME`: `shoeWearer`, `KIND`: `auxiliary`, `MUST`: `shoeSize`},
+	} {
+		for key, val := range want {
+			if got := maps.Index(idx).value(key); got != val {
+				t.Errorf("%s failed [%d/%s]: want %q, got %q", t.Name(), idx, key, val, got)
+				return
+			}
+		}
+	}
+
+	s := mySchema.Clone()
+	if err = s.FromStruct(ShoeWearer{}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// A second class may declare the same field types.
+	type Cobbler struct {
+		_        struct{} `schemax:"cobbler,oid=1.3.6.1.4.1.56521.999.10,sup=person"`
+		ShoeSize int      `schemax:",oid=1.3.6.1.4.1.56521.999.9.1"`
+	}
+	if err = s.FromStruct(Cobbler{}); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if oc := s.ObjectClasses().Get(`cobbler`); oc.Kind() != StructuralKind || !oc.AllMust().Contains(`sn`) {
+		t.Errorf("%s failed: unexpected class %s", t.Name(), oc)
+		return
+	}
+
+	type NoOID struct {
+		Size int `schemax:",oid=1.3.6.1.4.1.56521.999.11.1"`
+	}
+	type BadRef struct {
+		_     struct{} `schemax:",oid=1.3.6.1.4.1.56521.999.12"`
+		Extra int      `schemax:",oid=1.3.6.1.4.1.56521.999.12.1"`
+		Size  int      `schemax:"noSuchType"`
+	}
+	type BadType struct {
+		_    struct{}       `schemax:",oid=1.3.6.1.4.1.56521.999.13"`
+		Size map[string]int `schemax:",oid=1.3.6.1.4.1.56521.999.13.1"`
+	}
+	type BadElem struct {
+		_ struct{} `schemax:",oid=1.3.6.1.4.1.56521.999.14,colour=red"`
+	}
+
+	before := s.Counters()
+	for _, bad := range []any{NoOID{}, BadRef{}, BadType{}, BadElem{}, 42} {
+		if err = s.FromStruct(bad); err == nil {
+			t.Errorf("%s failed: expected error for %T", t.Name(), bad)
+			return
+		}
+	}
+
+	// A failed batch leaves the schema intact.
+	if s.Counters() != before || s.AttributeTypes().Contains(`extra`) {
+		t.Errorf("%s failed: schema altered by failed batch", t.Name())
+		return
+	}
+
+	if err = (Schema{}).FromStruct(ShoeWearer{}); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+	}
+}
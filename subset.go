@@ -0,0 +1,248 @@
+package schemax
+
+/*
+subset.go implements the extraction of closed sub-schemata.
+*/
+
+/*
+Subset returns a new instance of [Schema] alongside an error following
+an attempt to extract the [ObjectClass] and/or [AttributeType] instances
+identified by the input ids -- which may be names or numeric OIDs -- from
+the receiver instance.
+
+The return instance shall contain only the requested definitions, plus
+all of the definitions upon which they depend, whether directly or
+transitively:
+
+  - Superior [ObjectClass] instances
+  - [AttributeType] instances present within MUST and MAY clauses
+  - Super type [AttributeType] instances
+  - [MatchingRule] and [LDAPSyntax] instances
+  - [DITContentRule] instances which share an OID with an [ObjectClass]
+  - [NameForm] instances which reference an [ObjectClass]
+  - [DITStructureRule] instances which reference a [NameForm], as well
+    as their superior [DITStructureRule] instances
+
+All definitions are written to the return instance in dependency order.
+
+If the receiver instance contains [MatchingRuleUse] instances, they
+are regenerated within the return instance based upon the extracted
+[AttributeType] instances.
+
+The [Macros] instance, [Options] bit settings and DN are copied. User
+data values (see the various SetData methods) are shared between the
+receiver and the return instance.
+
+An error is returned if any of the ids cannot be resolved, or if the
+resulting [Schema] does not pass [Schema.Compliant] checks.
+*/
+func (r Schema) Subset(ids ...string) (sub Schema, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	ss := newSubsetter(r)
+	for i := 0; i < len(ids) && err == nil; i++ {
+		if oc := r.ObjectClasses().get(ids[i]); !oc.IsZero() {
+			ss.objectClass(oc)
+		} else if at := r.AttributeTypes().get(ids[i]); !at.IsZero() {
+			ss.attributeType(at)
+		} else {
+			err = mkerr(ErrDefNotFound.Error() + ": " + ids[i])
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	sub = r.cloneEmpty()
+	cl := newCloner(sub)
+
+	for _, ls := range ss.lss {
+		sub.LDAPSyntaxes().cast().Push(cl.lDAPSyntax(ls))
+	}
+
+	for _, mr := range ss.mrs {
+		sub.MatchingRules().cast().Push(cl.matchingRule(mr))
+	}
+
+	for _, at := range ss.ats {
+		sub.AttributeTypes().cast().Push(cl.attributeType(at))
+	}
+
+	for _, oc := range ss.ocs {
+		sub.ObjectClasses().cast().Push(cl.objectClass(oc))
+	}
+
+	for _, dc := range ss.dcs {
+		sub.DITContentRules().cast().Push(cl.dITContentRule(dc))
+	}
+
+	for _, nf := range ss.nfs {
+		sub.NameForms().cast().Push(cl.nameForm(nf))
+	}
+
+	for _, ds := range ss.dss {
+		sub.DITStructureRules().cast().Push(cl.dITStructureRule(ds))
+	}
+
+	if r.MatchingRuleUses().len() > 0 {
+		err = sub.updateMatchingRuleUses(sub.AttributeTypes())
+	}
+
+	if err == nil && !sub.Compliant() {
+		err = ErrDefNonCompliant
+	}
+
+	return
+}
+
+/*
+subsetter gathers the definitions required to produce a closed sub-schema.
+Each definition is recorded only after its own dependencies have been
+recorded, thus producing a dependency-ordered manifest.
+*/
+type subsetter struct {
+	schema Schema
+	seen   map[any]bool
+
+	lss []LDAPSyntax
+	mrs []MatchingRule
+	ats []AttributeType
+	ocs []ObjectClass
+	dcs []DITContentRule
+	nfs []NameForm
+	dss []DITStructureRule
+}
+
+func newSubsetter(s Schema) *subsetter {
+	return &subsetter{
+		schema: s,
+		seen:   make(map[any]bool),
+	}
+}
+
+/*
+visit returns a Boolean value indicative of whether ptr is being visited
+for the first time.
+*/
+func (r *subsetter) visit(ptr any) (first bool) {
+	if first = !r.seen[ptr]; first {
+		r.seen[ptr] = true
+	}
+
+	return
+}
+
+func (r *subsetter) lDAPSyntax(x LDAPSyntax) {
+	if x.IsZero() || !r.visit(x.lDAPSyntax) {
+		return
+	}
+
+	r.lss = append(r.lss, x)
+}
+
+func (r *subsetter) matchingRule(x MatchingRule) {
+	if x.IsZero() || !r.visit(x.matchingRule) {
+		return
+	}
+
+	r.lDAPSyntax(x.matchingRule.Syntax)
+	r.mrs = append(r.mrs, x)
+}
+
+func (r *subsetter) attributeType(x AttributeType) {
+	if x.IsZero() || !r.visit(x.attributeType) {
+		return
+	}
+
+	r.attributeType(x.attributeType.SuperType)
+	r.matchingRule(x.attributeType.Equality)
+	r.matchingRule(x.attributeType.Ordering)
+	r.matchingRule(x.attributeType.Substring)
+	r.lDAPSyntax(x.attributeType.Syntax)
+	r.ats = append(r.ats, x)
+}
+
+func (r *subsetter) attributeTypes(x AttributeTypes) {
+	for i := 0; i < x.len(); i++ {
+		r.attributeType(x.index(i))
+	}
+}
+
+func (r *subsetter) objectClass(x ObjectClass) {
+	if x.IsZero() || !r.visit(x.objectClass) {
+		return
+	}
+
+	for i := 0; i < x.objectClass.SuperClasses.len(); i++ {
+		r.objectClass(x.objectClass.SuperClasses.index(i))
+	}
+
+	r.attributeTypes(x.objectClass.Must)
+	r.attributeTypes(x.objectClass.May)
+	r.ocs = append(r.ocs, x)
+
+	// Gather any rules which govern the class.
+	r.dITContentRule(r.schema.DITContentRules().get(x.NumericOID()))
+
+	nfs := r.schema.NameForms()
+	for i := 0; i < nfs.len(); i++ {
+		if nf := nfs.index(i); nf.nameForm.Structural.objectClass == x.objectClass {
+			r.nameForm(nf)
+		}
+	}
+}
+
+func (r *subsetter) dITContentRule(x DITContentRule) {
+	if x.IsZero() || !r.visit(x.dITContentRule) {
+		return
+	}
+
+	for i := 0; i < x.dITContentRule.Aux.len(); i++ {
+		r.objectClass(x.dITContentRule.Aux.index(i))
+	}
+
+	r.attributeTypes(x.dITContentRule.Must)
+	r.attributeTypes(x.dITContentRule.May)
+	r.attributeTypes(x.dITContentRule.Not)
+	r.dcs = append(r.dcs, x)
+}
+
+func (r *subsetter) nameForm(x NameForm) {
+	if x.IsZero() || !r.visit(x.nameForm) {
+		return
+	}
+
+	r.objectClass(x.nameForm.Structural)
+	r.attributeTypes(x.nameForm.Must)
+	r.attributeTypes(x.nameForm.May)
+	r.nfs = append(r.nfs, x)
+
+	dss := r.schema.DITStructureRules()
+	for i := 0; i < dss.len(); i++ {
+		if ds := dss.index(i); ds.dITStructureRule.Form.nameForm == x.nameForm {
+			r.dITStructureRule(ds)
+		}
+	}
+}
+
+func (r *subsetter) dITStructureRule(x DITStructureRule) {
+	if x.IsZero() || !r.visit(x.dITStructureRule) {
+		return
+	}
+
+	r.nameForm(x.dITStructureRule.Form)
+
+	sup := x.dITStructureRule.SuperRules
+	for i := 0; i < sup.len(); i++ {
+		// superior rules may be recursive (i.e.: refer
+		// to the rule itself), which is handled by way
+		// of the visit method.
+		r.dITStructureRule(sup.index(i))
+	}
+
+	r.dss = append(r.dss, x)
+}
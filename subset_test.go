@@ -0,0 +1,102 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the extraction of a minimal, closed sub-schema
+containing the "inetOrgPerson" [ObjectClass] and all of its dependencies.
+*/
+func ExampleSchema_Subset() {
+	sub, err := mySchema.Subset(`inetOrgPerson`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%t, %d classes: %s",
+		sub.Compliant(),
+		sub.ObjectClasses().Len(),
+		sub.ObjectClasses().Inventory()[`2.16.840.1.113730.3.2.2`])
+	// Output: true, 4 classes: [inetOrgPerson]
+}
+
+func TestSchema_Subset(t *testing.T) {
+	sub, err := mySchema.Subset(`arc`, `2.5.4.3`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// The 'arc' class is governed by name forms and structure
+	// rules, the latter of which name a superior rule whose
+	// form references the 'rootArc' class.
+	for _, oc := range []string{`top`, `registration`, `arc`, `rootArc`} {
+		if !sub.ObjectClasses().Contains(oc) {
+			t.Errorf("%s failed: missing objectClass %s", t.Name(), oc)
+			return
+		}
+	}
+
+	if got := sub.DITStructureRules().Len(); got != 3 {
+		t.Errorf("%s failed: want 3 structure rules, got %d", t.Name(), got)
+		return
+	} else if got = sub.NameForms().Len(); got != 3 {
+		t.Errorf("%s failed: want 3 name forms, got %d", t.Name(), got)
+		return
+	} else if got = sub.DITContentRules().Len(); got != 1 {
+		t.Errorf("%s failed: want 1 content rule, got %d", t.Name(), got)
+		return
+	}
+
+	for _, at := range []string{`cn`, `name`, `n`, `dotNotation`} {
+		if !sub.AttributeTypes().Contains(at) {
+			t.Errorf("%s failed: missing attributeType %s", t.Name(), at)
+			return
+		}
+	}
+
+	if sub.ObjectClasses().Contains(`inetOrgPerson`) {
+		t.Errorf("%s failed: unexpected objectClass", t.Name())
+		return
+	} else if sub.MatchingRuleUses().Len() == 0 {
+		t.Errorf("%s failed: matchingRuleUses not regenerated", t.Name())
+		return
+	}
+
+	// superior classes must precede their subordinates
+	top := sub.ObjectClasses().Index(0)
+	if top.NumericOID() != `2.5.6.0` {
+		t.Errorf("%s failed: unexpected order; want top, got %s", t.Name(), top.OID())
+		return
+	}
+
+	if _, err = mySchema.Subset(`bogusClass`); err == nil {
+		t.Errorf("%s failed: expected error for unknown id", t.Name())
+		return
+	}
+
+	var zero Schema
+	if _, err = zero.Subset(`top`); err == nil {
+		t.Errorf("%s failed: expected error for zero receiver", t.Name())
+		return
+	}
+
+	if !mySchema.Compliant() {
+		t.Errorf("%s failed: mySchema not compliant", t.Name())
+		return
+	} else if zero.Compliant() {
+		t.Errorf("%s failed: zero schema reported as compliant", t.Name())
+		return
+	}
+
+	// a schema containing a dangling reference is not compliant
+	bogus := NewEmptySchema()
+	bogus.LDAPSyntaxes().cast().Push(sub.LDAPSyntaxes().Index(0))
+	bogus.MatchingRules().cast().Push(sub.MatchingRules().Get(`caseIgnoreMatch`))
+	if bogus.Compliant() {
+		t.Errorf("%s failed: dangling reference not detected", t.Name())
+	}
+}
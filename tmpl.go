@@ -2,6 +2,7 @@ package schemax
 
 import (
 	"bytes"
+	"sync"
 	"text/template"
 )
 
@@ -17,53 +18,262 @@ func funcMap(fm map[string]any) template.FuncMap {
 	return template.FuncMap(fm)
 }
 
+/*
+templates contains the text/template sources registered by way of the
+[Schema.SetTemplate] method, keyed by [Definition] type.
+*/
+type templates struct {
+	mutex *sync.RWMutex
+	src   map[string]string
+}
+
+func newTemplates() *templates {
+	return &templates{
+		mutex: &sync.RWMutex{},
+		src:   make(map[string]string, 0),
+	}
+}
+
+/*
+clone returns a copy of the receiver instance.
+*/
+func (r *templates) clone() (c *templates) {
+	c = newTemplates()
+	if r != nil {
+		r.mutex.RLock()
+		defer r.mutex.RUnlock()
+		for k, v := range r.src {
+			c.src[k] = v
+		}
+	}
+
+	return
+}
+
+/*
+templates returns the template registry of the receiver instance.
+*/
+func (r Schema) templates() (t *templates) {
+	if !r.IsZero() {
+		t, _ = r.cast().Auxiliary()[`templates`].(*templates)
+	}
+
+	return
+}
+
+/*
+template returns the text/template source registered within the receiver
+instance for definitions of type typ, else def if none was registered.
+*/
+func (r Schema) template(typ, def string) (src string) {
+	src = def
+	if t := r.templates(); t != nil {
+		t.mutex.RLock()
+		if s, found := t.src[typ]; found {
+			src = s
+		}
+		t.mutex.RUnlock()
+	}
+
+	return
+}
+
+/*
+templateSample returns the package-default text/template source and the
+template functions for definitions of type typ, alongside a new, empty
+definition of that type against which a template may be executed.
+*/
+func templateSample(typ string) (src string, funcs map[string]any, def any) {
+	switch typ {
+	case `ldapSyntax`:
+		x := newLDAPSyntax()
+		src, funcs, def = lDAPSyntaxTmpl, x.tmplFuncs(), x
+	case `matchingRule`:
+		x := newMatchingRule()
+		src, funcs, def = matchingRuleTmpl, x.tmplFuncs(), x
+	case `attributeType`:
+		x := newAttributeType()
+		src, funcs, def = attributeTypeTmpl, x.tmplFuncs(), x
+	case `matchingRuleUse`:
+		x := newMatchingRuleUse()
+		src, funcs, def = matchingRuleUseTmpl, x.tmplFuncs(), x
+	case `objectClass`:
+		x := newObjectClass()
+		src, funcs, def = objectClassTmpl, x.tmplFuncs(), x
+	case `dITContentRule`:
+		x := newDITContentRule()
+		src, funcs, def = dITContentRuleTmpl, x.tmplFuncs(), x
+	case `nameForm`:
+		x := newNameForm()
+		src, funcs, def = nameFormTmpl, x.tmplFuncs(), x
+	case `dITStructureRule`:
+		x := newDITStructureRule()
+		src, funcs, def = dITStructureRuleTmpl, x.tmplFuncs(), x
+	}
+
+	return
+}
+
+/*
+checkTemplate returns an error following an attempt to parse src and to
+execute it against def, such that errors which only surface when a
+template is executed (e.g.: references to unknown fields) are reported
+up front rather than by the String method of each definition.
+*/
+func checkTemplate(typ, src string, funcs map[string]any, def any) (err error) {
+	var t *template.Template
+	if t, err = newTemplate(typ).Funcs(funcMap(funcs)).Parse(src); err == nil {
+		err = t.Execute(newBuf(), struct {
+			Definition any
+			HIndent    string
+		}{
+			Definition: def,
+		})
+	}
+
+	return
+}
+
+/*
+SetTemplate returns an error following an attempt to register src as the
+[text/template] source used to render all definitions of type typ within
+the receiver instance, e.g.: "attributeType" or "objectClass" (see the
+Type method extended by any [Definition]).
+
+This allows vendor-specific or otherwise custom layouts to be produced by
+the String method of every such [Definition] without the need to assign a
+[Stringer] closure to each one.  Note that an assigned [Stringer] closure
+supersedes any template registered in this manner.
+
+The template is executed against a value bearing two fields: Definition,
+which is the underlying definition instance, and HIndent, which is the
+whitespace used to delimit clauses per the [Formatting] in effect.  The
+template functions made available are those used by the package-default
+templates.  Clauses, which returns the clause keywords of the relevant
+type in the order prescribed by [Formatting], and Name, which returns the
+formatted NAME value, are available for all types.  The rest vary by type:
+
+  - ldapSyntax: ExtensionSet
+  - matchingRule: Syntax, ExtensionSet, Obsolete
+  - attributeType: SuperType, Equality, Ordering, Substring, Syntax, MUB,
+    IsSingleVal, Collective, IsNoUserMod, Usage, ExtensionSet, Obsolete
+  - matchingRuleUse: MatchingRuleOID, Applied, ExtensionSet, Obsolete
+  - objectClass: Kind, SuperLen, MustLen, MayLen, ExtensionSet, Obsolete
+  - dITContentRule: StructuralOID, AuxLen, MustLen, MayLen, NotLen,
+    ExtensionSet, Obsolete
+  - nameForm: MayLen, ExtensionSet, Obsolete
+  - dITStructureRule: SuperLen, ExtensionSet, Obsolete
+
+An error is returned if typ is unknown, or if src cannot be parsed or
+executed against an empty definition of type typ.  A zero src restores
+the package-default template for typ.  The cached string values of any
+affected definitions are reset.
+
+Templates are preserved through [Schema.Clone] and [Schema.Overlay], but
+not through [Schema.MarshalBinary] or [Schema.MarshalJSON].  This method
+has no effect upon a frozen [Schema] (see [Schema.Snapshot]).
+*/
+func (r Schema) SetTemplate(typ, src string) (err error) {
+	t := r.templates()
+	if t == nil {
+		err = ErrNilReceiver
+		return
+	} else if r.IsFrozen() {
+		return
+	}
+
+	_, funcs, def := templateSample(typ)
+	if funcs == nil {
+		err = mkerr(ErrInvalidType.Error() + `: unknown definition type '` + typ + `'`)
+		return
+	}
+
+	if len(src) > 0 {
+		if err = checkTemplate(typ, src, funcs, def); err != nil {
+			return
+		}
+	}
+
+	t.mutex.Lock()
+	if len(src) == 0 {
+		delete(t.src, typ)
+	} else {
+		t.src[typ] = src
+	}
+	t.mutex.Unlock()
+
+	if defs := r.collection(typ); defs != nil {
+		for i := 0; i < defs.Len(); i++ {
+			definitionAt(defs, i).resetRendering()
+		}
+	}
+
+	return
+}
+
+/*
+Template returns the [text/template] source used to render definitions of
+type typ within the receiver instance.  This will be the source registered
+by way of [Schema.SetTemplate] if present, else the package default.  A
+zero string is returned if typ is unknown.
+*/
+func (r Schema) Template(typ string) (src string) {
+	var funcs map[string]any
+	if src, funcs, _ = templateSample(typ); funcs != nil {
+		src = r.template(typ, src)
+	}
+
+	return
+}
+
 const lDAPSyntaxTmpl = `{{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $extn:=(ExtensionSet) -}}
 {{- $hindent:=.HIndent -}}
 {{- $descl:="DESC " -}}
-{{- $numOID:=.Definition.OID -}}
+{{- $numOID:=(OID) -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $numOID -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
+{{- range (Clauses) -}}
+{{- if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
 {{- end -}}
-{{if $extn -}}{{- $extn -}}{{- end -}}
-{{$close -}}`
+{{- end -}}
+{{- $extn -}}
+{{- $close -}}`
 
 const matchingRuleTmpl = `{{- $open:="( " -}}
 {{- $close:=" )" -}}
-{{- $name:=.Definition.Name -}}
+{{- $name:=(Name) -}}
 {{- $hindent:=.HIndent -}}
 {{- $extn:=(ExtensionSet) -}}
 {{- $descl:="DESC " -}}
 {{- $namel:="NAME " -}}
 {{- $stxl:="SYNTAX " -}}
-{{- $numOID:=.Definition.OID -}}
+{{- $numOID:=(OID) -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $obs:=(Obsolete) -}}
 {{- $obsl:="OBSOLETE" -}}
 {{- $sytx:=(Syntax) -}}
 {{- $open -}}{{- $numOID -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
-{{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "SYNTAX" -}}
+{{- if $sytx -}}{{- $hindent -}}{{- $stxl -}}{{- $sytx -}}{{- end -}}
 {{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{if $sytx -}}
-{{- $hindent -}}{{- $stxl -}} {{- $sytx -}}
 {{- end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
+{{- $extn -}}
+{{- $close -}}`
 
 const attributeTypeTmpl = `{{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $namel:="NAME " -}}
-{{- $name:=.Definition.Name -}}
+{{- $name:=(Name) -}}
 {{- $extn:=(ExtensionSet) -}}
 {{- $hindent:=.HIndent -}}
 {{- $descl:="DESC " -}}
@@ -89,52 +299,43 @@ const attributeTypeTmpl = `{{- $open:="( " -}}
 {{- $nomod:="NO-USER-MODIFICATION" -}}
 {{- $sup:=(SuperType) -}}
 {{- $mub:=(MUB) -}}
-{{- $numOID:=.Definition.OID -}}
+{{- $numOID:=(OID) -}}
 {{- $open -}}{{- $numOID -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
-{{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
-{{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{if $sup -}}
-{{- $hindent -}}{{- $supl -}} {{- $sup -}}
-{{- end -}}
-{{if $eql -}}
-{{- $hindent -}}{{- $eqll -}} {{- $eql -}}
-{{- end -}}
-{{if $sub -}}
-{{- $hindent -}}{{- $subl -}} {{- $sub -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "SUP" -}}
+{{- if $sup -}}{{- $hindent -}}{{- $supl -}}{{- $sup -}}{{- end -}}
+{{- else if eq . "EQUALITY" -}}
+{{- if $eql -}}{{- $hindent -}}{{- $eqll -}}{{- $eql -}}{{- end -}}
+{{- else if eq . "SUBSTR" -}}
+{{- if $sub -}}{{- $hindent -}}{{- $subl -}}{{- $sub -}}{{- end -}}
+{{- else if eq . "ORDERING" -}}
+{{- if $ord -}}{{- $hindent -}}{{- $ordl -}}{{- $ord -}}{{- end -}}
+{{- else if eq . "SYNTAX" -}}
+{{- if $sytx -}}{{- $hindent -}}{{- $stxl -}}{{- $sytx -}}
+{{- if ne $mub "{0}" -}}{{- $mub -}}{{- end -}}
 {{- end -}}
-{{if $ord -}}
-{{- $hindent -}}{{- $ordl -}} {{- $ord -}}
+{{- else if eq . "SINGLE-VALUE" -}}
+{{- if $single -}}{{- $hindent -}}{{- $sv -}}{{- end -}}
+{{- else if eq . "COLLECTIVE" -}}
+{{- if and $collective (not $single) -}}{{- $hindent -}}{{- $coll -}}{{- end -}}
+{{- else if eq . "NO-USER-MODIFICATION" -}}
+{{- if $nousermod -}}{{- $hindent -}}{{- $nomod -}}{{- end -}}
+{{- else if eq . "USAGE" -}}
+{{- if $usage -}}{{- $hindent -}}{{- $usagel -}}{{- $usage -}}{{- end -}}
 {{- end -}}
-{{if $sytx -}}
-{{- $hindent -}}{{- $stxl -}} {{- $sytx -}}
-{{if ne $mub "{0}" -}}
-{{- $mub -}}
 {{- end -}}
-{{- end -}}
-{{if $single -}}
-{{- $hindent -}}{{- $sv -}}
-{{- else if $collective -}}
-{{- $hindent -}}{{- $coll -}}
-{{- end -}}
-{{if $nousermod -}}
-{{- $hindent -}}{{- $nomod -}}
-{{- end -}}
-{{if $usage -}}
-{{- $hindent -}}{{- $usagel -}} {{- $usage -}}
-{{- end -}}
-{{if $extn -}}{{- $extn}}{{- end -}}
-{{$close -}}`
+{{- $extn -}}
+{{- $close -}}`
 
 const matchingRuleUseTmpl = `{{- $open:="( " -}}
 {{- $close:=" )" -}}
-{{- $name:=.Definition.Name -}}
+{{- $name:=(Name) -}}
 {{- $hindent:=.HIndent -}}
 {{- $extn:=(ExtensionSet) -}}
 {{- $descl:="DESC " -}}
@@ -144,19 +345,19 @@ const matchingRuleUseTmpl = `{{- $open:="( " -}}
 {{- $applied:=Applied -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $numOID -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "APPLIES" -}}
+{{- if $applied -}}{{- $hindent -}}{{- $appl -}}{{- $applied -}}{{- end -}}
 {{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
 {{- end -}}
-{{if $applied -}}
-{{- $hindent -}}{{- $appl -}}{{- $applied -}}
-{{end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
+{{- $extn -}}
+{{- $close -}}`
 
-const objectClassTmpl = `{{- $name:=.Definition.Name -}}
+const objectClassTmpl = `{{- $name:=(Name) -}}
 {{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $extn:=(ExtensionSet) -}}
@@ -172,32 +373,30 @@ const objectClassTmpl = `{{- $name:=.Definition.Name -}}
 {{- $suplen:=SuperLen -}}
 {{- $mustlen:=MustLen -}}
 {{- $maylen:=MayLen -}}
-{{- $id:=.Definition.OID -}}
+{{- $id:=(OID) -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $id -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "SUP" -}}
+{{- if gt $suplen 0 -}}{{- $hindent -}}{{- $supl -}}{{- $.Definition.SuperClasses -}}{{- end -}}
+{{- else if eq . "KIND" -}}
+{{- $hindent -}}{{- $kind -}}
+{{- else if eq . "MUST" -}}
+{{- if gt $mustlen 0 -}}{{- $hindent -}}{{- $mustl -}}{{- $.Definition.Must -}}{{- end -}}
+{{- else if eq . "MAY" -}}
+{{- if gt $maylen 0 -}}{{- $hindent -}}{{- $mayl -}}{{- $.Definition.May -}}{{- end -}}
 {{- end -}}
-{{if $desc -}}
-{{- $hindent  -}}{{- $descl -}}'{{- $desc -}}'
 {{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{if gt $suplen 0 -}}
-{{- $hindent -}}{{- $supl -}}{{- .Definition.SuperClasses -}}
-{{end -}}
-{{- $hindent -}}{{- $kind -}}
-{{if gt $mustlen 0 -}}
-{{- $hindent -}}{{- $mustl -}}{{- .Definition.Must -}}
-{{end -}}
-{{if gt $maylen 0 -}}
-{{- $hindent -}}{{- $mayl -}}{{- .Definition.May -}}
-{{end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
-
-const dITContentRuleTmpl = `{{- $name:=.Definition.Name -}}
+{{- $extn -}}
+{{- $close -}}`
+
+const dITContentRuleTmpl = `{{- $name:=(Name) -}}
 {{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $extn:=(ExtensionSet) -}}
@@ -217,31 +416,27 @@ const dITContentRuleTmpl = `{{- $name:=.Definition.Name -}}
 {{- $id:=(StructuralOID) -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $id -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "AUX" -}}
+{{- if gt $auxlen 0 -}}{{- $hindent -}}{{- $auxl -}}{{- $.Definition.Aux -}}{{- end -}}
+{{- else if eq . "MUST" -}}
+{{- if gt $mustlen 0 -}}{{- $hindent -}}{{- $mustl -}}{{- $.Definition.Must -}}{{- end -}}
+{{- else if eq . "MAY" -}}
+{{- if gt $maylen 0 -}}{{- $hindent -}}{{- $mayl -}}{{- $.Definition.May -}}{{- end -}}
+{{- else if eq . "NOT" -}}
+{{- if gt $notlen 0 -}}{{- $hindent -}}{{- $notl -}}{{- $.Definition.Not -}}{{- end -}}
 {{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
 {{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{if gt $auxlen 0 -}}
-{{- $hindent -}}{{- $auxl -}}{{- .Definition.Aux -}}
-{{end -}}
-{{if gt $mustlen 0 -}}
-{{- $hindent -}}{{- $mustl -}}{{- .Definition.Must -}}
-{{end -}}
-{{if gt $maylen 0 -}}
-{{- $hindent -}}{{- $mayl -}}{{- .Definition.May -}}
-{{end -}}
-{{if gt $notlen 0 -}}
-{{- $hindent -}}{{- $notl -}}{{- .Definition.Not -}}
-{{end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
-
-const nameFormTmpl = `{{- $name:=.Definition.Name -}}
+{{- $extn -}}
+{{- $close -}}`
+
+const nameFormTmpl = `{{- $name:=(Name) -}}
 {{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $extn:=(ExtensionSet) -}}
@@ -255,27 +450,28 @@ const nameFormTmpl = `{{- $name:=.Definition.Name -}}
 {{- $ocl:="OC " -}}
 {{- $oc:=.Definition.Structural.OID -}}
 {{- $maylen:=MayLen -}}
-{{- $id:=.Definition.OID -}}
+{{- $id:=(OID) -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $id -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "OC" -}}
+{{- $hindent -}}{{- $ocl -}}{{- $oc -}}
+{{- else if eq . "MUST" -}}
+{{- $hindent -}}{{- $mustl -}}{{- $.Definition.Must -}}
+{{- else if eq . "MAY" -}}
+{{- if gt $maylen 0 -}}{{- $hindent -}}{{- $mayl -}}{{- $.Definition.May -}}{{- end -}}
 {{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
 {{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{- $hindent -}}{{- $ocl -}}{{- $oc -}}
-{{- $hindent -}}{{- $mustl -}}{{- .Definition.Must -}}
-{{if gt $maylen 0 -}}
-{{- $hindent -}}{{- $mayl -}}{{- .Definition.May -}}
-{{end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
-
-const dITStructureRuleTmpl = `{{- $name:=.Definition.Name -}}
+{{- $extn -}}
+{{- $close -}}`
+
+const dITStructureRuleTmpl = `{{- $name:=(Name) -}}
 {{- $open:="( " -}}
 {{- $close:=" )" -}}
 {{- $obs:=(Obsolete) -}}
@@ -291,18 +487,18 @@ const dITStructureRuleTmpl = `{{- $name:=.Definition.Name -}}
 {{- $form:=.Definition.Form.OID -}}
 {{- $desc:=.Definition.Desc -}}
 {{- $open -}}{{- $id -}}
-{{if $name -}}
-{{- $hindent -}}{{- $namel -}}{{- $name -}}
+{{- range (Clauses) -}}
+{{- if eq . "NAME" -}}
+{{- if $name -}}{{- $hindent -}}{{- $namel -}}{{- $name -}}{{- end -}}
+{{- else if eq . "DESC" -}}
+{{- if $desc -}}{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'{{- end -}}
+{{- else if eq . "OBSOLETE" -}}
+{{- if $obs -}}{{- $hindent -}}{{- $obsl -}}{{- end -}}
+{{- else if eq . "FORM" -}}
+{{- $hindent -}}{{- $forml -}}{{- $form -}}
+{{- else if eq . "SUP" -}}
+{{- if gt $suplen 0 -}}{{- $hindent -}}{{- $supl -}}{{- $.Definition.SuperRules -}}{{- end -}}
 {{- end -}}
-{{if $desc -}}
-{{- $hindent -}}{{- $descl -}}'{{- $desc -}}'
 {{- end -}}
-{{if $obs -}}
-{{- $hindent -}}{{- $obsl -}}
-{{end -}}
-{{- $hindent -}}{{- $forml -}}{{- $form -}}
-{{if gt $suplen 0 -}}
-{{- $hindent -}}{{- $supl -}}{{- .Definition.SuperRules -}}
-{{end -}}
-{{if $extn -}}{{$extn}}{{- end -}}
-{{$close -}}`
+{{- $extn -}}
+{{- $close -}}`
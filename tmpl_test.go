@@ -0,0 +1,93 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the registration of a custom template used to
+render all [AttributeType] instances within a [Schema].
+*/
+func ExampleSchema_SetTemplate() {
+	sch := mySchema.Clone()
+	err := sch.SetTemplate(`attributeType`,
+		`{{.Definition.OID}} {{.Definition.Name}}{{if Collective}} [collective]{{end}}`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.AttributeTypes().Get(`cn`))
+	// Output: 2.5.4.3 ( 'cn' 'commonName' )
+}
+
+func TestSchema_SetTemplate(t *testing.T) {
+	sch := mySchema.Clone()
+	at := sch.AttributeTypes().Get(`cn`)
+	want := at.String()
+
+	custom := `{{.Definition.OID}}{{.HIndent}}SYNTAX {{Syntax}}`
+	if err := sch.SetTemplate(`attributeType`, custom); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if got := sch.Template(`attributeType`); got != custom {
+		t.Errorf("%s failed: unexpected template: %s", t.Name(), got)
+		return
+	}
+
+	// cached rendering must have been reset
+	if got := at.String(); got == want || !hasPfx(got, `2.5.4.3`) {
+		t.Errorf("%s failed: template not applied: %s", t.Name(), got)
+		return
+	}
+
+	// unaffected definition types keep the default template
+	if got := sch.ObjectClasses().Get(`top`).String(); !hasPfx(got, `( 2.5.6.0`) {
+		t.Errorf("%s failed: unexpected objectClass rendering: %s", t.Name(), got)
+		return
+	}
+
+	// clones carry registered templates, whereas the source is untouched
+	if got := sch.Clone().AttributeTypes().Get(`cn`).String(); got != at.String() {
+		t.Errorf("%s failed: template not cloned: %s", t.Name(), got)
+		return
+	} else if got = mySchema.AttributeTypes().Get(`cn`).String(); got != want {
+		t.Errorf("%s failed: source schema altered: %s", t.Name(), got)
+		return
+	}
+
+	// bogus inputs
+	if err := sch.SetTemplate(`attributeTypo`, custom); err == nil {
+		t.Errorf("%s failed: expected error for unknown type", t.Name())
+		return
+	} else if err = sch.SetTemplate(`attributeType`, `{{Kind}}`); err == nil {
+		t.Errorf("%s failed: expected error for undefined function", t.Name())
+		return
+	} else if err = (Schema{}).SetTemplate(`attributeType`, custom); err == nil {
+		t.Errorf("%s failed: expected error for zero schema", t.Name())
+		return
+	}
+
+	// the package-default templates execute against empty definitions
+	for _, typ := range []string{`ldapSyntax`, `matchingRule`, `attributeType`,
+		`matchingRuleUse`, `objectClass`, `dITContentRule`, `nameForm`, `dITStructureRule`} {
+		if err := NewSchema().SetTemplate(typ, sch.Template(typ)); err != nil {
+			t.Errorf("%s failed: %s: %v", t.Name(), typ, err)
+			return
+		}
+	}
+
+	// restore the default
+	if err := sch.SetTemplate(`attributeType`, ``); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := at.String(); got != want {
+		t.Errorf("%s failed: default not restored:\nwant: %s\ngot:  %s", t.Name(), want, got)
+		return
+	} else if sch.Template(`attributeType`) != attributeTypeTmpl {
+		t.Errorf("%s failed: default template not returned", t.Name())
+	}
+}
@@ -0,0 +1,524 @@
+package schemax
+
+/*
+tx.go implements atomic change batches against a Schema.
+*/
+
+/*
+Begin returns a new instance of [Tx], through which any number of changes
+may be staged against the receiver instance.
+
+Staged changes are written to a private working copy (see [Schema.Clone])
+and shall not be visible within the receiver until [Tx.Commit] succeeds.
+Should any staged change fail, or should the resulting [Schema] fail the
+[Schema.Compliant] checks during [Tx.Commit], the receiver is left intact.
+
+Any [Observer] instances registered with the receiver are notified of the
+staged changes only upon a successful commit.
+
+Only one transaction may be active against the receiver at any given time;
+this method blocks until any other active transaction has been concluded
+by way of [Tx.Commit] or [Tx.Rollback].  See [Schema.Snapshot] for details
+regarding the concurrency model of this package.
+
+A zero instance of [Tx] is returned if the receiver is zero or frozen.
+
+Upon a successful commit, only the staged changes are applied to the
+receiver; [Definition] instances obtained from the receiver prior to the
+commit remain associated with it.  See [Tx.Commit] for details.
+*/
+func (r Schema) Begin() (t Tx) {
+	if !r.IsZero() && !r.IsFrozen() {
+		r.snapshots().writer.Lock()
+		t = Tx{&tx{
+			schema:  r,
+			stage:   r.Clone(),
+			version: r.observers().version(),
+		}}
+
+		// Record staged events for delivery
+		// to the target upon commit.
+		t.tx.stage.AddObserver(func(ev Event) {
+			t.tx.events = append(t.tx.events, ev)
+		})
+
+		// Record changes made to staged definitions
+		// by way of their Set methods.
+		t.tx.stage.observers().modified = func(x Definition) {
+			t.tx.events = append(t.tx.events, Event{Kind: modifyEvent, New: x})
+		}
+	}
+
+	return
+}
+
+/*
+modifyEvent is recorded within a [Tx] following a change made to a staged
+[Definition] by way of its Set methods.  It is never delivered to any
+[Observer].
+*/
+const modifyEvent EventKind = 1<<8 - 1
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r Tx) IsZero() bool {
+	return r.tx == nil
+}
+
+/*
+Schema returns the working copy of [Schema] in which changes are staged.
+
+This is useful for crafting new [Definition] instances for submission
+via [Tx.Push] or [Tx.Replace], e.g.:
+
+	oc := tx.Schema().NewObjectClass()
+*/
+func (r Tx) Schema() (s Schema) {
+	if !r.IsZero() {
+		s = r.tx.stage
+	}
+
+	return
+}
+
+/*
+active returns an error if the receiver is zero, or if it has already
+been committed or rolled back.
+*/
+func (r Tx) active() (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+	} else if r.tx.closed {
+		err = ErrTxClosed
+	}
+
+	return
+}
+
+/*
+parse returns an error following an attempt to execute the input parser
+function using raw.
+*/
+func (r Tx) parse(parser func(string) error, raw string) (err error) {
+	if err = r.active(); err == nil {
+		err = parser(raw)
+	}
+
+	return
+}
+
+/*
+ParseLDAPSyntax stages the [LDAPSyntax] represented by raw. See
+[Schema.ParseLDAPSyntax] for details.
+*/
+func (r Tx) ParseLDAPSyntax(raw string) error {
+	return r.parse(r.Schema().ParseLDAPSyntax, raw)
+}
+
+/*
+ParseMatchingRule stages the [MatchingRule] represented by raw. See
+[Schema.ParseMatchingRule] for details.
+*/
+func (r Tx) ParseMatchingRule(raw string) error {
+	return r.parse(r.Schema().ParseMatchingRule, raw)
+}
+
+/*
+ParseMatchingRuleUse stages the [MatchingRuleUse] represented by raw. See
+[Schema.ParseMatchingRuleUse] for details.
+*/
+func (r Tx) ParseMatchingRuleUse(raw string) error {
+	return r.parse(r.Schema().ParseMatchingRuleUse, raw)
+}
+
+/*
+ParseAttributeType stages the [AttributeType] represented by raw. See
+[Schema.ParseAttributeType] for details.
+*/
+func (r Tx) ParseAttributeType(raw string) error {
+	return r.parse(r.Schema().ParseAttributeType, raw)
+}
+
+/*
+ParseObjectClass stages the [ObjectClass] represented by raw. See
+[Schema.ParseObjectClass] for details.
+*/
+func (r Tx) ParseObjectClass(raw string) error {
+	return r.parse(r.Schema().ParseObjectClass, raw)
+}
+
+/*
+ParseDITContentRule stages the [DITContentRule] represented by raw. See
+[Schema.ParseDITContentRule] for details.
+*/
+func (r Tx) ParseDITContentRule(raw string) error {
+	return r.parse(r.Schema().ParseDITContentRule, raw)
+}
+
+/*
+ParseNameForm stages the [NameForm] represented by raw. See
+[Schema.ParseNameForm] for details.
+*/
+func (r Tx) ParseNameForm(raw string) error {
+	return r.parse(r.Schema().ParseNameForm, raw)
+}
+
+/*
+ParseDITStructureRule stages the [DITStructureRule] represented by raw.
+See [Schema.ParseDITStructureRule] for details.
+*/
+func (r Tx) ParseDITStructureRule(raw string) error {
+	return r.parse(r.Schema().ParseDITStructureRule, raw)
+}
+
+/*
+ParseRaw stages all definitions found within raw. See [Schema.ParseRaw]
+for details.
+*/
+func (r Tx) ParseRaw(raw []byte) (err error) {
+	if err = r.active(); err == nil {
+		err = r.Schema().ParseRaw(raw)
+	}
+
+	return
+}
+
+/*
+ParseFile stages all definitions found within file. See [Schema.ParseFile]
+for details.
+*/
+func (r Tx) ParseFile(file string) error {
+	return r.parse(r.Schema().ParseFile, file)
+}
+
+/*
+ParseDirectory stages all definitions found within dir. See
+[Schema.ParseDirectory] for details.
+*/
+func (r Tx) ParseDirectory(dir string) error {
+	return r.parse(r.Schema().ParseDirectory, dir)
+}
+
+/*
+rebind returns x as-is if it is already associated with the working
+copy of the receiver instance.  Otherwise, a copy of x is returned in
+which all references are satisfied by definitions within the working
+copy wherever possible.
+*/
+func (r Tx) rebind(x Definition) Definition {
+	if x.Schema() == r.tx.stage {
+		return x
+	}
+
+	return r.tx.stage.adopt(x)
+}
+
+/*
+Push returns an error following an attempt to stage the push of x into
+the appropriate collection.  The input value must be compliant and must
+not already be present.
+
+If x was not crafted using the [Schema] instance returned by [Tx.Schema],
+a copy of x is staged instead, with all of its references satisfied by
+the staged definitions.
+*/
+func (r Tx) Push(x Definition) (err error) {
+	if err = r.active(); err != nil {
+		return
+	} else if x == nil || x.IsZero() {
+		err = ErrNilInput
+		return
+	}
+
+	x = r.rebind(x)
+	if !x.Compliant() {
+		err = ErrDefNonCompliant
+	} else if defs := r.tx.stage.collection(x.Type()); defs == nil {
+		err = ErrInvalidType
+	} else if r.tx.stage.index(x) != -1 {
+		err = mkerr(ErrNotUnique.Error() + ": " + x.Type() + `, ` + x.NumericOID())
+	} else {
+		err = defs.Push(x)
+	}
+
+	return
+}
+
+/*
+Replace returns an error following an attempt to stage the replacement
+of the [Definition] identified by x.  See [Schema.Replace] for details.
+
+An error is returned if the [AllowOverride] option has not been set,
+if x is not compliant, or if no [Definition] is identified by x.
+*/
+func (r Tx) Replace(x Definition) (err error) {
+	if err = r.active(); err != nil {
+		return
+	} else if x == nil || x.IsZero() {
+		err = ErrNilInput
+		return
+	} else if !r.tx.stage.Options().Positive(AllowOverride) {
+		err = ErrOverrideNotAllowed
+		return
+	}
+
+	x = r.rebind(x)
+	if !x.Compliant() {
+		err = ErrDefNonCompliant
+	} else if r.tx.stage.index(x) == -1 {
+		err = mkerr(ErrDefNotFound.Error() + ": " + x.Type())
+	} else {
+		r.tx.stage.Replace(x)
+	}
+
+	return
+}
+
+/*
+Remove returns an error following an attempt to stage the removal of the
+[Definition] identified by x.  Identification is based upon the numeric
+OID, or the rule ID in the case of a [DITStructureRule].
+
+Note that removal of a [Definition] upon which others still depend shall
+cause [Tx.Commit] to fail, unless the dependents are also removed.
+
+If the [Schema] contains [MatchingRuleUse] instances, they are regenerated
+following the removal of an [AttributeType] or [MatchingRule].
+*/
+func (r Tx) Remove(x Definition) (err error) {
+	if err = r.active(); err != nil {
+		return
+	} else if x == nil || x.IsZero() {
+		err = ErrNilInput
+		return
+	}
+
+	stage := r.tx.stage
+	idx := stage.index(x)
+	if idx == -1 {
+		err = mkerr(ErrDefNotFound.Error() + ": " + x.Type())
+		return
+	}
+	stage.remove(x.Type(), idx)
+
+	switch x.Type() {
+	case `attributeType`, `matchingRule`:
+		if mus := stage.MatchingRuleUses(); mus.len() > 0 {
+			for i := 0; i < mus.len(); i++ {
+				stage.notify(Event{Kind: RemoveEvent, Old: mus.index(i)})
+			}
+			stage.setCollection(NewMatchingRuleUses(), matchingRuleUsesIndex)
+			err = stage.updateMatchingRuleUses(stage.AttributeTypes())
+		}
+	}
+
+	return
+}
+
+/*
+Commit returns an error following an attempt to validate and publish all
+staged changes to the target [Schema] instance.
+
+Staged changes are applied to the target in the order in which they were
+made: pushed definitions are copied into the target, replaced definitions
+are overridden in place (see [Schema.Replace]) and removed definitions are
+removed.  Any macros set are also applied, and the [Options] of the target
+are updated to match those of the working copy.  Definitions untouched by
+the transaction are left as-is.
+
+Changes made to staged definitions by way of their various Set methods
+are applied as replacements, reflecting the state of each such definition
+at the time of commit.
+
+An error is returned and the target is left untouched if the target was
+modified by other means since [Schema.Begin] was called ([ErrTxConflict]),
+or if the staged [Schema] fails [Schema.Compliant] checks.
+
+The receiver is concluded in all cases, successful or otherwise, and may
+not be used further.
+
+Upon success, a new snapshot is published atomically for the benefit of
+any readers (see [Schema.Snapshot]), after which the applied changes are
+delivered to any registered [Observer] instances.
+*/
+func (r Tx) Commit() (err error) {
+	if err = r.active(); err != nil {
+		return
+	}
+
+	target := r.tx.schema
+	if target.observers().version() != r.tx.version {
+		err = ErrTxConflict
+		r.close()
+		return
+	} else if !r.tx.stage.Compliant() {
+		err = ErrDefNonCompliant
+		r.close()
+		return
+	}
+
+	sn := target.snapshots()
+	obs := target.observers()
+
+	// Events are held until the new snapshot is
+	// published, as observers may request it.
+	sn.mutex.Lock()
+	obs.hold()
+	target.apply(r.tx.stage, r.tx.events)
+	events := obs.release()
+	target.republish()
+	sn.mutex.Unlock()
+
+	r.close()
+
+	for _, ev := range events {
+		obs.deliver(ev)
+	}
+
+	return
+}
+
+/*
+Rollback discards all staged changes.  The target [Schema] instance is
+left untouched.
+*/
+func (r Tx) Rollback() (err error) {
+	if err = r.active(); err == nil {
+		r.close()
+	}
+
+	return
+}
+
+/*
+close concludes the receiver instance, allowing other transactions to
+begin.
+*/
+func (r Tx) close() {
+	r.tx.stage = Schema{}
+	r.tx.events = nil
+	r.tx.closed = true
+	r.tx.schema.snapshots().writer.Unlock()
+}
+
+/*
+apply replays the input events, as recorded within stage, upon the
+receiver instance, including any changes made to staged definitions by
+way of their Set methods, after which the [Options] of stage are written to
+the receiver.
+*/
+func (r Schema) apply(stage Schema, events []Event) {
+	// Staged definitions are copied in their final
+	// state, thus each need only be copied once per
+	// push, replacement or series of changes.
+	done := make(map[any]bool, 0)
+	for _, ev := range events {
+		switch ev.Kind {
+		case PushEvent:
+			done[definitionPointer(ev.New)] = true
+			def := r.adopt(ev.New)
+			pushAndNotify(r.collection(def.Type()).cast(), def)
+		case ReplaceEvent:
+			done[definitionPointer(ev.New)] = true
+			r.replace(r.adopt(ev.New))
+		case modifyEvent:
+			if ptr := definitionPointer(ev.New); !done[ptr] && r.index(ev.New) != -1 {
+				done[ptr] = true
+				r.replace(r.adopt(ev.New))
+			}
+		case RemoveEvent:
+			if idx := r.index(ev.Old); idx != -1 {
+				r.remove(ev.Old.Type(), idx)
+			}
+		case MacroEvent:
+			r.Macros().Set(ev.Macro, ev.NewValue)
+		}
+	}
+
+	// Options are written in place, as the receiver's
+	// auxiliary map may be read concurrently.
+	opts := r.Options()
+	for i := 0; i < 16; i++ {
+		if opt := Option(1 << i); stage.Options().Positive(opt) {
+			opts.Shift(opt)
+		} else {
+			opts.Unshift(opt)
+		}
+	}
+}
+
+/*
+adopt returns a copy of x associated with the receiver instance, in which
+all references are satisfied by definitions within the receiver wherever
+possible.
+*/
+func (r Schema) adopt(x Definition) Definition {
+	cl := newCloner(r)
+	cl.resolve = true
+	cl.root = definitionPointer(x)
+
+	return cl.definition(x)
+}
+
+/*
+remove removes the definition found at index idx of the receiver's
+collection of the named type, delivering a [RemoveEvent] to any
+registered [Observer] instances.
+*/
+func (r Schema) remove(typ string, idx int) {
+	defs := r.collection(typ)
+	slice, _ := defs.cast().Remove(idx)
+	collectionLookup(defs.cast()).invalidate()
+	r.notify(Event{Kind: RemoveEvent, Old: slice.(Definition)})
+}
+
+/*
+setSchema associates the input [Definition] with s.
+*/
+func setSchema(x any, s Schema) {
+	switch tv := x.(type) {
+	case LDAPSyntax:
+		tv.lDAPSyntax.schema = s
+	case MatchingRule:
+		tv.matchingRule.schema = s
+	case AttributeType:
+		tv.attributeType.schema = s
+	case MatchingRuleUse:
+		tv.matchingRuleUse.schema = s
+	case ObjectClass:
+		tv.objectClass.schema = s
+	case DITContentRule:
+		tv.dITContentRule.schema = s
+	case NameForm:
+		tv.nameForm.schema = s
+	case DITStructureRule:
+		tv.dITStructureRule.schema = s
+	}
+}
+
+/*
+definitionPointer returns the underlying pointer instance of x.
+*/
+func definitionPointer(x Definition) (ptr any) {
+	switch tv := x.(type) {
+	case LDAPSyntax:
+		ptr = tv.lDAPSyntax
+	case MatchingRule:
+		ptr = tv.matchingRule
+	case AttributeType:
+		ptr = tv.attributeType
+	case MatchingRuleUse:
+		ptr = tv.matchingRuleUse
+	case ObjectClass:
+		ptr = tv.objectClass
+	case DITContentRule:
+		ptr = tv.dITContentRule
+	case NameForm:
+		ptr = tv.nameForm
+	case DITStructureRule:
+		ptr = tv.dITStructureRule
+	}
+
+	return
+}
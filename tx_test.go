@@ -0,0 +1,293 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates staging multiple changes within a transaction,
+one of which fails.  The transaction is rolled back, leaving the original
+[Schema] instance untouched.
+*/
+func ExampleTx_Rollback() {
+	sch := NewSchema()
+	tx := sch.Begin()
+
+	for _, raw := range []string{
+		`( 1.3.6.1.4.1.56521.999.88.1 NAME 'txAttrOne' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`,
+		`( 1.3.6.1.4.1.56521.999.88.2 NAME 'txAttrTwo' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`,
+		`( 1.3.6.1.4.1.56521.999.88.3 NAME 'txAttrThree' SYNTAX bogus )`,
+	} {
+		if err := tx.ParseAttributeType(raw); err != nil {
+			tx.Rollback()
+			break
+		}
+	}
+
+	fmt.Println(sch.AttributeTypes().Contains(`txAttrOne`))
+	// Output: false
+}
+
+/*
+This example demonstrates a successful transaction, in which the staged
+changes are published to the original [Schema] instance upon commit.
+*/
+func ExampleTx_Commit() {
+	sch := NewSchema()
+	tx := sch.Begin()
+
+	tx.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.1
+		NAME 'txAttrOne'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	tx.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.88.2
+		NAME 'txClass'
+		SUP top AUXILIARY
+		MAY txAttrOne )`)
+
+	fmt.Println(sch.ObjectClasses().Contains(`txClass`))
+	if err := tx.Commit(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(sch.ObjectClasses().Get(`txClass`).May().Index(0).Schema() == sch)
+	// Output:
+	// false
+	// true
+}
+
+func TestTx(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	before := sch.Counters()
+
+	// handles obtained prior to the commit must remain valid
+	cn := sch.AttributeTypes().Get(`cn`)
+	groupOfNames := sch.ObjectClasses().Get(`groupOfNames`)
+
+	tx := sch.Begin()
+	if tx.IsZero() {
+		t.Errorf("%s failed: zero transaction", t.Name())
+		return
+	}
+
+	// push a definition crafted using the original schema; its
+	// references must be rebound to the staged definitions.
+	oc := sch.NewObjectClass().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.88.10`).
+		SetName(`txPushClass`).
+		SetKind(AuxiliaryKind).
+		SetSuperClass(`top`).
+		SetMay(`cn`, `description`).
+		SetStringer()
+	if err := tx.Push(oc); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = tx.Push(oc); err == nil {
+		t.Errorf("%s failed: expected uniqueness error", t.Name())
+		return
+	}
+
+	staged := tx.Schema().ObjectClasses().Get(`txPushClass`)
+	if staged.May().Index(0).attributeType != tx.Schema().AttributeTypes().Get(`cn`).attributeType {
+		t.Errorf("%s failed: MAY clause not rebound", t.Name())
+		return
+	}
+
+	// replace an existing definition
+	gon := tx.Schema().ObjectClasses().Get(`groupOfNames`)
+	ngon := tx.Schema().NewObjectClass().
+		SetNumericOID(gon.NumericOID()).
+		SetName(gon.Name()).
+		SetKind(gon.Kind()).
+		SetSuperClass(`top`).
+		SetMust(`cn`).
+		SetMay(`member`).
+		SetStringer()
+	if err := tx.Replace(ngon); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// remove a definition nothing depends upon
+	if err := tx.Remove(tx.Schema().ObjectClasses().Get(`dcObject`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	tx.Schema().Options().Shift(SortLists)
+	tx.Schema().Macros().Set(`1.3.6.1.4.1.56521.999.88`, `txMacro`)
+
+	// nothing visible until commit
+	if sch.Counters() != before || sch.ObjectClasses().Get(`groupOfNames`).Must().Len() != 2 {
+		t.Errorf("%s failed: changes visible prior to commit", t.Name())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if !sch.ObjectClasses().Contains(`txPushClass`) ||
+		sch.ObjectClasses().Contains(`dcObject`) ||
+		sch.ObjectClasses().Get(`groupOfNames`).Must().Len() != 1 {
+		t.Errorf("%s failed: changes not published", t.Name())
+		return
+	} else if !sch.Compliant() {
+		t.Errorf("%s failed: published schema not compliant", t.Name())
+		return
+	}
+
+	if sch.AttributeTypes().Get(`cn`).attributeType != cn.attributeType ||
+		groupOfNames.Must().Len() != 1 || groupOfNames.Schema() != sch {
+		t.Errorf("%s failed: prior handles not retained", t.Name())
+		return
+	} else if pc := sch.ObjectClasses().Get(`txPushClass`); pc.Schema() != sch ||
+		pc.May().Index(0).attributeType != cn.attributeType {
+		t.Errorf("%s failed: pushed definition not adopted", t.Name())
+		return
+	} else if !sch.Options().Positive(SortLists) {
+		t.Errorf("%s failed: options not published", t.Name())
+		return
+	} else if m, _ := sch.Macros().Resolve(`1.3.6.1.4.1.56521.999.88`); m != `txMacro` {
+		t.Errorf("%s failed: macros not published", t.Name())
+		return
+	}
+
+	if err := tx.Commit(); err != ErrTxClosed {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrTxClosed, err)
+		return
+	}
+}
+
+func TestTx_failures(t *testing.T) {
+	sch := NewSchema()
+	before := sch.Counters()
+
+	tx := sch.Begin()
+
+	// removal of a definition upon which others depend
+	if err := tx.Remove(tx.Schema().AttributeTypes().Get(`cn`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = tx.Commit(); err != ErrDefNonCompliant {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrDefNonCompliant, err)
+		return
+	} else if sch.Counters() != before || !sch.AttributeTypes().Contains(`cn`) {
+		t.Errorf("%s failed: original schema altered", t.Name())
+		return
+	} else if err = tx.Rollback(); err != ErrTxClosed {
+		t.Errorf("%s failed: failed commit did not conclude transaction", t.Name())
+		return
+	}
+
+	// a failed commit must not block subsequent transactions
+	tx = sch.Begin()
+
+	// override not allowed by default
+	if err := tx.Replace(tx.Schema().ObjectClasses().Get(`top`)); err != ErrOverrideNotAllowed {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrOverrideNotAllowed, err)
+		return
+	}
+
+	if err := tx.Remove(ObjectClass{}); err != ErrNilInput {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilInput, err)
+		return
+	} else if err = tx.Remove(NewObjectClass().SetNumericOID(`1.3.6.1.4.1.56521.999.404`)); err == nil {
+		t.Errorf("%s failed: expected not found error", t.Name())
+		return
+	} else if err = tx.Push(NewObjectClass()); err != ErrDefNonCompliant {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrDefNonCompliant, err)
+		return
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	for _, err := range []error{
+		tx.Rollback(),
+		tx.ParseLDAPSyntax(``),
+		tx.ParseMatchingRule(``),
+		tx.ParseMatchingRuleUse(``),
+		tx.ParseAttributeType(``),
+		tx.ParseObjectClass(``),
+		tx.ParseDITContentRule(``),
+		tx.ParseNameForm(``),
+		tx.ParseDITStructureRule(``),
+		tx.ParseRaw([]byte{}),
+		tx.ParseFile(``),
+		tx.ParseDirectory(``),
+		tx.Push(ObjectClass{}),
+		tx.Replace(ObjectClass{}),
+		tx.Remove(ObjectClass{}),
+	} {
+		if err != ErrTxClosed {
+			t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrTxClosed, err)
+			return
+		}
+	}
+
+	// changes made to the target outside of the transaction
+	tx = sch.Begin()
+	tx.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.20 NAME 'txStaged' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.21 NAME 'txDirect' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	if err := tx.Commit(); err != ErrTxConflict {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrTxConflict, err)
+		return
+	} else if sch.AttributeTypes().Contains(`txStaged`) || !sch.AttributeTypes().Contains(`txDirect`) {
+		t.Errorf("%s failed: conflicting transaction applied", t.Name())
+		return
+	} else if err = tx.Rollback(); err != ErrTxClosed {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrTxClosed, err)
+		return
+	}
+
+	// changes made by way of Set methods are committed
+	tx = sch.Begin()
+	held := sch.AttributeTypes().Get(`cn`)
+	tx.Schema().AttributeTypes().Get(`cn`).SetDescription(`Staged description`)
+	tx.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.24 NAME 'txSetStaged' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	tx.Schema().AttributeTypes().Get(`txSetStaged`).SetDescription(`Staged push`)
+	if held.Description() == `Staged description` {
+		t.Errorf("%s failed: staged change visible prior to commit", t.Name())
+		return
+	} else if err := tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if got := held.Description(); got != `Staged description` {
+		t.Errorf("%s failed: staged change not committed: got '%s'", t.Name(), got)
+		return
+	} else if got = sch.AttributeTypes().Get(`txSetStaged`).Description(); got != `Staged push` {
+		t.Errorf("%s failed: staged change to pushed definition not committed: got '%s'", t.Name(), got)
+		return
+	}
+
+	// changes to definitions which are not members of the
+	// target do not conflict
+	tx = sch.Begin()
+	tx.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.88.22 NAME 'txStagedAgain' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	standalone := sch.NewAttributeType()
+	if err := standalone.Parse(`( 1.3.6.1.4.1.56521.999.88.23 NAME 'txStandalone' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = standalone.UnmarshalJSON([]byte(`{"oid":"1.3.6.1.4.1.56521.999.88.23","name":["txStandalone"],"syntax":"1.3.6.1.4.1.1466.115.121.1.15"}`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if err = tx.Commit(); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if !sch.AttributeTypes().Contains(`txStagedAgain`) || sch.AttributeTypes().Contains(`txStandalone`) {
+		t.Errorf("%s failed: unexpected commit results", t.Name())
+		return
+	}
+
+	var zero Tx
+	if err := zero.Commit(); err != ErrNilReceiver {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+		return
+	} else if !zero.Schema().IsZero() || !(Schema{}).Begin().IsZero() {
+		t.Errorf("%s failed: expected zero instances", t.Name())
+	}
+}
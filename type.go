@@ -42,6 +42,9 @@ for any reason.
 */
 type Macros struct {
 	macros
+	obs    *observers
+	schema Schema // owning schema, if any
+	ro     bool   // frozen
 }
 
 type macros map[string]string
@@ -59,12 +62,12 @@ const (
 	// occupying a single line each.
 	HangingIndents Option = 1 << iota
 
-	// SortExtensions will cause all ANTLR-based parsing
-	// operations to sort all extensions alphabetically
-	// according to the respective XString field value.
+	// SortExtensions has no effect.
 	//
-	// Note this does not influence definition Extensions
-	// that were manually crafted by the user.
+	// Deprecated: all ANTLR-based parsing operations now
+	// sort extensions alphabetically according to their
+	// respective XString field values, as the parser does
+	// not convey the order in which they were written.
 	SortExtensions
 
 	// SortLists will cause all ANTLR-based parsing operations
@@ -141,6 +144,17 @@ controlled string representation per definition.
 */
 type Stringer func() string
 
+/*
+DataCopier is an optional closure function or method signature which
+may be supplied to the [Schema.Clone] method for the purpose of copying
+any user-assigned values (see the various SetData methods) in a manner
+suitable to the underlying type.
+
+The input value is the original data instance, while the return value
+should be an independent copy thereof.
+*/
+type DataCopier func(any) any
+
 /*
 oIDList implements oidlist per § 4.1 of RFC 4512.  Instances
 of this type need not be handled by users directly.
@@ -216,6 +230,75 @@ type extension struct {
 	stringer Stringer
 }
 
+/*
+Tx implements a transaction against an instance of [Schema], allowing
+a batch of changes to be staged, validated as a whole and published in
+a single step.
+
+Instances of this type are created using the [Schema.Begin] method and
+are concluded using either the [Tx.Commit] or [Tx.Rollback] method.
+
+Transactions against a given [Schema] are serialized: [Schema.Begin]
+blocks until any other active transaction has been concluded.  It is
+therefore imperative that every instance is concluded.
+*/
+type Tx struct {
+	*tx
+}
+
+type tx struct {
+	schema  Schema  // the target schema
+	stage   Schema  // the working copy
+	events  []Event // staged events
+	version uint64  // change count of the target upon Begin
+	closed  bool
+}
+
+/*
+Observer is an optional closure function or method signature which may
+be registered with a [Schema] instance by way of the [Schema.AddObserver]
+method, allowing the receipt of an [Event] following each change made to
+the [Schema].
+*/
+type Observer func(Event)
+
+/*
+EventKind describes the nature of an [Event].
+*/
+type EventKind uint8
+
+const (
+	PushEvent    EventKind = iota + 1 // Definition pushed into a Schema collection
+	ReplaceEvent                      // Definition replaced
+	RemoveEvent                       // Definition removed from a Schema collection
+	MacroEvent                        // Macro set within Macros
+)
+
+/*
+Event describes a single change made to a [Schema] instance, and is
+delivered to all registered [Observer] instances.
+
+The Old and New fields contain the [Definition] instances affected by
+the change, as follows:
+
+  - [PushEvent]: New contains the pushed [Definition]
+  - [ReplaceEvent]: Old contains a copy of the [Definition] as it was prior
+    to replacement, while New contains the [Definition] as it is now
+  - [RemoveEvent]: Old contains the removed [Definition]
+
+In the case of a [MacroEvent], the Macro field contains the affected macro
+key, while the OldValue and NewValue fields contain the prior value (if any)
+and the new value respectively.
+*/
+type Event struct {
+	Kind     EventKind
+	Old      Definition
+	New      Definition
+	Macro    string
+	OldValue string
+	NewValue string
+}
+
 /*
 Schema is a practical implementation of a 'subschemaSubentry'
 in that individual definitions are accessible and collectively
@@ -354,10 +437,11 @@ type attributeType struct {
 	Usage      uint
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	valQual  ValueQualifier
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	valQual   ValueQualifier
+	data      any
 }
 
 /*
@@ -408,9 +492,10 @@ type dITContentRule struct {
 	Not        AttributeTypes
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	data      any
 }
 
 /*
@@ -465,9 +550,10 @@ type dITStructureRule struct {
 	SuperRules DITStructureRules
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	data      any
 }
 
 type extensions map[string]QuotedStringList
@@ -504,10 +590,11 @@ type lDAPSyntax struct {
 	Desc       string
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	synQual  SyntaxQualifier
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	synQual   SyntaxQualifier
+	data      any
 }
 
 /*
@@ -556,10 +643,11 @@ type matchingRule struct {
 	Syntax     LDAPSyntax
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	assMatch AssertionMatcher
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	assMatch  AssertionMatcher
+	data      any
 }
 
 /*
@@ -612,9 +700,10 @@ type matchingRuleUse struct {
 	Applies    AttributeTypes
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	data      any
 }
 
 /*
@@ -665,9 +754,10 @@ type nameForm struct {
 	May        AttributeTypes
 	Extensions Extensions
 
-	schema   Schema
-	stringer Stringer
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	data      any
 }
 
 /*
@@ -724,16 +814,18 @@ type objectClass struct {
 	May          AttributeTypes
 	Extensions   Extensions
 
-	schema   Schema
-	stringer Stringer
-	data     any
+	schema    Schema
+	stringer  Stringer
+	rendering *rendering
+	data      any
 }
 
 /*
 Counters is a simple struct type defined to store the current number
 of definition instances within an instance of [Schema].
 
-Instances of this type are NOT thread-safe.
+Instances of this type are plain values, and reflect the state of the
+[Schema] at the time of creation only.  See [Schema.Counters] for details.
 */
 type Counters struct {
 	LS int
@@ -878,6 +970,11 @@ type Definition interface {
 	// is used for a low-cyclo means of resolving macros to actual
 	// numeric OIDs during the parsing phase.
 	macro() []string
+
+	// resetRendering discards the cached string representation of
+	// the definition, such as following a change to the template
+	// used to produce it.
+	resetRendering()
 }
 
 /*
@@ -949,15 +1046,3 @@ type Definitions interface {
 	// explicitly.
 	cast() stackage.Stack
 }
-
-/*
-TODO: Deprecated: get rid of me using Options.HangingIndents
-*/
-func hindent(use bool) (x string) {
-	x = string(rune(10)) + `    `
-	if !use {
-		x = ` `
-	}
-
-	return
-}
@@ -0,0 +1,462 @@
+package schemax
+
+/*
+write.go implements the writing of Schema instances to the filesystem as
+ordered directories of schema files, as well as the file provenance upon
+which such writes may rely.
+*/
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/JesseCoretta/go-antlr4512"
+)
+
+/*
+Layout is a closure signature used to assign each [Definition] to a
+named group by [Schema.WriteDirectory].  Definitions bearing the same
+group name are written to the same file(s); the name itself is used to
+derive the name of each such file.
+
+See [LayoutByOrigin] and [LayoutBySource] for ready-made instances.  A
+zero string return value is interpreted as "schema".
+*/
+type Layout func(Definition) string
+
+/*
+LayoutByOrigin is a [Layout] which groups definitions by the first value
+of their X-ORIGIN extension, e.g.: "RFC4519".  Definitions which lack
+such an extension are grouped under "other".
+*/
+func LayoutByOrigin(def Definition) (group string) {
+	group = `other`
+	if ext, found := def.Extensions().get(`X-ORIGIN`); found && ext.len() > 0 {
+		group = ext.index(0)
+	}
+
+	return
+}
+
+/*
+LayoutBySource is a [Layout] which groups definitions by the name of the
+file from which they were parsed (see [Schema.SourceFile]), minus its
+".schema" extension.  Definitions which were not parsed from a file are
+grouped according to [LayoutByOrigin].
+*/
+func LayoutBySource(def Definition) (group string) {
+	file := def.Schema().SourceFile(def)
+	if len(file) == 0 {
+		return LayoutByOrigin(def)
+	}
+
+	if group = filepath.Base(file); hasSfx(group, `.schema`) {
+		group = group[:len(group)-len(`.schema`)]
+	}
+
+	return
+}
+
+/*
+sources contains the file provenance of definitions parsed by way of the
+[Schema.ParseFile] and [Schema.ParseDirectory] methods.
+*/
+type sources struct {
+	mutex *sync.RWMutex
+	files map[string]string
+}
+
+func newSources() *sources {
+	return &sources{
+		mutex: &sync.RWMutex{},
+		files: make(map[string]string, 0),
+	}
+}
+
+/*
+clone returns a copy of the receiver instance.
+*/
+func (r *sources) clone() (c *sources) {
+	c = newSources()
+	if r != nil {
+		r.mutex.RLock()
+		defer r.mutex.RUnlock()
+		for k, v := range r.files {
+			c.files[k] = v
+		}
+	}
+
+	return
+}
+
+/*
+sources returns the file provenance registry of the receiver instance.
+*/
+func (r Schema) sources() (s *sources) {
+	if !r.IsZero() {
+		s, _ = r.cast().Auxiliary()[`sources`].(*sources)
+	}
+
+	return
+}
+
+/*
+SourceFile returns the path of the file from which def was parsed by way
+of [Schema.ParseFile] or [Schema.ParseDirectory].  A zero string is
+returned if def was not parsed from a file, such as is the case for any
+built-in [Definition].
+
+Should the receiver be an overlay (see [Schema.Overlay]), its base is
+consulted if no provenance is recorded within the receiver.
+
+Note that provenance is not preserved through [Schema.MarshalBinary] or
+[Schema.MarshalJSON].
+*/
+func (r Schema) SourceFile(def Definition) (file string) {
+	if src := r.sources(); src != nil && def != nil && !def.IsZero() {
+		src.mutex.RLock()
+		file = src.files[sourceKey(def)]
+		src.mutex.RUnlock()
+	}
+
+	if len(file) == 0 && r.IsOverlay() {
+		file = r.Base().SourceFile(def)
+	}
+
+	return
+}
+
+/*
+sourceKey returns the string by which the provenance of def is recorded.
+*/
+func sourceKey(def Definition) string {
+	if ds, ok := def.(DITStructureRule); ok {
+		return def.Type() + `:` + uitoa(ds.RuleID())
+	}
+
+	return def.Type() + `:` + def.NumericOID()
+}
+
+/*
+setSources records file as the provenance of all definitions which reside
+within the receiver instance at or beyond the collection indices within
+marks, as returned by a prior call of the marks method.
+*/
+func (r Schema) setSources(file string, marks []int) {
+	src := r.sources()
+	if src == nil {
+		return
+	}
+
+	src.mutex.Lock()
+	defer src.mutex.Unlock()
+
+	for i, defs := range r.collections() {
+		for j := marks[i]; j < defs.Len(); j++ {
+			src.files[sourceKey(definitionAt(defs, j))] = file
+		}
+	}
+}
+
+/*
+definitionAt returns the [Definition] found at index idx within defs.
+*/
+func definitionAt(defs Definitions, idx int) (def Definition) {
+	if slice, found := defs.cast().Index(idx); found {
+		def, _ = slice.(Definition)
+	}
+
+	return
+}
+
+/*
+marks returns the number of definitions within each collection of the
+receiver instance, in collection order.
+*/
+func (r Schema) marks() (m []int) {
+	for _, defs := range r.collections() {
+		m = append(m, defs.Len())
+	}
+
+	return
+}
+
+/*
+parseFile returns an error following an attempt to parse file into the
+receiver instance, recording file as the provenance of each definition
+incorporated.
+
+If non-nil, macros declared within previously parsed files are made
+available through om, which is updated to include those declared within
+file.
+*/
+func (r Schema) parseFile(file string, om antlr4512.Macros) (err error) {
+	s := new4512Schema()
+	for k, v := range om {
+		s.OM[k] = v
+	}
+	r.seedMacros(s.OM)
+
+	var raw []byte
+	if !hasSfx(file, `.schema`) {
+		err = mkerr(ErrInvalidInput.Error() + ": '" + file + "' does not end in '.schema'")
+		return
+	} else if raw, err = os.ReadFile(file); err != nil {
+		return
+	}
+
+	if err = s.ParseRaw([]byte(qdEscapes(string(raw)))); err == nil {
+		marks := r.marks()
+
+		// begin second phase
+		r.adoptMacros(s.OM)
+		if err = r.incorporate(s); err == nil {
+			r.setSources(file, marks)
+		}
+
+		for k, v := range s.OM {
+			if om != nil {
+				om[k] = v
+			}
+		}
+	}
+
+	return
+}
+
+/*
+parseDirectory returns an error following an attempt to parse, in lexical
+order, each file ending in ".schema" found beneath dir.
+*/
+func (r Schema) parseDirectory(dir string) (err error) {
+	if _, err = os.Stat(dir); err != nil {
+		return
+	}
+
+	om := make(antlr4512.Macros, 0)
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && hasSfx(p, `.schema`) {
+			var info fs.FileInfo
+			if info, err = d.Info(); err == nil && info.Size() > 0 {
+				err = r.parseFile(p, om)
+			}
+		}
+
+		return err
+	})
+
+	return
+}
+
+/*
+WriteDirectory returns an error following an attempt to write all of the
+definitions within the receiver instance to dir as a series of files, each
+ending in ".schema", which may be read back using [Schema.ParseDirectory].
+
+The layout closure assigns each [Definition] to a named group.  Use of
+[LayoutByOrigin] or [LayoutBySource] is typical, though any [Layout] may
+be used.  A nil layout results in a single group named "schema".
+
+Each file name bears a numerical prefix such that the lexical order of the
+files satisfies all dependencies between definitions, e.g.:
+
+	00-RFC4512.schema
+	01-RFC4517.schema
+	02-RFC4519.schema
+
+A group is split across multiple files only when necessary to satisfy
+such ordering requirements.
+
+The directory is created if it does not exist.  An error is returned if
+it already contains files ending in ".schema", as these would interfere
+with the parsing process.
+
+Only definitions are written.  The [Macros] instance, [Options] settings
+and DN of the receiver are not, though should the MacroOIDs field of the
+[Formatting] in effect be true, each file shall begin with declarations
+of those macros needed by its definitions (see [Schema.MacroDeclarations]).
+
+Should the receiver be an overlay (see [Schema.Overlay]), the definitions
+of its base are not written.
+*/
+func (r Schema) WriteDirectory(dir string, layout Layout) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	var existing []string
+	if existing, err = filepath.Glob(filepath.Join(dir, `*.schema`)); err != nil {
+		return
+	} else if len(existing) > 0 {
+		err = mkerr(ErrDirectoryNotEmpty.Error() + `: ` + dir)
+		return
+	}
+
+	if layout == nil {
+		layout = func(_ Definition) string { return `schema` }
+	}
+
+	files := r.layoutFiles(layout)
+	width := len(itoa(len(files) - 1))
+	if width < 2 {
+		width = 2
+	}
+
+	for i := 0; i < len(files) && err == nil; i++ {
+		prefix := itoa(i)
+		for len(prefix) < width {
+			prefix = `0` + prefix
+		}
+
+		content := files[i].content()
+		if r.Formatting().MacroOIDs {
+			content = append([]byte(r.MacroDeclarations(files[i].defs...)), content...)
+		}
+
+		name := filepath.Join(dir, prefix+`-`+fileGroupName(files[i].group)+`.schema`)
+		err = os.WriteFile(name, content, 0644)
+	}
+
+	return
+}
+
+/*
+layoutFile describes a single file to be written by [Schema.WriteDirectory].
+*/
+type layoutFile struct {
+	group string
+	defs  []Definition
+}
+
+/*
+content returns the contents of the receiver instance, with each
+[Definition] prefixed by its type label.
+*/
+func (r layoutFile) content() (b []byte) {
+	for _, def := range r.defs {
+		b = append(b, []byte(def.Type()+` `+def.String()+string(rune(10)))...)
+	}
+
+	return
+}
+
+/*
+layoutFiles returns the sequence of files to be written, in order, based
+upon the group assignments made by layout.  Each [Definition] is appended
+to the latest file of its group unless doing so would place it before
+any of its dependencies, in which case a new file is started.
+*/
+func (r Schema) layoutFiles(layout Layout) (files []layoutFile) {
+	// file index by definition source key
+	placed := make(map[string]int, 0)
+	// latest file index by group name
+	latest := make(map[string]int, 0)
+
+	for _, defs := range r.collections() {
+		for i := 0; i < defs.Len(); i++ {
+			def := definitionAt(defs, i)
+
+			after := -1
+			for _, dep := range dependencies(def) {
+				if idx, found := placed[sourceKey(dep)]; found && idx > after {
+					after = idx
+				}
+			}
+
+			group := layout(def)
+			if len(group) == 0 {
+				group = `schema`
+			}
+
+			idx, found := latest[group]
+			if !found || idx < after {
+				idx = len(files)
+				files = append(files, layoutFile{group: group})
+				latest[group] = idx
+			}
+
+			files[idx].defs = append(files[idx].defs, def)
+			placed[sourceKey(def)] = idx
+		}
+	}
+
+	return
+}
+
+/*
+dependencies returns the definitions directly referenced by def.
+*/
+func dependencies(def Definition) (deps []Definition) {
+	ats := func(x AttributeTypes) {
+		for i := 0; i < x.Len(); i++ {
+			deps = append(deps, x.Index(i))
+		}
+	}
+	ocs := func(x ObjectClasses) {
+		for i := 0; i < x.Len(); i++ {
+			deps = append(deps, x.Index(i))
+		}
+	}
+
+	switch tv := def.(type) {
+	case MatchingRule:
+		deps = append(deps, tv.Syntax())
+	case AttributeType:
+		deps = append(deps, tv.SuperType(), tv.Equality(),
+			tv.Ordering(), tv.Substring(), tv.Syntax())
+	case MatchingRuleUse:
+		deps = append(deps, tv.Schema().MatchingRules().Get(tv.NumericOID()))
+		ats(tv.Applies())
+	case ObjectClass:
+		ocs(tv.SuperClasses())
+		ats(tv.Must())
+		ats(tv.May())
+	case DITContentRule:
+		deps = append(deps, tv.Schema().ObjectClasses().Get(tv.NumericOID()))
+		ocs(tv.Aux())
+		ats(tv.Must())
+		ats(tv.May())
+		ats(tv.Not())
+	case NameForm:
+		deps = append(deps, tv.OC())
+		ats(tv.Must())
+		ats(tv.May())
+	case DITStructureRule:
+		deps = append(deps, tv.Form())
+		for i := 0; i < tv.SuperRules().Len(); i++ {
+			deps = append(deps, tv.SuperRules().Index(i))
+		}
+	}
+
+	// discard zero references
+	for i := 0; i < len(deps); i++ {
+		if deps[i] == nil || deps[i].IsZero() {
+			deps = append(deps[:i], deps[i+1:]...)
+			i--
+		}
+	}
+
+	return
+}
+
+/*
+fileGroupName returns group with all characters other than alphanumerics,
+periods, hyphens and underscores replaced by underscores.
+*/
+func fileGroupName(group string) string {
+	b := []rune(group)
+	for i, c := range b {
+		if !(isAlnum(c) || c == '.' || c == '-' || c == '_') {
+			b[i] = '_'
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,106 @@
+package schemax
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+This example demonstrates writing definitions to a directory, grouped
+into files by their X-ORIGIN extension values.
+*/
+func ExampleSchema_WriteDirectory() {
+	dir, err := os.MkdirTemp(``, `schemax`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	sch, _ := NewSchema().Subset(`account`)
+	if err = sch.WriteDirectory(dir, LayoutByOrigin); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, `*.schema`))
+	for _, file := range files {
+		fmt.Println(filepath.Base(file))
+	}
+	// Output:
+	// 00-RFC4517.schema
+	// 01-RFC4512.schema
+	// 02-RFC4519.schema
+	// 03-RFC4524.schema
+	// 04-RFC4517.schema
+}
+
+func TestSchema_WriteDirectory(t *testing.T) {
+	dir, err := os.MkdirTemp(``, `schemax`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// SortLists would reorder lists which were not
+	// sorted at the time they were populated
+	orig := mySchema.Clone()
+	orig.Options().Unshift(SortLists)
+
+	if err = orig.WriteDirectory(dir, LayoutByOrigin); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	restored := NewEmptySchema(SortExtensions, HangingIndents)
+	if err = restored.ParseDirectory(dir); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if restored.Counters() != orig.Counters() {
+		t.Errorf("%s failed: counters mismatch:\nwant: %#v\ngot:  %#v",
+			t.Name(), orig.Counters(), restored.Counters())
+		return
+	}
+
+	cols := restored.collections()
+	for i, defs := range orig.collections() {
+		for j := 0; j < defs.Len(); j++ {
+			def := definitionAt(defs, j)
+			if got := definitionAt(cols[i], restored.index(def)); def.String() != got.String() {
+				t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), def, got)
+				return
+			}
+		}
+	}
+
+	// provenance is recorded, and can be used to write the
+	// restored definitions back out to identically named files
+	cn := restored.AttributeTypes().Get(`cn`)
+	if src := restored.SourceFile(cn); filepath.Dir(src) != dir {
+		t.Errorf("%s failed: unexpected source file %q", t.Name(), src)
+		return
+	} else if LayoutBySource(cn) != filepath.Base(src[:len(src)-len(`.schema`)]) {
+		t.Errorf("%s failed: unexpected source group %q", t.Name(), LayoutBySource(cn))
+		return
+	} else if LayoutBySource(mySchema.AttributeTypes().Get(`cn`)) != `RFC4519` {
+		t.Errorf("%s failed: origin not used in lieu of source", t.Name())
+		return
+	}
+
+	// existing schema files are not clobbered
+	if err = restored.WriteDirectory(dir, LayoutBySource); err == nil {
+		t.Errorf("%s failed: existing files overwritten", t.Name())
+		return
+	}
+
+	single := filepath.Join(dir, `single`)
+	if err = restored.WriteDirectory(single, nil); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if files, _ := filepath.Glob(filepath.Join(single, `*.schema`)); len(files) != 1 {
+		t.Errorf("%s failed: want 1 file, got %d", t.Name(), len(files))
+	}
+}